@@ -2,97 +2,206 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"slices"
 	"strconv"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 	"weather-api/internal/core/usecase"
 
+	"github.com/XSAM/otelsql"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 
 	"weather-api/internal/adapter/cache/core/metrics"
 	"weather-api/internal/adapter/cache/core/redis"
 	weathercache "weather-api/internal/adapter/cache/weather"
 	"weather-api/internal/adapter/email"
 	httphandler "weather-api/internal/adapter/handler/http"
+	"weather-api/internal/adapter/handler/http/middleware"
+	"weather-api/internal/adapter/lock"
+	appmetrics "weather-api/internal/adapter/metrics"
+	"weather-api/internal/adapter/ratelimit"
 	"weather-api/internal/adapter/repository/postgres"
+	"weather-api/internal/adapter/telegram"
 	"weather-api/internal/adapter/weather"
 	"weather-api/internal/adapter/weather/openweathermap"
 	"weather-api/internal/adapter/weather/weatherapi"
+	"weather-api/internal/adapter/webhook"
+	"weather-api/internal/adapter/webpush"
 	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
 	"weather-api/internal/core/service"
 	"weather-api/internal/util/configutil"
+	"weather-api/internal/util/cronutil"
 	"weather-api/internal/util/logger"
+	"weather-api/internal/util/netutil"
+	"weather-api/internal/util/tracing"
 )
 
 func main() {
+	if err := run(); err != nil {
+		log.Fatalf("Server stopped: %v", err)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	cfg, err := configutil.LoadConfig()
 	if err != nil {
-		log.Fatalf("Unable to load config: %v", err)
+		return fmt.Errorf("unable to load config: %w", err)
+	}
+
+	appLogger, err := logger.New(os.Stdout, logger.Options{
+		Level:  cfg.LogLevel,
+		Format: cfg.LogFormat,
+		Redact: cfg.LogRedact,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
 	}
+	slog.SetDefault(appLogger)
 
-	db, err := sql.Open("postgres", cfg.DBConnStr)
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		Exporter:    cfg.TracingExporter,
+		Endpoint:    cfg.TracingOTLPEndpoint,
+		ServiceName: "weather-api",
+		InstanceID:  cfg.InstanceID,
+		SampleRatio: cfg.TracingSampleRatio,
+		Redact:      cfg.LogRedact,
+	})
 	if err != nil {
-		log.Fatalf("Unable to connect to DB: %v", err)
+		return fmt.Errorf("unable to initialize tracing: %w", err)
 	}
 	defer func() {
-		if err := db.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			appLogger.Error("unable to flush traces", "error", err)
 		}
 	}()
 
-	m, err := migrate.New("file://migrations", cfg.DBConnStr)
+	fileLogger, err := logger.NewFileLogger("logs", "provider_responses.log", appLogger)
 	if err != nil {
-		log.Fatalf("Unable to initialize migration: %v", err)
-	}
-	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		log.Fatalf("Unable to apply migrations: %v", err)
+		return fmt.Errorf("unable to initialize file logger: %w", err)
 	}
+	defer func() {
+		if closeErr := fileLogger.Close(); closeErr != nil {
+			appLogger.Error("unable to close file logger", "error", closeErr)
+		}
+	}()
 
-	fileLogger, err := logger.NewFileLogger("logs", "provider_responses.log")
+	db, err := otelsql.Open("postgres", cfg.DBConnStr, otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL))
 	if err != nil {
-		log.Fatalf("Unable to initialize file logger: %v", err)
+		return fmt.Errorf("unable to connect to DB: %w", err)
 	}
 	defer func() {
-		if closeErr := fileLogger.Close(); closeErr != nil {
-			log.Printf("Error closing file logger: %v", closeErr)
+		if err := db.Close(); err != nil {
+			appLogger.Error("unable to close database connection", "error", err)
 		}
 	}()
 
-	emailAdapter := email.NewSender(email.SenderOptions{
+	if cfg.AutoMigrate {
+		m, err := migrate.New("file://migrations", cfg.DBConnStr)
+		if err != nil {
+			return fmt.Errorf("unable to initialize migration: %w", err)
+		}
+		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("unable to apply migrations: %w", err)
+		}
+	}
+
+	smtpOptions := email.SenderOptions{
 		Host: cfg.SMTPHost,
 		Port: cfg.SMTPPort,
 		User: cfg.SMTPUser,
 		Pass: cfg.SMTPPass,
+	}
+	emailSender, err := email.NewEmailSender(cfg.EmailBackends(), email.BackendOptions{
+		SMTP:        smtpOptions,
+		From:        cfg.EmailFrom,
+		MaildirPath: cfg.EmailMaildir,
+		Logger:      appLogger,
+		HTTPAPI: email.HTTPAPISenderOptions{
+			Endpoint:   cfg.EmailAPIEndpoint,
+			AuthHeader: cfg.EmailAPIAuthHeader,
+			AuthValue:  cfg.EmailAPIKey,
+			HTTPClient: &http.Client{Timeout: cfg.HTTPClientTimeout},
+		},
 	})
+	if err != nil {
+		return fmt.Errorf("unable to initialize email sender: %w", err)
+	}
+	emailAdapter := email.NewWorkerPool(emailSender, cfg.EmailWorkers)
 
-	httpClient := &http.Client{Timeout: cfg.HTTPClientTimeout}
+	httpClient := &http.Client{
+		Timeout:   cfg.HTTPClientTimeout,
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
 
 	weatherAPIProvider := weatherapi.NewClient(weatherapi.ClientOptions{
-		APIKey:     cfg.WeatherAPIKey,
-		BaseURL:    cfg.WeatherAPIBaseURL,
-		HTTPClient: httpClient,
-		Logger:     fileLogger,
+		APIKey:         cfg.WeatherAPIKey,
+		BaseURL:        cfg.WeatherAPIBaseURL,
+		HTTPClient:     httpClient,
+		ResponseLogger: fileLogger,
+		Logger:         appLogger,
 	})
 
 	openWeatherMapProvider := openweathermap.NewClient(openweathermap.ClientOptions{
-		APIKey:     cfg.OpenWeatherMapAPIKey,
-		BaseURL:    cfg.OpenWeatherMapBaseURL,
-		HTTPClient: httpClient,
-		Logger:     fileLogger,
+		APIKey:         cfg.OpenWeatherMapAPIKey,
+		BaseURL:        cfg.OpenWeatherMapBaseURL,
+		HTTPClient:     httpClient,
+		ResponseLogger: fileLogger,
+		Logger:         appLogger,
+	})
+
+	promRegistry := appmetrics.NewRegistry()
+	businessMetrics := appmetrics.NewBusinessMetrics(promRegistry)
+	providerMetrics := weather.NewProviderMetrics(promRegistry)
+	quotaTracker := weather.NewQuotaTracker(postgres.NewProviderUsageRepository(db, appLogger), promRegistry, weather.QuotaTrackerOptions{
+		Limits: map[string]int64{
+			weatherAPIProvider.Name():     cfg.WeatherAPIQuota,
+			openWeatherMapProvider.Name(): cfg.OpenWeatherMapQuota,
+		},
+		Cutoff: cfg.ProviderQuotaCutoff,
+		Logger: appLogger,
 	})
+	instrumentProvider := func(provider out.WeatherProvider) out.WeatherProvider {
+		return weather.NewProviderWithMetrics(weather.NewQuotaLimitedProvider(provider, quotaTracker), providerMetrics)
+	}
+
+	openWeatherMap := instrumentProvider(openWeatherMapProvider)
+	weatherAPI := instrumentProvider(weatherAPIProvider)
+	weatherAPIAlerts := weather.NewAlertProviderWithMetrics(weather.NewQuotaLimitedAlertProvider(weatherAPIProvider, quotaTracker), providerMetrics)
 
-	chainProvider := weather.NewChainWeatherProvider(openWeatherMapProvider, weatherAPIProvider)
+	chainProvider := weather.NewChainWeatherProvider(appLogger, openWeatherMap, weatherAPI).WithMetrics(providerMetrics)
 
-	subscriptionRepo := postgres.NewSubscriptionRepo(db)
-	cityRepo := postgres.NewCityRepository(db)
+	subscriptionRepo := postgres.NewSubscriptionRepo(db, appLogger)
+	cityRepo := postgres.NewCityRepository(db, appLogger)
+	alertRepo := postgres.NewAlertRepository(db, appLogger)
+	subscriberRepo := postgres.NewSubscriberRepository(db, appLogger)
+	observationRepo := postgres.NewWeatherObservationRepository(db, appLogger)
+	deliveryRepo := postgres.NewDeliveryRepository(db, appLogger)
+	auditRepo := postgres.NewAuditLogRepository(db, appLogger)
+	suppressionRepo := postgres.NewSuppressionRepository(db, appLogger)
+	webhookRepo := postgres.NewWebhookRepository(db, appLogger)
+	pushRepo := postgres.NewPushRepository(db, appLogger)
+	apiKeyRepo := postgres.NewAPIKeyRepository(db, appLogger)
 
 	redisCache := redis.NewCache(redis.CacheOptions{
 		Address:      cfg.RedisAddress,
@@ -103,73 +212,299 @@ func main() {
 		PoolSize:     cfg.RedisPoolSize,
 		MinIdleConns: cfg.RedisMinIdleConns,
 	})
+	defer func() {
+		if closeErr := redisCache.Close(); closeErr != nil {
+			appLogger.Error("unable to close redis client", "error", closeErr)
+		}
+	}()
 
-	promRegistry := prometheus.NewRegistry()
-	cacheMetrics := weathercache.NewCacheMetrics(promRegistry)
+	cacheMetrics := weathercache.NewCacheMetrics(promRegistry, metrics.WithHotKeys(cfg.CacheHotKeys))
 	cacheWithMetrics := metrics.NewCacheWithMetrics(redisCache, cacheMetrics)
 	weatherCache := weathercache.NewCache(cacheWithMetrics)
 
-	cachedProvider := weather.NewCachedWeatherProvider(weatherCache, chainProvider)
+	cachedProvider := weather.NewCachedWeatherProvider(weatherCache, chainProvider, appLogger)
 	weatherService := service.NewWeatherService(cachedProvider)
-	tokenService := service.NewTokenService(subscriptionRepo)
-	emailService := service.NewEmailService(emailAdapter)
-	cityService := service.NewCityService(cityRepo, cachedProvider)
+	tokenService := service.NewTokenService(subscriptionRepo, appLogger)
+	emailService := service.NewEmailService(emailAdapter, suppressionRepo, subscriptionRepo, appLogger)
+	cityService := service.NewCityService(cityRepo, cachedProvider, appLogger)
 
 	weatherUseCase := usecase.NewWeatherUseCase(cachedProvider)
-	subscriptionService := service.NewSubscriptionService(subscriptionRepo, cityRepo, chainProvider, tokenService, emailService)
-	subscribeUseCase := usecase.NewSubscribeUseCase(subscriptionRepo, subscriptionService, cityService, emailService)
-	confirmUseCase := usecase.NewConfirmSubscriptionUseCase(subscriptionRepo, tokenService, emailService)
-	unsubscribeUseCase := usecase.NewUnsubscribeUseCase(subscriptionRepo, tokenService)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, cityRepo, chainProvider, tokenService, emailService, appLogger)
+	subscriberService := service.NewSubscriberService(subscriberRepo, tokenService, appLogger)
+	subscribeUseCase := usecase.NewSubscribeUseCase(subscriptionRepo, subscriptionService, subscriberService, cityService, emailService, businessMetrics, appLogger)
+	schedules := service.Schedules{
+		Hourly:   cfg.ScheduleHourly,
+		Daily:    cfg.ScheduleDaily,
+		Weekly:   cfg.ScheduleWeekly,
+		Weekdays: cfg.ScheduleWeekdays,
+	}
+	confirmUseCase := usecase.NewConfirmSubscriptionUseCase(subscriptionRepo, tokenService, emailService, schedules, businessMetrics, appLogger)
+	unsubscribeUseCase := usecase.NewUnsubscribeUseCase(subscriptionRepo, tokenService, businessMetrics, appLogger)
+	manageSubscriberUseCase := usecase.NewManageSubscriberUseCase(subscriberRepo, subscriptionRepo, businessMetrics, appLogger)
+
+	weatherUpdateService := service.NewWeatherUpdateService(subscriptionService, weatherService, observationRepo, appLogger)
+	alertUseCase := usecase.NewAlertUseCase(weather.NewCachedAlertProvider(weatherCache, weatherAPIAlerts, appLogger))
+
+	notifiers := service.Notifiers{
+		domain.ChannelEmail: service.NewEmailNotifier(emailService),
+		domain.ChannelWebhook: service.NewWebhookNotifier(
+			webhook.NewHTTPSender(&http.Client{
+				Timeout:   cfg.WebhookTimeout,
+				Transport: otelhttp.NewTransport(netutil.PublicTransport(cfg.WebhookTimeout)),
+			}),
+			webhookRepo,
+			service.WebhookRetryOptions{
+				MaxAttempts:    cfg.WebhookMaxAttempts,
+				InitialBackoff: cfg.WebhookInitialBackoff,
+				MaxBackoff:     cfg.WebhookMaxBackoff,
+				RetryBudget:    cfg.WebhookRetryBudget,
+				DisableAfter:   cfg.WebhookDisableAfter,
+			},
+			appLogger,
+		),
+	}
+	if cfg.PushEnabled() {
+		pushSender, pushErr := webpush.NewSender(webpush.SenderOptions{
+			VAPIDPublicKey:  cfg.VAPIDPublicKey,
+			VAPIDPrivateKey: cfg.VAPIDPrivateKey,
+			Subject:         cfg.VAPIDSubject,
+			TTL:             cfg.PushTTL,
+			HTTPClient: &http.Client{
+				Timeout:   cfg.HTTPClientTimeout,
+				Transport: otelhttp.NewTransport(netutil.PublicTransport(cfg.HTTPClientTimeout)),
+			},
+		})
+		if pushErr != nil {
+			return fmt.Errorf("unable to initialize web push: %w", pushErr)
+		}
+		notifiers[domain.ChannelPush] = service.NewPushNotifier(pushSender, pushRepo, appLogger)
+	}
+	var telegramClient *telegram.Client
+	if cfg.TelegramBotToken != "" {
+		telegramClient = telegram.NewClient(telegram.ClientOptions{
+			BaseURL:    cfg.TelegramAPIBaseURL,
+			Token:      cfg.TelegramBotToken,
+			HTTPClient: &http.Client{Timeout: cfg.TelegramPollTimeout + cfg.HTTPClientTimeout},
+		})
+		notifiers[domain.ChannelTelegram] = telegram.NewNotifier(telegramClient)
+	}
+
+	alertService := service.NewAlertService(subscriptionRepo, alertRepo, weatherAPIAlerts, notifiers, appLogger)
+
+	locker, err := lock.NewLocker(cfg.LeaderElection, db, redisCache.Client(), cfg.InstanceID)
+	if err != nil {
+		return fmt.Errorf("unable to initialize leader election: %w", err)
+	}
+	elector := service.NewLeaderElector(locker, service.LeaderElectionOptions{
+		LockName:   "scheduler",
+		InstanceID: cfg.InstanceID,
+		TTL:        cfg.LeaderLockTTL,
+		Metrics:    lock.NewLeaderMetrics(promRegistry, cfg.InstanceID),
+		Logger:     appLogger,
+	})
+
+	schedulerService := service.NewSchedulerService(weatherUpdateService, notifiers, subscriptionRepo, deliveryRepo, schedules, businessMetrics, appLogger)
+	triggeredSender := service.NewTriggeredSender(schedulerService, postgres.NewSendTriggerRepository(db, appLogger), appLogger)
+	adminUseCase := usecase.NewAdminUseCase(subscriptionRepo, cityRepo, deliveryRepo, auditRepo, triggeredSender, schedules, businessMetrics, appLogger)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(apiKeyRepo, auditRepo, appLogger)
+	var bounceSource out.BounceSource
+	if cfg.BounceMailboxDir != "" {
+		bounceSource = email.NewMailboxBounceSource(cfg.BounceMailboxDir, appLogger)
+	}
+	bounceUseCase := usecase.NewBounceUseCase(suppressionRepo, subscriptionRepo, bounceSource, appLogger)
+	webhookUseCase := usecase.NewWebhookUseCase(subscriptionRepo, webhookRepo, tokenService, appLogger)
+	pushUseCase := usecase.NewPushUseCase(subscriptionRepo, pushRepo, tokenService, appLogger)
+	previewUseCase := usecase.NewPreviewUseCase(subscriptionRepo, weatherUpdateService, emailService, auditRepo, appLogger)
 
-	weatherUpdateService := service.NewWeatherUpdateService(subscriptionService, weatherService)
+	healthService := service.NewHealthService(cfg.HealthCheckTimeout, appLogger)
+	healthService.Register(postgres.NewHealthCheck(db), service.HealthCheckOptions{Critical: true, CacheTTL: cfg.HealthCacheTTL})
+	healthService.Register(redis.NewHealthCheck(redisCache), service.HealthCheckOptions{CacheTTL: cfg.HealthCacheTTL})
+	if slices.Contains(cfg.EmailBackends(), email.BackendSMTP) {
+		healthService.Register(email.NewHealthCheck(smtpOptions), service.HealthCheckOptions{CacheTTL: cfg.HealthCacheTTL})
+	}
+	// Probes go through the quota tracker too: they are billable calls, and a
+	// provider past its cutoff is reported as down.
+	for _, provider := range []out.WeatherProvider{openWeatherMap, weatherAPI} {
+		healthService.Register(weather.NewProviderHealthCheck(provider, cfg.HealthProviderCity), service.HealthCheckOptions{CacheTTL: cfg.HealthProviderTTL})
+	}
 
 	weatherHandler := httphandler.NewWeatherHandler(weatherUseCase)
-	subscriptionHandler := httphandler.NewSubscriptionHandler(subscribeUseCase, confirmUseCase, unsubscribeUseCase)
+	subscriptionHandler := httphandler.NewSubscriptionHandler(subscribeUseCase, confirmUseCase, unsubscribeUseCase, appLogger)
+	alertHandler := httphandler.NewAlertHandler(alertUseCase, appLogger)
+	subscriberHandler := httphandler.NewSubscriberHandler(manageSubscriberUseCase, appLogger)
+	healthHandler := httphandler.NewHealthHandler(healthService)
+	adminHandler := httphandler.NewAdminHandler(adminUseCase, appLogger)
+	apiKeyHandler := httphandler.NewAPIKeyHandler(apiKeyUseCase, appLogger)
+	previewHandler := httphandler.NewPreviewHandler(previewUseCase, appLogger)
+	webhookHandler := httphandler.NewWebhookHandler(webhookUseCase, appLogger)
 
-	r := gin.Default()
+	r := gin.New()
+	// Lets handlers pass *gin.Context to use cases while loggers still see
+	// the request ID stored in the request context.
+	r.ContextWithFallback = true
+	// Client IPs key the rate limits, so X-Forwarded-For is only honoured from
+	// configured proxies.
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	r.Use(gin.Recovery(), middleware.RequestID(), otelgin.Middleware("weather-api"), middleware.Metrics(promRegistry), middleware.AccessLog(appLogger), middleware.Problems(appLogger))
 
 	r.Static("/web", "./web")
+	r.StaticFile("/sw.js", "./web/sw.js")
+
+	r.GET("/healthz", healthHandler.Liveness)
+	r.GET("/readyz", healthHandler.Readiness)
+
+	// /api/metrics is kept for scrapers configured before both paths served the same registry.
+	metricsHandler := gin.WrapH(promhttp.HandlerFor(promRegistry, promhttp.HandlerOpts{Registry: promRegistry}))
+	r.GET("/metrics", metricsHandler)
+	r.GET("/api/metrics", metricsHandler)
+
+	adminAuth := middleware.AdminAuthOptions{
+		APIKey:   cfg.AdminAPIKey,
+		Username: cfg.AdminUsername,
+		Password: cfg.AdminPassword,
+	}
+	if adminAuth.Enabled() {
+		admin := r.Group("/admin/api", middleware.AdminAuth(adminAuth))
+		{
+			admin.GET("/subscriptions", adminHandler.SearchSubscriptions)
+			admin.GET("/subscriptions/:id/deliveries", adminHandler.GetDeliveryHistory)
+			admin.POST("/subscriptions/:id/confirm", adminHandler.ConfirmSubscription)
+			admin.DELETE("/subscriptions/:id", adminHandler.DeleteSubscription)
+			admin.GET("/cities", adminHandler.ListCities)
+			admin.POST("/send", adminHandler.TriggerSend)
+			admin.GET("/keys", apiKeyHandler.ListKeys)
+			admin.POST("/keys", apiKeyHandler.IssueKey)
+			admin.GET("/keys/:id/usage", apiKeyHandler.GetUsage)
+			admin.DELETE("/keys/:id", apiKeyHandler.RevokeKey)
+		}
+
+		preview := r.Group("/admin/preview", middleware.AdminAuth(adminAuth))
+		{
+			preview.GET("/update", previewHandler.PreviewUpdate)
+			preview.GET("/confirm", previewHandler.PreviewConfirmation)
+		}
+	} else {
+		appLogger.Info("admin API is disabled: set ADMIN_API_KEY or ADMIN_USERNAME and ADMIN_PASSWORD to enable it")
+	}
+
+	rateLimitOptions, err := parseRateLimits(cfg)
+	if err != nil {
+		return err
+	}
+	rateLimiter := ratelimit.NewFallbackLimiter(
+		ratelimit.NewRedisLimiter(redisCache.Client()), ratelimit.NewMemoryLimiter(), appLogger)
 
-	api := r.Group("/api")
-	{
-		api.GET("/weather", weatherHandler.GetWeather)
-		api.POST("/subscribe", subscriptionHandler.Subscribe)
-		api.GET("/confirm/:token", subscriptionHandler.Confirm)
-		api.GET("/unsubscribe/:token", subscriptionHandler.Unsubscribe)
-		api.GET("/metrics", gin.WrapH(promhttp.HandlerFor(promRegistry, promhttp.HandlerOpts{})))
+	apiHandlers := httphandler.APIHandlers{
+		Weather:      weatherHandler,
+		Alert:        alertHandler,
+		Subscription: subscriptionHandler,
+		Subscriber:   subscriberHandler,
+		Webhook:      webhookHandler,
+		APIKeys: middleware.APIKeyAuth(apiKeyUseCase, middleware.APIKeyAuthOptions{
+			Required: cfg.APIKeyRequired,
+			Limiter:  rateLimiter,
+			Logger:   appLogger,
+		}),
+		RateLimits: httphandler.NewRateLimits(rateLimiter, rateLimitOptions, appLogger),
 	}
 
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	if cfg.PushEnabled() {
+		apiHandlers.Push = httphandler.NewPushHandler(pushUseCase, cfg.VAPIDPublicKey, appLogger)
+	} else {
+		appLogger.Info("web push is disabled: set VAPID_PUBLIC_KEY, VAPID_PRIVATE_KEY and VAPID_SUBJECT to enable it")
+	}
+
+	var telegramBot *telegram.Bot
+	if telegramClient != nil {
+		telegramChatUseCase := usecase.NewTelegramChatUseCase(subscriberRepo, subscriptionRepo, businessMetrics, appLogger)
+		telegramBot = telegram.NewBot(telegramClient, subscribeUseCase, confirmUseCase, weatherUseCase, telegramChatUseCase, appLogger)
+		if cfg.TelegramMode == "webhook" {
+			apiHandlers.TelegramWebhook = telegramBot.WebhookHandler(cfg.TelegramWebhookSecret)
+		}
+	} else {
+		appLogger.Info("telegram bot is disabled: set TELEGRAM_BOT_TOKEN to enable it")
+	}
+
+	if cfg.BounceWebhookSecret != "" {
+		apiHandlers.Bounce = httphandler.NewBounceHandler(bounceUseCase, appLogger)
+		apiHandlers.BounceSecret = cfg.BounceWebhookSecret
+	} else {
+		appLogger.Info("bounce webhook is disabled: set BOUNCE_WEBHOOK_SECRET to enable it")
+	}
+
+	httphandler.RegisterAPI(r, apiHandlers)
 
 	r.NoRoute(func(c *gin.Context) {
 		c.File("./web/index.html")
 	})
 
-	schedulerService := service.NewSchedulerService(weatherUpdateService, emailService)
-	cron := cron.New()
-	_, err = cron.AddFunc("* * * * *", func() {
-		updateErr := schedulerService.SendWeatherUpdates(context.Background(), domain.FrequencyHourly)
-		if updateErr != nil {
-			log.Printf("Unable to send hourly weather updates: %v", updateErr)
-		}
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+	electorDone := make(chan struct{})
+	go func() {
+		elector.Run(jobsCtx)
+		close(electorDone)
+	}()
+
+	scheduler := cronutil.NewScheduler(appLogger)
+	err = scheduler.AddJob("weather updates", cfg.DispatchSchedule, func() {
+		elector.RunIfLeader(func(ctx context.Context) {
+			updateErr := schedulerService.SendDueUpdates(ctx, time.Now())
+			if updateErr != nil {
+				appLogger.ErrorContext(ctx, "unable to send scheduled weather updates", "error", updateErr)
+			}
+		})
 	})
 	if err != nil {
-		log.Printf("Unable to add hourly cron job: %v", err)
-		return
+		return fmt.Errorf("unable to add weather updates cron job: %w", err)
 	}
 
-	_, err = cron.AddFunc("0 0 * * *", func() {
-		updateErr := schedulerService.SendWeatherUpdates(context.Background(), domain.FrequencyDaily)
-		if updateErr != nil {
-			log.Printf("Unable to send daily weather updates: %v", updateErr)
-		}
+	err = scheduler.AddJob("triggered sends", cfg.TriggeredSendSchedule, func() {
+		elector.RunIfLeader(func(ctx context.Context) {
+			if triggerErr := triggeredSender.RunQueued(ctx); triggerErr != nil {
+				appLogger.ErrorContext(ctx, "unable to run triggered sends", "error", triggerErr)
+			}
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add triggered sends cron job: %w", err)
+	}
+
+	err = scheduler.AddJob("severe weather alerts", cfg.AlertsSchedule, func() {
+		elector.RunIfLeader(func(ctx context.Context) {
+			if alertErr := alertService.ProcessAlerts(ctx); alertErr != nil {
+				appLogger.ErrorContext(ctx, "unable to process severe weather alerts", "error", alertErr)
+			}
+		})
 	})
 	if err != nil {
-		log.Printf("Unable to add daily cron job: %v", err)
-		return
+		return fmt.Errorf("unable to add alerts cron job: %w", err)
+	}
+
+	if cfg.BounceMailboxDir != "" {
+		err = scheduler.AddJob("bounce mailbox", cfg.BounceSchedule, func() {
+			elector.RunIfLeader(func(ctx context.Context) {
+				if bounceErr := bounceUseCase.ProcessMailbox(ctx); bounceErr != nil {
+					appLogger.ErrorContext(ctx, "unable to process bounce mailbox", "error", bounceErr)
+				}
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("unable to add bounce mailbox cron job: %w", err)
+		}
 	}
 
-	cron.Start()
+	scheduler.Start()
+
+	telegramDone := make(chan struct{})
+	go func() {
+		if telegramBot != nil && cfg.TelegramMode == "polling" {
+			telegramBot.Poll(jobsCtx, cfg.TelegramPollTimeout)
+		}
+		close(telegramDone)
+	}()
 
 	port := strconv.Itoa(cfg.Port)
 
@@ -180,8 +515,69 @@ func main() {
 		WriteTimeout: cfg.HTTPWriteTimeout,
 	}
 
-	log.Printf("Server running on %s", srv.Addr)
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("Server error: %v", err)
+	serverErr := make(chan error, 1)
+	go func() {
+		appLogger.Info("server running", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case <-ctx.Done():
+		appLogger.Info("shutdown signal received")
+	case err = <-serverErr:
+		appLogger.Error("server error", "error", err)
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+
+	if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil {
+		appLogger.Error("unable to shut down HTTP server", "error", shutdownErr)
+	}
+
+	jobsDone := scheduler.Stop()
+	select {
+	case <-jobsDone.Done():
+	case <-shutdownCtx.Done():
+		appLogger.Warn("scheduled jobs did not finish before the shutdown deadline, cancelling them")
+	}
+	cancelJobs()
+	<-jobsDone.Done()
+	<-electorDone
+	<-telegramDone
+
+	// The shutdown deadline may already be spent on jobs, so draining the
+	// emails they queued gets a deadline of its own.
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.EmailDrainTimeout)
+	defer cancelDrain()
+	if drainErr := emailAdapter.Shutdown(drainCtx); drainErr != nil {
+		appLogger.Error("unable to drain email workers", "error", drainErr)
+	}
+
+	appLogger.Info("server stopped")
+	return err
+}
+
+func parseRateLimits(cfg *configutil.Config) (httphandler.RateLimitOptions, error) {
+	var opts httphandler.RateLimitOptions
+	for _, limit := range []struct {
+		env  string
+		spec string
+		dst  *ratelimit.Limit
+	}{
+		{"RATE_LIMIT_WEATHER", cfg.RateLimitWeather, &opts.Weather},
+		{"RATE_LIMIT_ALERTS", cfg.RateLimitAlerts, &opts.Alerts},
+		{"RATE_LIMIT_SUBSCRIBE_IP", cfg.RateLimitSubscribeIP, &opts.SubscribeIP},
+		{"RATE_LIMIT_SUBSCRIBE_EMAIL", cfg.RateLimitRecipient, &opts.SubscribeEmail},
+	} {
+		parsed, err := ratelimit.ParseLimit(limit.spec)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s: %w", limit.env, err)
+		}
+		*limit.dst = parsed
 	}
+	return opts, nil
 }
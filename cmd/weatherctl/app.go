@@ -0,0 +1,270 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/user"
+	"weather-api/internal/adapter/cache/core/redis"
+	weathercache "weather-api/internal/adapter/cache/weather"
+	"weather-api/internal/adapter/email"
+	"weather-api/internal/adapter/repository/postgres"
+	"weather-api/internal/adapter/telegram"
+	"weather-api/internal/adapter/weather"
+	"weather-api/internal/adapter/weather/openweathermap"
+	"weather-api/internal/adapter/weather/weatherapi"
+	"weather-api/internal/adapter/webhook"
+	"weather-api/internal/adapter/webpush"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/core/service"
+	"weather-api/internal/core/usecase"
+	"weather-api/internal/util/configutil"
+	"weather-api/internal/util/logger"
+	"weather-api/internal/util/netutil"
+
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// app wires the same adapters and services as cmd/server without starting
+// the HTTP server or the scheduler.
+type app struct {
+	cfg        *configutil.Config
+	db         *sql.DB
+	redisCache *redis.Cache
+	fileLogger *logger.FileLogger
+	logger     *slog.Logger
+
+	providers           []out.WeatherProvider
+	weatherCache        *weathercache.Cache
+	subscriptionRepo    *postgres.SubscriptionRepository
+	cityRepo            *postgres.CityRepo
+	auditRepo           *postgres.AuditLogRepository
+	suppressionRepo     *postgres.SuppressionRepository
+	subscriptionService *service.SubscriptionServiceImpl
+	subscriberService   *service.SubscriberServiceImpl
+	weatherUpdates      *service.WeatherUpdateServiceImpl
+	emailService        *service.EmailServiceImpl
+	schedulerService    *service.SchedulerService
+	adminUseCase        *usecase.AdminUseCase
+	schedules           service.Schedules
+	actor               string
+}
+
+func newApp() (*app, error) {
+	cfg, err := configutil.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load config: %w", err)
+	}
+
+	// Logs go to stderr so command output on stdout stays machine-readable.
+	appLogger, err := logger.New(os.Stderr, logger.Options{
+		Level:  cfg.LogLevel,
+		Format: cfg.LogFormat,
+		Redact: cfg.LogRedact,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	slog.SetDefault(appLogger)
+
+	fileLogger, err := logger.NewFileLogger("logs", "provider_responses.log", appLogger)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize file logger: %w", err)
+	}
+
+	db, err := sql.Open("postgres", cfg.DBConnStr)
+	if err != nil {
+		_ = fileLogger.Close()
+		return nil, fmt.Errorf("unable to connect to DB: %w", err)
+	}
+
+	redisCache := redis.NewCache(redis.CacheOptions{
+		Address:      cfg.RedisAddress,
+		TTL:          cfg.RedisTTL,
+		DialTimeout:  cfg.RedisDialTimeout,
+		ReadTimeout:  cfg.RedisReadTimeout,
+		WriteTimeout: cfg.RedisWriteTimeout,
+		PoolSize:     cfg.RedisPoolSize,
+		MinIdleConns: cfg.RedisMinIdleConns,
+	})
+
+	httpClient := &http.Client{Timeout: cfg.HTTPClientTimeout}
+	weatherAPIProvider := weatherapi.NewClient(weatherapi.ClientOptions{
+		APIKey:         cfg.WeatherAPIKey,
+		BaseURL:        cfg.WeatherAPIBaseURL,
+		HTTPClient:     httpClient,
+		ResponseLogger: fileLogger,
+		Logger:         appLogger,
+	})
+	openWeatherMapProvider := openweathermap.NewClient(openweathermap.ClientOptions{
+		APIKey:         cfg.OpenWeatherMapAPIKey,
+		BaseURL:        cfg.OpenWeatherMapBaseURL,
+		HTTPClient:     httpClient,
+		ResponseLogger: fileLogger,
+		Logger:         appLogger,
+	})
+
+	// Metrics aren't exported from the CLI, but the quota is shared with the
+	// server through the usage table, so calls made here count against it.
+	promRegistry := prometheus.NewRegistry()
+	providerMetrics := weather.NewProviderMetrics(promRegistry)
+	quotaTracker := weather.NewQuotaTracker(postgres.NewProviderUsageRepository(db, appLogger), promRegistry, weather.QuotaTrackerOptions{
+		Limits: map[string]int64{
+			weatherAPIProvider.Name():     cfg.WeatherAPIQuota,
+			openWeatherMapProvider.Name(): cfg.OpenWeatherMapQuota,
+		},
+		Cutoff: cfg.ProviderQuotaCutoff,
+		Logger: appLogger,
+	})
+	instrumentProvider := func(provider out.WeatherProvider) out.WeatherProvider {
+		return weather.NewProviderWithMetrics(weather.NewQuotaLimitedProvider(provider, quotaTracker), providerMetrics)
+	}
+
+	openWeatherMap := instrumentProvider(openWeatherMapProvider)
+	weatherAPI := instrumentProvider(weatherAPIProvider)
+	providers := []out.WeatherProvider{openWeatherMap, weatherAPI}
+
+	emailSender, err := email.NewEmailSender(cfg.EmailBackends(), email.BackendOptions{
+		SMTP: email.SenderOptions{
+			Host: cfg.SMTPHost,
+			Port: cfg.SMTPPort,
+			User: cfg.SMTPUser,
+			Pass: cfg.SMTPPass,
+		},
+		From:        cfg.EmailFrom,
+		MaildirPath: cfg.EmailMaildir,
+		Logger:      appLogger,
+		HTTPAPI: email.HTTPAPISenderOptions{
+			Endpoint:   cfg.EmailAPIEndpoint,
+			AuthHeader: cfg.EmailAPIAuthHeader,
+			AuthValue:  cfg.EmailAPIKey,
+			HTTPClient: httpClient,
+		},
+	})
+	if err != nil {
+		_ = redisCache.Close()
+		_ = db.Close()
+		_ = fileLogger.Close()
+		return nil, fmt.Errorf("unable to initialize email sender: %w", err)
+	}
+
+	schedules := service.Schedules{
+		Hourly:   cfg.ScheduleHourly,
+		Daily:    cfg.ScheduleDaily,
+		Weekly:   cfg.ScheduleWeekly,
+		Weekdays: cfg.ScheduleWeekdays,
+	}
+
+	subscriptionRepo := postgres.NewSubscriptionRepo(db, appLogger)
+	cityRepo := postgres.NewCityRepository(db, appLogger)
+	subscriberRepo := postgres.NewSubscriberRepository(db, appLogger)
+	observationRepo := postgres.NewWeatherObservationRepository(db, appLogger)
+	deliveryRepo := postgres.NewDeliveryRepository(db, appLogger)
+	auditRepo := postgres.NewAuditLogRepository(db, appLogger)
+	suppressionRepo := postgres.NewSuppressionRepository(db, appLogger)
+	webhookRepo := postgres.NewWebhookRepository(db, appLogger)
+	pushRepo := postgres.NewPushRepository(db, appLogger)
+
+	weatherCache := weathercache.NewCache(redisCache)
+	chainProvider := weather.NewChainWeatherProvider(appLogger, openWeatherMap, weatherAPI).WithMetrics(providerMetrics)
+	cachedProvider := weather.NewCachedWeatherProvider(weatherCache, chainProvider, appLogger)
+	weatherService := service.NewWeatherService(cachedProvider)
+	tokenService := service.NewTokenService(subscriptionRepo, appLogger)
+	emailService := service.NewEmailService(emailSender, suppressionRepo, subscriptionRepo, appLogger)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, cityRepo, chainProvider, tokenService, emailService, appLogger)
+	subscriberService := service.NewSubscriberService(subscriberRepo, tokenService, appLogger)
+	weatherUpdates := service.NewWeatherUpdateService(subscriptionService, weatherService, observationRepo, appLogger)
+	notifiers := service.Notifiers{
+		domain.ChannelEmail: service.NewEmailNotifier(emailService),
+		domain.ChannelWebhook: service.NewWebhookNotifier(
+			webhook.NewHTTPSender(&http.Client{
+				Timeout:   cfg.WebhookTimeout,
+				Transport: netutil.PublicTransport(cfg.WebhookTimeout),
+			}),
+			webhookRepo,
+			service.WebhookRetryOptions{
+				MaxAttempts:    cfg.WebhookMaxAttempts,
+				InitialBackoff: cfg.WebhookInitialBackoff,
+				MaxBackoff:     cfg.WebhookMaxBackoff,
+				RetryBudget:    cfg.WebhookRetryBudget,
+				DisableAfter:   cfg.WebhookDisableAfter,
+			},
+			appLogger,
+		),
+	}
+	if cfg.PushEnabled() {
+		pushSender, err := webpush.NewSender(webpush.SenderOptions{
+			VAPIDPublicKey:  cfg.VAPIDPublicKey,
+			VAPIDPrivateKey: cfg.VAPIDPrivateKey,
+			Subject:         cfg.VAPIDSubject,
+			TTL:             cfg.PushTTL,
+			HTTPClient: &http.Client{
+				Timeout:   cfg.HTTPClientTimeout,
+				Transport: netutil.PublicTransport(cfg.HTTPClientTimeout),
+			},
+		})
+		if err != nil {
+			_ = redisCache.Close()
+			_ = db.Close()
+			_ = fileLogger.Close()
+			return nil, fmt.Errorf("unable to initialize web push: %w", err)
+		}
+		notifiers[domain.ChannelPush] = service.NewPushNotifier(pushSender, pushRepo, appLogger)
+	}
+	if cfg.TelegramBotToken != "" {
+		notifiers[domain.ChannelTelegram] = telegram.NewNotifier(telegram.NewClient(telegram.ClientOptions{
+			BaseURL:    cfg.TelegramAPIBaseURL,
+			Token:      cfg.TelegramBotToken,
+			HTTPClient: &http.Client{Timeout: cfg.HTTPClientTimeout},
+		}))
+	}
+	schedulerService := service.NewSchedulerService(weatherUpdates, notifiers, subscriptionRepo, deliveryRepo, schedules, nil, appLogger)
+
+	return &app{
+		cfg:                 cfg,
+		db:                  db,
+		redisCache:          redisCache,
+		fileLogger:          fileLogger,
+		logger:              appLogger,
+		providers:           providers,
+		weatherCache:        weatherCache,
+		subscriptionRepo:    subscriptionRepo,
+		cityRepo:            cityRepo,
+		auditRepo:           auditRepo,
+		suppressionRepo:     suppressionRepo,
+		subscriptionService: subscriptionService,
+		subscriberService:   subscriberService,
+		weatherUpdates:      weatherUpdates,
+		emailService:        emailService,
+		schedulerService:    schedulerService,
+		adminUseCase:        usecase.NewAdminUseCase(subscriptionRepo, cityRepo, deliveryRepo, auditRepo, schedulerService, schedules, nil, appLogger),
+		schedules:           schedules,
+		actor:               cliActor(),
+	}, nil
+}
+
+func (a *app) Close() {
+	if err := a.redisCache.Close(); err != nil {
+		a.logger.Error("unable to close redis client", "error", err)
+	}
+	if err := a.db.Close(); err != nil {
+		a.logger.Error("unable to close database connection", "error", err)
+	}
+	if err := a.fileLogger.Close(); err != nil {
+		a.logger.Error("unable to close file logger", "error", err)
+	}
+}
+
+func cliActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "weatherctl:" + u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return "weatherctl:" + name
+	}
+	return "weatherctl"
+}
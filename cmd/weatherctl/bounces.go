@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"weather-api/internal/adapter/email"
+	"weather-api/internal/core/usecase"
+)
+
+func runBounces(args []string) error {
+	_, args, err := subcommand(args, "process")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("bounces process", flag.ContinueOnError)
+	dir := fs.String("dir", "", "maildir with bounce messages (defaults to BOUNCE_MAILBOX_DIR)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := newApp()
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if *dir == "" {
+		*dir = a.cfg.BounceMailboxDir
+	}
+	if *dir == "" {
+		return errors.New("--dir or BOUNCE_MAILBOX_DIR is required")
+	}
+
+	ctx := context.Background()
+	bounces := usecase.NewBounceUseCase(a.suppressionRepo, a.subscriptionRepo, email.NewMailboxBounceSource(*dir, a.logger), a.logger)
+	if err := bounces.ProcessMailbox(ctx); err != nil {
+		return err
+	}
+	a.audit(ctx, "process_bounces", *dir)
+	fmt.Printf("Processed bounce mailbox %s\n", *dir)
+	return nil
+}
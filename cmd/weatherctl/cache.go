@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+func runCache(args []string) error {
+	_, args, err := subcommand(args, "purge")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("cache purge", flag.ContinueOnError)
+	city := fs.String("city", "", "city whose cached weather should be removed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *city == "" {
+		return errors.New("--city is required")
+	}
+
+	a, err := newApp()
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx := context.Background()
+	if err := a.weatherCache.Delete(ctx, *city); err != nil {
+		return err
+	}
+	a.audit(ctx, "purge_cache", *city)
+	fmt.Printf("Purged cached weather for %s\n", *city)
+	return nil
+}
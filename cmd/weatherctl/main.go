@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: weatherctl <command> <subcommand> [flags]
+
+Commands:
+  migrate up|down|status         Apply, roll back or inspect database migrations
+  subs list|export|import|delete Manage subscriptions
+  send                           Send weather updates for a frequency
+  cache purge                    Remove a city from the weather cache
+  provider check                 Query each weather provider for a city
+  bounces process                Suppress addresses from DSN and complaint reports in a maildir
+  push keys                      Generate a VAPID key pair for Web Push
+`
+
+type command func(args []string) error
+
+func main() {
+	commands := map[string]command{
+		"migrate":  runMigrate,
+		"subs":     runSubs,
+		"send":     runSend,
+		"cache":    runCache,
+		"provider": runProvider,
+		"bounces":  runBounces,
+		"push":     runPush,
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "weatherctl %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func subcommand(args []string, names ...string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("expected one of %v", names)
+	}
+	for _, name := range names {
+		if args[0] == name {
+			return name, args[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("unknown subcommand %q, expected one of %v", args[0], names)
+}
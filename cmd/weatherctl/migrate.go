@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+func runMigrate(args []string) error {
+	name, args, err := subcommand(args, "up", "down", "status")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("migrate "+name, flag.ContinueOnError)
+	dsn := fs.String("database", os.Getenv("DB_CONN_STR"), "Postgres connection string (defaults to DB_CONN_STR)")
+	path := fs.String("path", "migrations", "directory with migration files")
+	steps := fs.Int("steps", 1, "number of migrations to roll back (down only)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dsn == "" {
+		return errors.New("database connection string is required")
+	}
+
+	m, err := migrate.New("file://"+*path, *dsn)
+	if err != nil {
+		return fmt.Errorf("unable to initialize migration: %w", err)
+	}
+	defer func() {
+		_, _ = m.Close()
+	}()
+
+	switch name {
+	case "up":
+		err = m.Up()
+	case "down":
+		if *steps < 1 {
+			return errors.New("steps must be positive")
+		}
+		err = m.Steps(-*steps)
+	case "status":
+		return printMigrationStatus(m)
+	}
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("No change")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(m)
+}
+
+func printMigrationStatus(m *migrate.Migrate) error {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Println("No migrations applied")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Version: %d\nDirty: %t\n", version, dirty)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+func runProvider(args []string) error {
+	_, args, err := subcommand(args, "check")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("provider check", flag.ContinueOnError)
+	city := fs.String("city", "Kyiv", "city to request from every provider")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := newApp()
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx := context.Background()
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tSTATUS\tTEMPERATURE\tLATENCY")
+	for _, provider := range a.providers {
+		start := time.Now()
+		weather, err := provider.GetWeather(ctx, *city)
+		latency := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "%s\terror: %v\t-\t%s\n", provider.Name(), err, latency)
+			continue
+		}
+		fmt.Fprintf(w, "%s\tok\t%.1f°C\t%s\n", provider.Name(), weather.Temperature, latency)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d providers failed", failed, len(a.providers))
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"weather-api/internal/adapter/webpush"
+)
+
+func runPush(args []string) error {
+	_, args, err := subcommand(args, "keys")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("push keys", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Key generation needs no database, so the app is not initialized.
+	publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("VAPID_PUBLIC_KEY=%s\nVAPID_PRIVATE_KEY=%s\n", publicKey, privateKey)
+	return nil
+}
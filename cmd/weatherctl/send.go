@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"weather-api/internal/adapter/email"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+func runSend(args []string) error {
+	fs := flag.NewFlagSet("send", flag.ContinueOnError)
+	frequency := fs.String("frequency", "", "frequency to send updates for (hourly, daily, weekly, ...)")
+	dryRun := fs.Bool("dry-run", false, "render the emails instead of sending them")
+	outDir := fs.String("out", "", "with --dry-run, write each rendered email to this directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !domain.Frequency(*frequency).IsValid() {
+		return errors.New("--frequency is required and must be a valid frequency")
+	}
+
+	a, err := newApp()
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx := context.Background()
+	if *dryRun {
+		return dryRunSend(ctx, a, domain.Frequency(*frequency), *outDir)
+	}
+
+	if err := a.schedulerService.SendWeatherUpdates(ctx, domain.Frequency(*frequency)); err != nil {
+		return err
+	}
+	a.audit(ctx, "trigger_send", *frequency)
+	fmt.Printf("Sent %s weather updates\n", *frequency)
+	return nil
+}
+
+func dryRunSend(ctx context.Context, a *app, frequency domain.Frequency, outDir string) error {
+	var sink out.EmailSender
+	memory := email.NewMemorySink()
+	sink = memory
+	if outDir != "" {
+		fileSink, err := email.NewFileSink(outDir, a.logger)
+		if err != nil {
+			return err
+		}
+		sink = fileSink
+	}
+
+	summary, err := a.schedulerService.DryRunWeatherUpdates(ctx, frequency, sink)
+	if err != nil {
+		return err
+	}
+
+	for _, rendered := range memory.Emails() {
+		fmt.Printf("To: %s\nSubject: %s\n\n", rendered.To, rendered.Subject)
+	}
+	fmt.Printf("Frequency: %s\nEmails: %d\nUpdates: %d\nFailed: %d\n",
+		summary.Frequency, summary.Emails, summary.Updates, summary.Failed)
+	if outDir != "" {
+		fmt.Printf("Rendered emails written to %s\n", outDir)
+	}
+	return nil
+}
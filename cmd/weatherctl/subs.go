@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+const exportPageSize = 500
+
+// subscriptionRecord is the export/import format. It mirrors the subscribe request.
+type subscriptionRecord struct {
+	Email             string `json:"email"`
+	City              string `json:"city"`
+	Frequency         string `json:"frequency"`
+	Weekday           string `json:"weekday,omitempty"`
+	IntervalHours     int    `json:"interval_hours,omitempty"`
+	CronExpression    string `json:"cron_expression,omitempty"`
+	Timezone          string `json:"timezone,omitempty"`
+	QuietHoursStart   string `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd     string `json:"quiet_hours_end,omitempty"`
+	QuietHoursSummary bool   `json:"quiet_hours_summary,omitempty"`
+	SevereAlerts      bool   `json:"severe_alerts"`
+	Confirmed         bool   `json:"confirmed"`
+}
+
+func runSubs(args []string) error {
+	name, args, err := subcommand(args, "list", "export", "import", "delete")
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("subs "+name, flag.ContinueOnError)
+	email := fs.String("email", "", "filter by email (partial match)")
+	city := fs.String("city", "", "filter by city")
+	limit := fs.Int("limit", 50, "maximum number of subscriptions to list")
+	file := fs.String("file", "", "file to export to or import from (defaults to stdout/stdin)")
+	id := fs.Int64("id", 0, "subscription id to delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := newApp()
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx := context.Background()
+	filter := out.SearchSubscriptionsOptions{Email: *email, City: *city, Limit: *limit}
+
+	switch name {
+	case "list":
+		return listSubscriptions(ctx, a, filter)
+	case "export":
+		return withOutput(*file, func(w io.Writer) error {
+			return exportSubscriptions(ctx, a, filter, w)
+		})
+	case "import":
+		return withInput(*file, func(r io.Reader) error {
+			return importSubscriptions(ctx, a, r)
+		})
+	case "delete":
+		if *id <= 0 {
+			return errors.New("--id is required")
+		}
+		if err := a.adminUseCase.DeleteSubscription(ctx, a.actor, *id); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted subscription %d\n", *id)
+	}
+	return nil
+}
+
+func listSubscriptions(ctx context.Context, a *app, filter out.SearchSubscriptionsOptions) error {
+	subs, err := a.adminUseCase.SearchSubscriptions(ctx, a.actor, filter)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEMAIL\tCITY\tFREQUENCY\tCONFIRMED\tNEXT RUN")
+	for _, sub := range subs {
+		nextRun := "-"
+		if !sub.NextRunAt.IsZero() {
+			nextRun = sub.NextRunAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%t\t%s\n", sub.ID, sub.Email, sub.City.Name, sub.Frequency, sub.IsConfirmed, nextRun)
+	}
+	return w.Flush()
+}
+
+func exportSubscriptions(ctx context.Context, a *app, filter out.SearchSubscriptionsOptions, w io.Writer) error {
+	records := make([]subscriptionRecord, 0)
+	filter.Limit = exportPageSize
+	for {
+		subs, err := a.subscriptionRepo.SearchSubscriptions(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, sub := range subs {
+			records = append(records, toRecord(sub))
+		}
+		if len(subs) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+	}
+
+	a.audit(ctx, "export_subscriptions", fmt.Sprintf("%d subscriptions", len(records)))
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+func importSubscriptions(ctx context.Context, a *app, r io.Reader) error {
+	var records []subscriptionRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return fmt.Errorf("unable to decode subscriptions: %w", err)
+	}
+
+	var imported, skipped, failed int
+	for _, record := range records {
+		created, err := importSubscription(ctx, a, record)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(os.Stderr, "unable to import %s/%s/%s: %v\n", record.Email, record.City, record.Frequency, err)
+		case created:
+			imported++
+		default:
+			skipped++
+		}
+	}
+
+	a.audit(ctx, "import_subscriptions", fmt.Sprintf("imported=%d skipped=%d failed=%d", imported, skipped, failed))
+	fmt.Printf("Imported: %d, skipped: %d, failed: %d\n", imported, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d subscriptions failed to import", failed)
+	}
+	return nil
+}
+
+func importSubscription(ctx context.Context, a *app, record subscriptionRecord) (bool, error) {
+	opts, err := fromRecord(record)
+	if err != nil {
+		return false, err
+	}
+
+	city, err := a.cityRepo.GetByName(ctx, record.City)
+	if errors.Is(err, domain.ErrCityNotFound) {
+		city, err = a.cityRepo.Create(ctx, domain.City{Name: record.City})
+	}
+	if err != nil {
+		return false, err
+	}
+
+	exists, err := a.subscriptionRepo.IsSubscriptionExists(ctx, out.IsSubscriptionExistsOptions{
+		Email:     record.Email,
+		CityID:    city.ID,
+		Frequency: opts.Frequency,
+	})
+	if err != nil || exists {
+		return false, err
+	}
+
+	subscriber, err := a.subscriberService.EnsureSubscriberExists(ctx, record.Email)
+	if err != nil {
+		return false, err
+	}
+	opts.SubscriberID = subscriber.ID
+	opts.CityID = city.ID
+
+	token, err := a.subscriptionService.CreateSubscription(ctx, opts)
+	if err != nil {
+		return false, err
+	}
+	if !record.Confirmed {
+		return true, nil
+	}
+
+	sub, err := a.subscriptionRepo.GetSubscriptionByToken(ctx, token)
+	if err != nil {
+		return false, err
+	}
+	sub.IsConfirmed = true
+	if sub.NextRunAt, err = a.schedules.NextRunAt(sub.Frequency, sub.Schedule, time.Now().In(sub.Location())); err != nil {
+		return false, err
+	}
+	return true, a.subscriptionRepo.UpdateSubscription(ctx, sub)
+}
+
+func toRecord(sub domain.Subscription) subscriptionRecord {
+	record := subscriptionRecord{
+		Email:        sub.Email,
+		City:         sub.City.Name,
+		Frequency:    string(sub.Frequency),
+		Timezone:     sub.Timezone,
+		SevereAlerts: sub.SevereAlerts,
+		Confirmed:    sub.IsConfirmed,
+	}
+	switch sub.Frequency {
+	case domain.FrequencyWeekly:
+		record.Weekday = strings.ToLower(sub.Schedule.Weekday.String())
+	case domain.FrequencyInterval:
+		record.IntervalHours = sub.Schedule.IntervalHours
+	case domain.FrequencyCustom:
+		record.CronExpression = sub.Schedule.CronExpression
+	}
+	if sub.QuietHours.Enabled() {
+		record.QuietHoursStart = domain.FormatClock(sub.QuietHours.Start)
+		record.QuietHoursEnd = domain.FormatClock(sub.QuietHours.End)
+		record.QuietHoursSummary = sub.QuietHours.SendSummary
+	}
+	return record
+}
+
+func fromRecord(record subscriptionRecord) (out.CreateSubscriptionOptions, error) {
+	opts := out.CreateSubscriptionOptions{
+		Email:        record.Email,
+		Frequency:    domain.Frequency(record.Frequency),
+		Timezone:     record.Timezone,
+		SevereAlerts: record.SevereAlerts,
+		Schedule: domain.Schedule{
+			IntervalHours:  record.IntervalHours,
+			CronExpression: record.CronExpression,
+		},
+	}
+	if record.Email == "" || record.City == "" {
+		return opts, errors.New("email and city are required")
+	}
+	if !opts.Frequency.IsValid() {
+		return opts, fmt.Errorf("invalid frequency %q", record.Frequency)
+	}
+	if record.Weekday != "" {
+		weekday, ok := parseWeekday(record.Weekday)
+		if !ok {
+			return opts, fmt.Errorf("invalid weekday %q", record.Weekday)
+		}
+		opts.Schedule.Weekday = weekday
+	}
+	if record.QuietHoursStart != "" || record.QuietHoursEnd != "" {
+		start, startOK := domain.ParseClock(record.QuietHoursStart)
+		end, endOK := domain.ParseClock(record.QuietHoursEnd)
+		if !startOK || !endOK {
+			return opts, errors.New("invalid quiet hours")
+		}
+		opts.QuietHours = domain.QuietHours{Start: start, End: end, SendSummary: record.QuietHoursSummary}
+	}
+	return opts, nil
+}
+
+func parseWeekday(value string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), value) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+func (a *app) audit(ctx context.Context, action, details string) {
+	if err := a.auditRepo.Record(ctx, domain.AuditEntry{Actor: a.actor, Action: action, Details: details}); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to record audit entry: %v\n", err)
+	}
+}
+
+func withOutput(path string, fn func(io.Writer) error) error {
+	if path == "" {
+		return fn(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func withInput(path string, fn func(io.Reader) error) error {
+	if path == "" {
+		return fn(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return fn(f)
+}
@@ -7,8 +7,14 @@ import (
 	"weather-api/internal/core/ports/out"
 
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("weather-api/internal/adapter/cache/core/metrics")
+
 type CacheWithMetrics struct {
 	cache   out.Cache
 	metrics *CacheMetrics
@@ -21,54 +27,97 @@ func NewCacheWithMetrics(cache out.Cache, metrics *CacheMetrics) *CacheWithMetri
 	}
 }
 
-func (c *CacheWithMetrics) Get(ctx context.Context, key string) ([]byte, error) {
+// Get passes a miss through as redis.Nil, exactly as the cache it wraps
+// reports it, so callers see the same result with or without metrics.
+func (c *CacheWithMetrics) Get(ctx context.Context, key string) (data []byte, err error) {
+	ctx, span := startSpan(ctx, "cache get", key)
+	defer func() {
+		span.SetAttributes(attribute.Bool("cache.hit", err == nil && data != nil))
+		endSpan(span, err)
+	}()
+
 	if c.metrics == nil {
 		return c.cache.Get(ctx, key)
 	}
+	if c.metrics.HotKeys != nil {
+		c.metrics.HotKeys.Observe(key)
+	}
 
 	start := time.Now()
-	defer func() {
-		c.metrics.OperationDuration.Observe(time.Since(start).Seconds())
-	}()
+	data, err = c.cache.Get(ctx, key)
+	c.metrics.OperationDuration.WithLabelValues(OperationGet).Observe(time.Since(start).Seconds())
 
-	data, err := c.cache.Get(ctx, key)
-	if errors.Is(err, redis.Nil) {
-		c.metrics.Misses.Inc()
-		return nil, nil
+	switch {
+	case errors.Is(err, redis.Nil), err == nil && data == nil:
+		c.metrics.record(OperationGet, OutcomeMiss)
+	case err != nil:
+		c.metrics.record(OperationGet, OutcomeError)
+	default:
+		c.metrics.record(OperationGet, OutcomeHit)
 	}
-	if err != nil {
-		c.metrics.Errors.Inc()
-		return nil, err
-	}
-
-	c.metrics.Hits.WithLabelValues(key).Inc()
-	return data, nil
+	return data, err
 }
 
-func (c *CacheWithMetrics) Set(ctx context.Context, key string, value []byte) error {
+func (c *CacheWithMetrics) Set(ctx context.Context, key string, value []byte) (err error) {
+	ctx, span := startSpan(ctx, "cache set", key)
+	defer func() { endSpan(span, err) }()
+
 	if c.metrics == nil {
 		return c.cache.Set(ctx, key, value)
 	}
 
 	if key == "" {
-		c.metrics.Skipped.Inc()
+		c.metrics.record(OperationSet, OutcomeSkipped)
 		return nil
 	}
 
 	start := time.Now()
-	defer func() {
-		c.metrics.OperationDuration.Observe(time.Since(start).Seconds())
-	}()
+	err = c.cache.Set(ctx, key, value)
+	c.metrics.OperationDuration.WithLabelValues(OperationSet).Observe(time.Since(start).Seconds())
+	if err != nil {
+		c.metrics.record(OperationSet, OutcomeError)
+		return err
+	}
+
+	c.metrics.record(OperationSet, OutcomeSuccess)
+	return nil
+}
+
+func (c *CacheWithMetrics) Delete(ctx context.Context, key string) (err error) {
+	ctx, span := startSpan(ctx, "cache delete", key)
+	defer func() { endSpan(span, err) }()
+
+	if c.metrics == nil {
+		return c.cache.Delete(ctx, key)
+	}
 
-	err := c.cache.Set(ctx, key, value)
+	start := time.Now()
+	err = c.cache.Delete(ctx, key)
+	c.metrics.OperationDuration.WithLabelValues(OperationDelete).Observe(time.Since(start).Seconds())
 	if err != nil {
-		c.metrics.Errors.Inc()
+		c.metrics.record(OperationDelete, OutcomeError)
 		return err
 	}
 
+	c.metrics.record(OperationDelete, OutcomeSuccess)
 	return nil
 }
 
 func (c *CacheWithMetrics) Close() error {
 	return c.cache.Close()
 }
+
+func startSpan(ctx context.Context, name, key string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("db.system.name", "redis"),
+		attribute.String("cache.key", key),
+	))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,96 @@
+//go:build unit
+// +build unit
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapCache map[string][]byte
+
+func (m mapCache) Get(_ context.Context, key string) ([]byte, error) {
+	if key == "down" {
+		return nil, errors.New("connection refused")
+	}
+	if value, ok := m[key]; ok {
+		return value, nil
+	}
+	return nil, redis.Nil
+}
+
+func (m mapCache) Set(_ context.Context, key string, value []byte) error {
+	m[key] = value
+	return nil
+}
+
+func (m mapCache) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m mapCache) Close() error { return nil }
+
+func TestCacheWithMetrics_Get_ReportsMissLikeWrappedCache(t *testing.T) {
+	raw := mapCache{}
+	withMetrics := NewCacheWithMetrics(raw, NewCacheMetrics(prometheus.NewRegistry(), "weather"))
+	withoutMetrics := NewCacheWithMetrics(raw, nil)
+
+	_, err := withMetrics.Get(context.Background(), "Kyiv")
+	assert.ErrorIs(t, err, redis.Nil)
+	_, err = withoutMetrics.Get(context.Background(), "Kyiv")
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestCacheWithMetrics_LabelsAreBounded(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	cacheMetrics := NewCacheMetrics(reg, "weather")
+	cache := NewCacheWithMetrics(mapCache{"kyiv": []byte("{}")}, cacheMetrics)
+
+	for i := 0; i < 50; i++ {
+		_, _ = cache.Get(context.Background(), fmt.Sprintf("city-%d", i))
+	}
+	_, _ = cache.Get(context.Background(), "kyiv")
+	_, _ = cache.Get(context.Background(), "down")
+
+	assert.Equal(t, 50.0, testutil.ToFloat64(cacheMetrics.Operations.WithLabelValues(OperationGet, OutcomeMiss)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheMetrics.Operations.WithLabelValues(OperationGet, OutcomeHit)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheMetrics.Operations.WithLabelValues(OperationGet, OutcomeError)))
+
+	count, err := testutil.GatherAndCount(reg, "cache_operations_total")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestHotKeyTracker_ExportsOnlyTopKeys(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	cacheMetrics := NewCacheMetrics(reg, "weather", WithHotKeys(2))
+	cache := NewCacheWithMetrics(mapCache{}, cacheMetrics)
+
+	for i := 0; i < 200; i++ {
+		_, _ = cache.Get(context.Background(), fmt.Sprintf("typo-%d", i))
+		if i%2 == 0 {
+			_, _ = cache.Get(context.Background(), "kyiv")
+		}
+		if i%4 == 0 {
+			_, _ = cache.Get(context.Background(), "lviv")
+		}
+	}
+
+	top := cacheMetrics.HotKeys.Top(2)
+	require.Len(t, top, 2)
+	assert.Equal(t, "kyiv", top[0].Key)
+	assert.Equal(t, "lviv", top[1].Key)
+	count, err := testutil.GatherAndCount(reg, "cache_hot_key_requests")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// hotKeyCapacityFactor sizes the candidate set relative to the exported top
+// N so keys can climb into the top before they are evicted.
+const hotKeyCapacityFactor = 10
+
+type KeyCount struct {
+	Key   string
+	Count uint64
+}
+
+// HotKeyTracker approximates the most requested keys with the Space-Saving
+// algorithm: memory is bounded by a fixed number of counters and a new key
+// replaces the least counted one, inheriting its count. Only the top N keys
+// are exported, so the number of series stays bounded whatever users type.
+type HotKeyTracker struct {
+	top      int
+	capacity int
+	desc     *prometheus.Desc
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+func NewHotKeyTracker(top int, constLabels prometheus.Labels) *HotKeyTracker {
+	return &HotKeyTracker{
+		top:      top,
+		capacity: top * hotKeyCapacityFactor,
+		desc: prometheus.NewDesc(
+			"cache_hot_key_requests",
+			"Approximate requests of the most requested cache keys since startup",
+			[]string{"key"}, constLabels,
+		),
+		counts: make(map[string]uint64),
+	}
+}
+
+func (t *HotKeyTracker) Observe(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.counts[key]; ok || len(t.counts) < t.capacity {
+		t.counts[key]++
+		return
+	}
+
+	var minKey string
+	var minCount uint64
+	for k, count := range t.counts {
+		if minKey == "" || count < minCount {
+			minKey, minCount = k, count
+		}
+	}
+	delete(t.counts, minKey)
+	t.counts[key] = minCount + 1
+}
+
+// Top returns up to n keys ordered by descending count.
+func (t *HotKeyTracker) Top(n int) []KeyCount {
+	t.mu.Lock()
+	keys := make([]KeyCount, 0, len(t.counts))
+	for key, count := range t.counts {
+		keys = append(keys, KeyCount{Key: key, Count: count})
+	}
+	t.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Count != keys[j].Count {
+			return keys[i].Count > keys[j].Count
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+func (t *HotKeyTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.desc
+}
+
+func (t *HotKeyTracker) Collect(ch chan<- prometheus.Metric) {
+	for _, key := range t.Top(t.top) {
+		ch <- prometheus.MustNewConstMetric(t.desc, prometheus.GaugeValue, float64(key.Count), key.Key)
+	}
+}
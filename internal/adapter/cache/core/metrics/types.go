@@ -4,93 +4,76 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-type CacheMetrics struct {
-	Hits              *prometheus.CounterVec
-	Misses            prometheus.Counter
-	Errors            prometheus.Counter
-	Skipped           prometheus.Counter
-	OperationDuration prometheus.Histogram
-	reg               prometheus.Registerer
-	namespace         string
-}
-
-type CacheMetricsOption func(*CacheMetrics)
+const (
+	OperationGet    = "get"
+	OperationSet    = "set"
+	OperationDelete = "delete"
 
-func WithHits() CacheMetricsOption {
-	return func(m *CacheMetrics) {
-		m.Hits = prometheus.NewCounterVec(prometheus.CounterOpts{
-			Namespace: m.namespace,
-			Name:      "cache_hits",
-			Help:      "Cache hits per key",
-		}, []string{"key"})
-		m.reg.MustRegister(m.Hits)
-	}
-}
+	OutcomeHit     = "hit"
+	OutcomeMiss    = "miss"
+	OutcomeSuccess = "success"
+	OutcomeSkipped = "skipped"
+	OutcomeError   = "error"
+)
 
-func WithMisses() CacheMetricsOption {
-	return func(m *CacheMetrics) {
-		m.Misses = prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: m.namespace,
-			Name:      "cache_misses_total",
-			Help:      "Total number of cache misses",
-		})
-		m.reg.MustRegister(m.Misses)
-	}
+// CacheMetrics labels every series by cache name, tier, operation and
+// outcome only. Keys are never used as labels; the optional HotKeys tracker
+// exports the most requested keys as a separate, bounded series.
+type CacheMetrics struct {
+	Operations        *prometheus.CounterVec
+	OperationDuration *prometheus.HistogramVec
+	HotKeys           *HotKeyTracker
+	cache             string
+	tier              string
+	hotKeys           int
 }
 
-func WithErrors() CacheMetricsOption {
-	return func(m *CacheMetrics) {
-		m.Errors = prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: m.namespace,
-			Name:      "cache_errors_total",
-			Help:      "Total number of cache errors",
-		})
-		m.reg.MustRegister(m.Errors)
-	}
-}
+type CacheMetricsOption func(*CacheMetrics)
 
-func WithSkipped() CacheMetricsOption {
+// WithTier names the storage behind the cache, e.g. redis. Defaults to redis.
+func WithTier(tier string) CacheMetricsOption {
 	return func(m *CacheMetrics) {
-		m.Skipped = prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: m.namespace,
-			Name:      "cache_skipped_total",
-			Help:      "Number of skipped or ignored cache sets",
-		})
-		m.reg.MustRegister(m.Skipped)
+		m.tier = tier
 	}
 }
 
-func WithOperationDuration() CacheMetricsOption {
+// WithHotKeys tracks the n most requested keys. Disabled by default.
+func WithHotKeys(n int) CacheMetricsOption {
 	return func(m *CacheMetrics) {
-		m.OperationDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
-			Namespace: m.namespace,
-			Name:      "cache_operation_duration_seconds",
-			Help:      "Duration of cache operations",
-			Buckets:   prometheus.DefBuckets,
-		})
-		m.reg.MustRegister(m.OperationDuration)
+		m.hotKeys = n
 	}
 }
 
-func NewCacheMetrics(reg prometheus.Registerer, namespace string, opts ...CacheMetricsOption) *CacheMetrics {
+func NewCacheMetrics(reg prometheus.Registerer, cache string, opts ...CacheMetricsOption) *CacheMetrics {
 	m := &CacheMetrics{
-		reg:       reg,
-		namespace: namespace,
-	}
-
-	if len(opts) == 0 {
-		opts = []CacheMetricsOption{
-			WithHits(),
-			WithMisses(),
-			WithErrors(),
-			WithSkipped(),
-			WithOperationDuration(),
-		}
+		cache: cache,
+		tier:  "redis",
 	}
-
 	for _, opt := range opts {
 		opt(m)
 	}
 
+	constLabels := prometheus.Labels{"cache": m.cache, "tier": m.tier}
+	m.Operations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:        "cache_operations_total",
+		Help:        "Cache operations by operation and outcome",
+		ConstLabels: constLabels,
+	}, []string{"operation", "outcome"})
+	m.OperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "cache_operation_duration_seconds",
+		Help:        "Duration of cache operations",
+		ConstLabels: constLabels,
+		Buckets:     prometheus.DefBuckets,
+	}, []string{"operation"})
+	reg.MustRegister(m.Operations, m.OperationDuration)
+
+	if m.hotKeys > 0 {
+		m.HotKeys = NewHotKeyTracker(m.hotKeys, constLabels)
+		reg.MustRegister(m.HotKeys)
+	}
 	return m
 }
+
+func (m *CacheMetrics) record(operation, outcome string) {
+	m.Operations.WithLabelValues(operation, outcome).Inc()
+}
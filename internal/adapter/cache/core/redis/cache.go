@@ -59,6 +59,18 @@ func (r *Cache) Set(ctx context.Context, key string, value []byte) error {
 	return r.client.Set(ctx, key, value, r.ttl).Err()
 }
 
+func (r *Cache) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return core.NewError(core.InvalidKey, key, nil)
+	}
+	return r.client.Del(ctx, normalizeKey(key)).Err()
+}
+
+// Client exposes the underlying connection pool so other adapters can share it.
+func (r *Cache) Client() *redis.Client {
+	return r.client
+}
+
 func (r *Cache) Close() error {
 	return r.client.Close()
 }
@@ -0,0 +1,19 @@
+package redis
+
+import "context"
+
+type HealthCheck struct {
+	cache *Cache
+}
+
+func NewHealthCheck(cache *Cache) *HealthCheck {
+	return &HealthCheck{cache: cache}
+}
+
+func (h *HealthCheck) Name() string {
+	return "redis"
+}
+
+func (h *HealthCheck) Check(ctx context.Context) error {
+	return h.cache.client.Ping(ctx).Err()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"weather-api/internal/adapter/cache/core"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
@@ -54,6 +55,59 @@ func (w *Cache) Set(ctx context.Context, city string, weather domain.Weather) er
 	return nil
 }
 
+// GetAlerts reports found as false on a cache miss, so a cached empty list
+// can be told apart from one that was never fetched.
+func (w *Cache) GetAlerts(ctx context.Context, city string) ([]domain.Alert, bool, error) {
+	if city == "" {
+		return nil, false, core.NewError(core.InvalidKey, city, nil)
+	}
+	data, err := w.cache.Get(ctx, alertsKey(city))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, core.NewError(core.RedisError, city, err)
+	}
+	if data == nil {
+		return nil, false, nil
+	}
+	var alerts []domain.Alert
+	if err := json.Unmarshal(data, &alerts); err != nil {
+		return nil, false, core.NewError(core.UnmarshalError, city, err)
+	}
+	return alerts, true, nil
+}
+
+func (w *Cache) SetAlerts(ctx context.Context, city string, alerts []domain.Alert) error {
+	if city == "" {
+		return core.NewError(core.InvalidKey, city, nil)
+	}
+	data, err := json.Marshal(alerts)
+	if err != nil {
+		return core.NewError(core.MarshalError, city, err)
+	}
+	if err := w.cache.Set(ctx, alertsKey(city), data); err != nil {
+		return core.NewError(core.RedisError, city, err)
+	}
+	return nil
+}
+
+// alertsKey normalizes city like the weather key, which the redis cache
+// lowercases and trims as a whole, so " Kyiv" and "kyiv" share an entry.
+func alertsKey(city string) string {
+	return "alerts:" + strings.ToLower(strings.TrimSpace(city))
+}
+
+func (w *Cache) Delete(ctx context.Context, city string) error {
+	if city == "" {
+		return core.NewError(core.InvalidKey, city, nil)
+	}
+	if err := w.cache.Delete(ctx, city); err != nil {
+		return core.NewError(core.RedisError, city, err)
+	}
+	return nil
+}
+
 func (w *Cache) Close() error {
 	return w.cache.Close()
 }
@@ -6,6 +6,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func NewCacheMetrics(reg prometheus.Registerer) *metrics.CacheMetrics {
-	return metrics.NewCacheMetrics(reg, "weather")
+func NewCacheMetrics(reg prometheus.Registerer, opts ...metrics.CacheMetricsOption) *metrics.CacheMetrics {
+	return metrics.NewCacheMetrics(reg, "weather", opts...)
 }
@@ -0,0 +1,69 @@
+package email
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"weather-api/internal/core/ports/out"
+)
+
+const (
+	BackendSMTP    = "smtp"
+	BackendMaildir = "maildir"
+	BackendLog     = "log"
+	BackendHTTP    = "http"
+)
+
+type BackendOptions struct {
+	SMTP        SenderOptions
+	From        string
+	MaildirPath string
+	HTTPAPI     HTTPAPISenderOptions
+	Logger      *slog.Logger
+}
+
+// NewEmailSender builds the sender for the configured backends. With more than
+// one backend the result fails over to the next one in the given order.
+func NewEmailSender(backends []string, opts BackendOptions) (out.EmailSender, error) {
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no email backend configured")
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+
+	failover := make([]FailoverBackend, 0, len(backends))
+	for _, backend := range backends {
+		sender, err := newBackend(backend, opts)
+		if err != nil {
+			return nil, err
+		}
+		failover = append(failover, FailoverBackend{Name: backend, Sender: sender})
+	}
+
+	if len(failover) == 1 {
+		return failover[0].Sender, nil
+	}
+	return NewFailoverSender(opts.Logger, failover...), nil
+}
+
+func newBackend(backend string, opts BackendOptions) (out.EmailSender, error) {
+	switch backend {
+	case BackendSMTP:
+		smtpOpts := opts.SMTP
+		smtpOpts.Logger = opts.Logger
+		return NewSender(smtpOpts), nil
+	case BackendMaildir:
+		return NewMaildirSender(opts.MaildirPath, opts.From, opts.Logger)
+	case BackendLog:
+		return NewLogSender(os.Stdout, opts.Logger), nil
+	case BackendHTTP:
+		apiOpts := opts.HTTPAPI
+		if apiOpts.From == "" {
+			apiOpts.From = opts.From
+		}
+		apiOpts.Logger = opts.Logger
+		return NewHTTPAPISender(apiOpts), nil
+	}
+	return nil, fmt.Errorf("unsupported email backend %q", backend)
+}
@@ -0,0 +1,76 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"weather-api/internal/core/domain"
+)
+
+// MailboxBounceSource reads bounce messages delivered to a Maildir. Handled
+// and unparseable messages are moved from new to cur so they are read once.
+type MailboxBounceSource struct {
+	dir    string
+	logger *slog.Logger
+}
+
+func NewMailboxBounceSource(dir string, logger *slog.Logger) *MailboxBounceSource {
+	return &MailboxBounceSource{dir: dir, logger: logger}
+}
+
+func (s *MailboxBounceSource) ProcessBounces(ctx context.Context, handle func(ctx context.Context, events []domain.BounceEvent) error) error {
+	entries, err := os.ReadDir(filepath.Join(s.dir, "new"))
+	if err != nil {
+		return fmt.Errorf("read bounce mailbox %s: %w", s.dir, err)
+	}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		events, err := s.parse(entry.Name())
+		switch {
+		case errors.Is(err, ErrNotBounceReport):
+			s.logger.DebugContext(ctx, "skipping message in bounce mailbox", "file", entry.Name(), "error", err)
+		case err != nil:
+			s.logger.WarnContext(ctx, "unable to parse message in bounce mailbox", "file", entry.Name(), "error", err)
+		default:
+			if err := handle(ctx, events); err != nil {
+				return err
+			}
+		}
+
+		if err := s.markSeen(entry.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MailboxBounceSource) parse(name string) ([]domain.BounceEvent, error) {
+	f, err := os.Open(filepath.Join(s.dir, "new", name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return ParseBounceMessage(f)
+}
+
+func (s *MailboxBounceSource) markSeen(name string) error {
+	if err := os.MkdirAll(filepath.Join(s.dir, "cur"), 0o755); err != nil {
+		return fmt.Errorf("create %s/cur: %w", s.dir, err)
+	}
+	if err := os.Rename(filepath.Join(s.dir, "new", name), filepath.Join(s.dir, "cur", name+":2,S")); err != nil {
+		return fmt.Errorf("move %s to cur: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package email
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+	"weather-api/internal/core/domain"
+)
+
+var ErrNotBounceReport = errors.New("message is not a delivery status or feedback report")
+
+// ParseBounceMessage extracts bounce and complaint events from a delivery
+// status notification (RFC 3464) or an abuse feedback report (RFC 5965).
+func ParseBounceMessage(r io.Reader) ([]domain.BounceEvent, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" || params["boundary"] == "" {
+		return nil, ErrNotBounceReport
+	}
+
+	occurredAt, err := msg.Header.Date()
+	if err != nil {
+		occurredAt = time.Now()
+	}
+
+	var events []domain.BounceEvent
+	var complaint *domain.BounceEvent
+	var complainedAbout string
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read report part: %w", err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		body := partBody(part)
+		switch partType {
+		case "message/delivery-status", "message/global-delivery-status":
+			dsnEvents, err := parseDeliveryStatus(body, occurredAt)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, dsnEvents...)
+		case "message/feedback-report":
+			fields, err := readFieldBlocks(body)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) > 0 {
+				complaint = &domain.BounceEvent{
+					Email:      recipientAddress(fields[0].Get("Original-Rcpt-To")),
+					Type:       domain.BounceTypeComplaint,
+					Reason:     "feedback report: " + fields[0].Get("Feedback-Type"),
+					OccurredAt: occurredAt,
+				}
+			}
+		case "message/rfc822", "text/rfc822-headers":
+			if original, err := mail.ReadMessage(body); err == nil {
+				complainedAbout = recipientAddress(original.Header.Get("To"))
+			}
+		}
+	}
+
+	if complaint != nil {
+		if complaint.Email == "" {
+			complaint.Email = complainedAbout
+		}
+		if complaint.Email != "" {
+			events = append(events, *complaint)
+		}
+	}
+	if len(events) == 0 {
+		return nil, ErrNotBounceReport
+	}
+	return events, nil
+}
+
+func parseDeliveryStatus(r io.Reader, occurredAt time.Time) ([]domain.BounceEvent, error) {
+	blocks, err := readFieldBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []domain.BounceEvent
+	// The first block holds per-message fields, every following one describes a recipient.
+	for _, fields := range blocks {
+		recipient := fields.Get("Final-Recipient")
+		if recipient == "" {
+			recipient = fields.Get("Original-Recipient")
+		}
+		if recipient == "" {
+			continue
+		}
+
+		bounceType, ok := classifyDelivery(fields.Get("Action"), fields.Get("Status"))
+		if !ok {
+			continue
+		}
+
+		reason := strings.TrimSpace(fields.Get("Diagnostic-Code"))
+		if reason == "" {
+			reason = "status " + fields.Get("Status")
+		}
+		events = append(events, domain.BounceEvent{
+			Email:      recipientAddress(recipient),
+			Type:       bounceType,
+			Reason:     reason,
+			OccurredAt: occurredAt,
+		})
+	}
+	return events, nil
+}
+
+// classifyDelivery maps a DSN action and status code to a bounce type.
+// Permanent (5.x.x) failures are hard bounces, everything else is temporary.
+func classifyDelivery(action, status string) (domain.BounceType, bool) {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "failed":
+		if strings.HasPrefix(strings.TrimSpace(status), "5") {
+			return domain.BounceTypeHard, true
+		}
+		return domain.BounceTypeSoft, true
+	case "delayed":
+		return domain.BounceTypeSoft, true
+	}
+	return "", false
+}
+
+// readFieldBlocks reads header-style field groups separated by blank lines.
+func readFieldBlocks(r io.Reader) ([]textproto.MIMEHeader, error) {
+	reader := textproto.NewReader(bufio.NewReader(r))
+	var blocks []textproto.MIMEHeader
+	for {
+		fields, err := reader.ReadMIMEHeader()
+		if len(fields) > 0 {
+			blocks = append(blocks, fields)
+		}
+		if errors.Is(err, io.EOF) {
+			return blocks, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read report fields: %w", err)
+		}
+	}
+}
+
+// recipientAddress strips the address type from values such as
+// "rfc822; user@example.com" and any display name.
+func recipientAddress(value string) string {
+	if _, address, found := strings.Cut(value, ";"); found {
+		value = address
+	}
+	value = strings.TrimSpace(value)
+	if parsed, err := mail.ParseAddress(value); err == nil {
+		return strings.ToLower(parsed.Address)
+	}
+	return strings.ToLower(strings.Trim(value, "<>"))
+}
+
+func partBody(part *multipart.Part) io.Reader {
+	if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+		return base64.NewDecoder(base64.StdEncoding, part)
+	}
+	return part
+}
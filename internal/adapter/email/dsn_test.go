@@ -0,0 +1,158 @@
+//go:build unit
+// +build unit
+
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const hardBounceDSN = `From: MAILER-DAEMON@mail.example.com
+To: weather@example.com
+Date: Wed, 11 Jun 2025 10:00:00 +0000
+Subject: Undelivered Mail Returned to Sender
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="BOUNDARY"
+
+--BOUNDARY
+Content-Type: text/plain
+
+This is the mail system. Your message could not be delivered.
+
+--BOUNDARY
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mail.example.com
+Arrival-Date: Wed, 11 Jun 2025 09:59:58 +0000
+
+Final-Recipient: rfc822; Gone@Example.com
+Original-Recipient: rfc822;gone@example.com
+Action: failed
+Status: 5.1.1
+Diagnostic-Code: smtp; 550 5.1.1 user unknown
+
+Final-Recipient: rfc822; full@example.com
+Action: delayed
+Status: 4.2.2
+
+Final-Recipient: rfc822; fine@example.com
+Action: delivered
+Status: 2.0.0
+
+--BOUNDARY
+Content-Type: text/rfc822-headers
+
+To: gone@example.com
+Subject: Weather update
+
+--BOUNDARY--
+`
+
+const complaintARF = `From: feedback@isp.example.net
+To: weather@example.com
+Date: Wed, 11 Jun 2025 12:00:00 +0000
+Subject: Abuse report
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=feedback-report; boundary="ARF"
+
+--ARF
+Content-Type: text/plain
+
+This is an email abuse report.
+
+--ARF
+Content-Type: message/feedback-report
+
+Feedback-Type: abuse
+User-Agent: ExampleFBL/1.0
+Version: 1
+
+--ARF
+Content-Type: message/rfc822
+
+From: weather@example.com
+To: Angry User <angry@example.com>
+Subject: Weather update
+
+Sunny
+--ARF--
+`
+
+func crlf(s string) string {
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+func TestParseBounceMessage_DeliveryStatus(t *testing.T) {
+	events, err := ParseBounceMessage(strings.NewReader(crlf(hardBounceDSN)))
+
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "gone@example.com", events[0].Email)
+	assert.Equal(t, domain.BounceTypeHard, events[0].Type)
+	assert.Equal(t, "smtp; 550 5.1.1 user unknown", events[0].Reason)
+	assert.Equal(t, "full@example.com", events[1].Email)
+	assert.Equal(t, domain.BounceTypeSoft, events[1].Type)
+	assert.Equal(t, "status 4.2.2", events[1].Reason)
+}
+
+func TestParseBounceMessage_FeedbackReport(t *testing.T) {
+	events, err := ParseBounceMessage(strings.NewReader(crlf(complaintARF)))
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "angry@example.com", events[0].Email)
+	assert.Equal(t, domain.BounceTypeComplaint, events[0].Type)
+	assert.Equal(t, "feedback report: abuse", events[0].Reason)
+}
+
+func TestParseBounceMessage_NotAReport(t *testing.T) {
+	_, err := ParseBounceMessage(strings.NewReader(crlf("From: a@example.com\nSubject: hi\n\nhello\n")))
+
+	assert.ErrorIs(t, err, ErrNotBounceReport)
+}
+
+func TestMailboxBounceSource_ProcessBounces(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "new"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new", "1.dsn"), []byte(crlf(hardBounceDSN)), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new", "2.txt"), []byte("Subject: hi\r\n\r\nhello\r\n"), 0o644))
+
+	var handled []domain.BounceEvent
+	err := NewMailboxBounceSource(dir, logger.Discard()).ProcessBounces(context.Background(), func(_ context.Context, events []domain.BounceEvent) error {
+		handled = append(handled, events...)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, handled, 2)
+	pending, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	seen, err := os.ReadDir(filepath.Join(dir, "cur"))
+	require.NoError(t, err)
+	assert.Len(t, seen, 2)
+}
+
+func TestMailboxBounceSource_KeepsMessageWhenHandlingFails(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "new"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new", "1.dsn"), []byte(crlf(hardBounceDSN)), 0o644))
+
+	err := NewMailboxBounceSource(dir, logger.Discard()).ProcessBounces(context.Background(), func(context.Context, []domain.BounceEvent) error {
+		return assert.AnError
+	})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	pending, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+}
@@ -1,41 +1,56 @@
 package email
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/smtp"
 	"strconv"
 	"weather-api/internal/core/ports/out"
 
 	"github.com/jordan-wright/email"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("weather-api/internal/adapter/email")
+
 type SenderOptions struct {
 	Host string
 	Port int
 	User string
 	Pass string
+	// Logger defaults to slog.Default when nil.
+	Logger *slog.Logger
 }
 
 type Sender struct {
-	host string
-	port int
-	user string
-	pass string
+	host   string
+	port   int
+	user   string
+	pass   string
+	logger *slog.Logger
 }
 
 func NewSender(opts SenderOptions) *Sender {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &Sender{
-		host: opts.Host,
-		port: opts.Port,
-		user: opts.User,
-		pass: opts.Pass,
+		host:   opts.Host,
+		port:   opts.Port,
+		user:   opts.User,
+		pass:   opts.Pass,
+		logger: logger,
 	}
 }
 
-func (e *Sender) SendEmail(opts out.SendEmailOptions) error {
-	log.Printf("Attempting to send email to: %s, subject: %s, from: %s", opts.To, opts.Subject, e.user)
+func (e *Sender) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	e.logger.DebugContext(ctx, "sending email", "to", opts.To, "subject", opts.Subject, "from", e.user)
 
 	msg := email.NewEmail()
 	msg.From = e.user
@@ -43,14 +58,24 @@ func (e *Sender) SendEmail(opts out.SendEmailOptions) error {
 	msg.Subject = opts.Subject
 	msg.HTML = []byte(opts.Body)
 
+	// The recipient is deliberately not a span attribute: traces leave the
+	// process and are not covered by log redaction.
+	_, span := tracer.Start(ctx, "smtp send", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.ServerAddress(e.host),
+		semconv.ServerPort(e.port),
+	))
+	defer span.End()
+
 	addr := e.host + ":" + strconv.Itoa(e.port)
 	err := msg.Send(addr, smtp.PlainAuth("", e.user, e.pass, e.host))
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "smtp send failed")
 		msg := fmt.Sprintf("unable to send email to %s: %v", opts.To, err)
-		log.Print(msg)
+		e.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
-	log.Printf("Successfully sent email to: %s", opts.To)
+	e.logger.InfoContext(ctx, "sent email", "to", opts.To)
 	return nil
 }
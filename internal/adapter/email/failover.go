@@ -0,0 +1,37 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/ports/out"
+)
+
+type FailoverBackend struct {
+	Name   string
+	Sender out.EmailSender
+}
+
+// FailoverSender tries each backend in order until one accepts the message.
+type FailoverSender struct {
+	backends []FailoverBackend
+	logger   *slog.Logger
+}
+
+func NewFailoverSender(logger *slog.Logger, backends ...FailoverBackend) *FailoverSender {
+	return &FailoverSender{backends: backends, logger: logger}
+}
+
+func (s *FailoverSender) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	var errs []error
+	for _, backend := range s.backends {
+		err := backend.Sender.SendEmail(ctx, opts)
+		if err == nil {
+			return nil
+		}
+		s.logger.WarnContext(ctx, "email backend failed, trying next", "backend", backend.Name, "to", opts.To, "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", backend.Name, err))
+	}
+	return fmt.Errorf("all email backends failed: %w", errors.Join(errs...))
+}
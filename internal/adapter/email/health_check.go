@@ -0,0 +1,51 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+)
+
+// HealthCheck opens an SMTP session and says hello without authenticating or sending mail.
+type HealthCheck struct {
+	host string
+	port int
+}
+
+func NewHealthCheck(opts SenderOptions) *HealthCheck {
+	return &HealthCheck{
+		host: opts.Host,
+		port: opts.Port,
+	}
+}
+
+func (h *HealthCheck) Name() string {
+	return "smtp"
+}
+
+func (h *HealthCheck) Check(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(h.host, strconv.Itoa(h.port)))
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, h.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("open smtp session: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if err := client.Hello("localhost"); err != nil {
+		return fmt.Errorf("smtp hello: %w", err)
+	}
+	return client.Quit()
+}
@@ -0,0 +1,104 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"weather-api/internal/core/ports/out"
+)
+
+const maxErrorBodySize = 512
+
+type HTTPAPISenderOptions struct {
+	Endpoint   string
+	AuthHeader string
+	AuthValue  string
+	From       string
+	HTTPClient *http.Client
+	// Logger defaults to slog.Default when nil.
+	Logger *slog.Logger
+}
+
+// HTTPAPISender posts messages as JSON to a transactional mail API.
+type HTTPAPISender struct {
+	endpoint   string
+	authHeader string
+	authValue  string
+	from       string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+type httpAPIMessage struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+}
+
+func NewHTTPAPISender(opts HTTPAPISenderOptions) *HTTPAPISender {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &HTTPAPISender{
+		endpoint:   opts.Endpoint,
+		authHeader: opts.AuthHeader,
+		authValue:  opts.AuthValue,
+		from:       opts.From,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+func (s *HTTPAPISender) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	payload, err := json.Marshal(httpAPIMessage{
+		From:    s.from,
+		To:      opts.To,
+		Subject: opts.Subject,
+		HTML:    opts.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to encode email to %s: %w", opts.To, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("unable to create email API request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authHeader != "" && s.authValue != "" {
+		req.Header.Set(s.authHeader, s.authValue)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		msg := fmt.Sprintf("unable to send email to %s: %v", opts.To, err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		msg := fmt.Sprintf("unable to send email to %s: email API responded with %d: %s",
+			opts.To, resp.StatusCode, strings.TrimSpace(string(body)))
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	s.logger.InfoContext(ctx, "sent email", "to", opts.To)
+	return nil
+}
@@ -0,0 +1,34 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"weather-api/internal/core/ports/out"
+)
+
+// LogSender writes every message to w (usually stdout) instead of sending it.
+type LogSender struct {
+	mu     sync.Mutex
+	w      io.Writer
+	logger *slog.Logger
+}
+
+func NewLogSender(w io.Writer, logger *slog.Logger) *LogSender {
+	return &LogSender{w: w, logger: logger}
+}
+
+func (s *LogSender) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := fmt.Fprintf(s.w, "To: %s\nSubject: %s\n\n%s\n\n", opts.To, opts.Subject, opts.Body); err != nil {
+		msg := fmt.Sprintf("unable to log email to %s: %v", opts.To, err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/jordan-wright/email"
+)
+
+// MaildirSender delivers messages into a local Maildir (tmp/new/cur) as
+// complete .eml files, so development needs no SMTP server and tests can
+// read what was sent.
+type MaildirSender struct {
+	dir      string
+	from     string
+	hostname string
+	seq      atomic.Uint64
+	logger   *slog.Logger
+}
+
+func NewMaildirSender(dir, from string, logger *slog.Logger) (*MaildirSender, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			msg := fmt.Sprintf("unable to create maildir %s: %v", dir, err)
+			logger.Error(msg)
+			return nil, errors.New(msg)
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	return &MaildirSender{dir: dir, from: from, hostname: hostname, logger: logger}, nil
+}
+
+func (s *MaildirSender) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	msg := email.NewEmail()
+	msg.From = s.from
+	msg.To = []string{opts.To}
+	msg.Subject = opts.Subject
+	msg.HTML = []byte(opts.Body)
+
+	raw, err := msg.Bytes()
+	if err != nil {
+		msg := fmt.Sprintf("unable to build email to %s: %v", opts.To, err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	// Maildir delivery: write to tmp, then rename into new so readers never
+	// see a partially written message.
+	name := fmt.Sprintf("%d.%d_%d.%s.eml", time.Now().Unix(), os.Getpid(), s.seq.Add(1), s.hostname)
+	tmpPath := filepath.Join(s.dir, "tmp", name)
+	if err := os.WriteFile(tmpPath, raw, 0o644); err != nil {
+		msg := fmt.Sprintf("unable to write email to %s: %v", opts.To, err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(s.dir, "new", name)); err != nil {
+		_ = os.Remove(tmpPath)
+		msg := fmt.Sprintf("unable to deliver email to %s: %v", opts.To, err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	s.logger.InfoContext(ctx, "wrote email to maildir", "to", opts.To, "dir", s.dir)
+	return nil
+}
@@ -0,0 +1,179 @@
+//go:build unit
+// +build unit
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEmail = out.SendEmailOptions{
+	To:      "user@example.com",
+	Subject: "Weather update",
+	Body:    "<p>Sunny</p>",
+}
+
+func TestHTTPAPISender_SendEmail(t *testing.T) {
+	var received httpAPIMessage
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("X-Api-Key")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPAPISender(HTTPAPISenderOptions{
+		Endpoint:   server.URL,
+		AuthHeader: "X-Api-Key",
+		AuthValue:  "secret",
+		From:       "weather@example.com",
+		HTTPClient: server.Client(),
+	})
+
+	require.NoError(t, sender.SendEmail(context.Background(), testEmail))
+	assert.Equal(t, "secret", authorization)
+	assert.Equal(t, httpAPIMessage{
+		From:    "weather@example.com",
+		To:      "user@example.com",
+		Subject: "Weather update",
+		HTML:    "<p>Sunny</p>",
+	}, received)
+}
+
+func TestHTTPAPISender_SendEmail_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid recipient", http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPAPISender(HTTPAPISenderOptions{Endpoint: server.URL, HTTPClient: server.Client()})
+	err := sender.SendEmail(context.Background(), testEmail)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "422")
+	assert.Contains(t, err.Error(), "invalid recipient")
+}
+
+func TestMaildirSender_SendEmail(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := NewMaildirSender(dir, "weather@example.com", logger.Discard())
+	require.NoError(t, err)
+
+	require.NoError(t, sender.SendEmail(context.Background(), testEmail))
+
+	delivered, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+	assert.True(t, strings.HasSuffix(delivered[0].Name(), ".eml"))
+
+	pending, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "new", delivered[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "To: <user@example.com>")
+	assert.Contains(t, string(raw), "Subject: Weather update")
+	assert.Contains(t, string(raw), "<p>Sunny</p>")
+}
+
+func TestLogSender_SendEmail(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, NewLogSender(&buf, logger.Discard()).SendEmail(context.Background(), testEmail))
+
+	assert.Contains(t, buf.String(), "To: user@example.com")
+	assert.Contains(t, buf.String(), "<p>Sunny</p>")
+}
+
+type recordingSender struct {
+	err   error
+	calls int
+}
+
+func (s *recordingSender) SendEmail(context.Context, out.SendEmailOptions) error {
+	s.calls++
+	return s.err
+}
+
+func TestFailoverSender_SendEmail(t *testing.T) {
+	primary := &recordingSender{err: errors.New("api down")}
+	secondary := &recordingSender{}
+	tertiary := &recordingSender{}
+
+	sender := NewFailoverSender(logger.Discard(),
+		FailoverBackend{Name: "http", Sender: primary},
+		FailoverBackend{Name: "smtp", Sender: secondary},
+		FailoverBackend{Name: "log", Sender: tertiary},
+	)
+
+	require.NoError(t, sender.SendEmail(context.Background(), testEmail))
+	assert.Equal(t, 1, primary.calls)
+	assert.Equal(t, 1, secondary.calls)
+	assert.Equal(t, 0, tertiary.calls)
+}
+
+func TestFailoverSender_SendEmail_AllFail(t *testing.T) {
+	sender := NewFailoverSender(logger.Discard(),
+		FailoverBackend{Name: "http", Sender: &recordingSender{err: errors.New("api down")}},
+		FailoverBackend{Name: "smtp", Sender: &recordingSender{err: errors.New("connection refused")}},
+	)
+
+	err := sender.SendEmail(context.Background(), testEmail)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "http: api down")
+	assert.Contains(t, err.Error(), "smtp: connection refused")
+}
+
+func TestNewEmailSender(t *testing.T) {
+	sender, err := NewEmailSender([]string{BackendLog}, BackendOptions{})
+	require.NoError(t, err)
+	assert.IsType(t, &LogSender{}, sender)
+
+	sender, err = NewEmailSender([]string{BackendHTTP, BackendMaildir}, BackendOptions{MaildirPath: t.TempDir()})
+	require.NoError(t, err)
+	assert.IsType(t, &FailoverSender{}, sender)
+
+	_, err = NewEmailSender([]string{"pigeon"}, BackendOptions{})
+	assert.EqualError(t, err, `unsupported email backend "pigeon"`)
+}
+
+type blockingSender struct{ release chan struct{} }
+
+func (s blockingSender) SendEmail(ctx context.Context, _ out.SendEmailOptions) error {
+	<-s.release
+	return nil
+}
+
+func TestWorkerPool_SendEmailStopsWaitingWhenCancelled(t *testing.T) {
+	sender := blockingSender{release: make(chan struct{})}
+	pool := NewWorkerPool(sender, 1)
+
+	busy := make(chan error, 1)
+	go func() { busy <- pool.SendEmail(context.Background(), testEmail) }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, pool.SendEmail(ctx, testEmail), context.Canceled)
+
+	close(sender.release)
+	assert.NoError(t, <-busy)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+}
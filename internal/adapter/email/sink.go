@@ -0,0 +1,82 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"weather-api/internal/core/ports/out"
+)
+
+// MemorySink keeps rendered emails in memory instead of sending them.
+type MemorySink struct {
+	mu     sync.Mutex
+	emails []out.SendEmailOptions
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emails = append(s.emails, opts)
+	return nil
+}
+
+// Emails returns a copy of every email written to the sink.
+func (s *MemorySink) Emails() []out.SendEmailOptions {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]out.SendEmailOptions(nil), s.emails...)
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// FileSink writes every rendered email to its own HTML file so it can be
+// opened in a browser.
+type FileSink struct {
+	dir string
+
+	mu     sync.Mutex
+	count  int
+	logger *slog.Logger
+}
+
+func NewFileSink(dir string, logger *slog.Logger) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		msg := fmt.Sprintf("unable to create dry-run directory %s: %v", dir, err)
+		logger.Error(msg)
+		return nil, errors.New(msg)
+	}
+	return &FileSink{dir: dir, logger: logger}, nil
+}
+
+func (s *FileSink) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	s.mu.Lock()
+	s.count++
+	name := fmt.Sprintf("%04d-%s.html", s.count, unsafeFileChars.ReplaceAllString(opts.To, "_"))
+	s.mu.Unlock()
+
+	content := fmt.Sprintf("<!--\nTo: %s\nSubject: %s\n-->\n%s",
+		html.EscapeString(opts.To), html.EscapeString(opts.Subject), opts.Body)
+
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		msg := fmt.Sprintf("unable to write dry-run email %s: %v", path, err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// Dir returns the directory the emails are written to.
+func (s *FileSink) Dir() string {
+	return s.dir
+}
@@ -0,0 +1,98 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"weather-api/internal/core/ports/out"
+)
+
+var ErrSenderClosed = errors.New("email sender is shut down")
+
+type emailJob struct {
+	ctx    context.Context
+	opts   out.SendEmailOptions
+	result chan error
+}
+
+// WorkerPool bounds the number of concurrent SMTP sessions. SendEmail still
+// blocks until its message is delivered so callers keep seeing send errors.
+type WorkerPool struct {
+	sender out.EmailSender
+	jobs   chan emailJob
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewWorkerPool(sender out.EmailSender, workers int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &WorkerPool{
+		sender: sender,
+		jobs:   make(chan emailJob),
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+func (p *WorkerPool) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrSenderClosed
+	}
+	result := make(chan error, 1)
+	select {
+	case p.jobs <- emailJob{ctx: ctx, opts: opts, result: result}:
+	case <-ctx.Done():
+		p.mu.RUnlock()
+		return ctx.Err()
+	}
+	p.mu.RUnlock()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown rejects new emails and waits for queued ones to be sent.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		if err := job.ctx.Err(); err != nil {
+			job.result <- err
+			continue
+		}
+		job.result <- p.sender.SendEmail(job.ctx, job.opts)
+	}
+}
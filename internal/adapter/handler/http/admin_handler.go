@@ -0,0 +1,166 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"weather-api/internal/core/ports/in"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/handler/http/middleware"
+	"weather-api/internal/adapter/handler/http/request"
+	"weather-api/internal/adapter/handler/http/response"
+)
+
+type AdminHandler struct {
+	adminUseCase in.AdminUseCase
+	logger       *slog.Logger
+}
+
+func NewAdminHandler(adminUseCase in.AdminUseCase, logger *slog.Logger) *AdminHandler {
+	return &AdminHandler{adminUseCase: adminUseCase, logger: logger}
+}
+
+func (h *AdminHandler) SearchSubscriptions(c *gin.Context) {
+	var req request.SearchSubscriptionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		writeError(c, httperrors.ErrInvalidInput)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	subs, err := h.adminUseCase.SearchSubscriptions(c, middleware.AdminActor(c), out.SearchSubscriptionsOptions{
+		Email:  req.Email,
+		City:   req.City,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		h.logger.WarnContext(c, "unable to search subscriptions", "error", err)
+		writeError(c, err)
+		return
+	}
+
+	resp := make([]response.AdminSubscriptionResponse, 0, len(subs))
+	for _, sub := range subs {
+		resp = append(resp, response.AdminSubscriptionResponse{
+			ID:           sub.ID,
+			Email:        sub.Email,
+			City:         sub.City.Name,
+			Frequency:    string(sub.Frequency),
+			Timezone:     sub.Timezone,
+			Confirmed:    sub.IsConfirmed,
+			Paused:       sub.IsPaused,
+			SevereAlerts: sub.SevereAlerts,
+			NextRunAt:    optionalTime(sub.NextRunAt),
+			LastSentAt:   sub.LastSentAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) GetDeliveryHistory(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.adminUseCase.GetDeliveryHistory(c, middleware.AdminActor(c), id)
+	if err != nil {
+		h.logger.WarnContext(c, "unable to get delivery history", "subscription_id", id, "error", err)
+		writeError(c, err)
+		return
+	}
+
+	resp := make([]response.DeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, response.DeliveryResponse{
+			Status:    string(delivery.Status),
+			Error:     delivery.Error,
+			CreatedAt: delivery.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) ConfirmSubscription(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUseCase.ConfirmSubscription(c, middleware.AdminActor(c), id); err != nil {
+		h.logger.WarnContext(c, "unable to force-confirm subscription", "subscription_id", id, "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Subscription confirmed"})
+}
+
+func (h *AdminHandler) DeleteSubscription(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUseCase.DeleteSubscription(c, middleware.AdminActor(c), id); err != nil {
+		h.logger.WarnContext(c, "unable to delete subscription", "subscription_id", id, "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Subscription deleted"})
+}
+
+func (h *AdminHandler) ListCities(c *gin.Context) {
+	stats, err := h.adminUseCase.ListCities(c, middleware.AdminActor(c))
+	if err != nil {
+		h.logger.WarnContext(c, "unable to list cities", "error", err)
+		writeError(c, err)
+		return
+	}
+
+	resp := make([]response.CityStatsResponse, 0, len(stats))
+	for _, cityStats := range stats {
+		resp = append(resp, response.CityStatsResponse{
+			ID:            cityStats.City.ID,
+			Name:          cityStats.City.Name,
+			Subscribers:   cityStats.Subscribers,
+			Subscriptions: cityStats.Subscriptions,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) TriggerSend(c *gin.Context) {
+	var req request.TriggerSendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, httperrors.ErrInvalidInput)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if err := h.adminUseCase.TriggerSend(c, middleware.AdminActor(c), req.Frequency); err != nil {
+		h.logger.WarnContext(c, "unable to trigger send", "frequency", req.Frequency, "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Weather updates are queued and will be sent by the scheduler shortly"})
+}
+
+func subscriptionID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		writeError(c, httperrors.ErrInvalidSubscriptionID)
+		return 0, false
+	}
+	return id, true
+}
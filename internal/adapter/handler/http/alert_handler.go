@@ -0,0 +1,65 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+
+	"weather-api/internal/adapter/handler/http/request"
+	"weather-api/internal/adapter/handler/http/response"
+)
+
+type AlertHandler struct {
+	alertUseCase in.AlertUseCase
+	logger       *slog.Logger
+}
+
+func NewAlertHandler(alertUseCase in.AlertUseCase, logger *slog.Logger) *AlertHandler {
+	return &AlertHandler{alertUseCase: alertUseCase, logger: logger}
+}
+
+func (h *AlertHandler) GetAlerts(c *gin.Context) {
+	city := c.Query("city")
+	cityReq := request.NewCityRequest(city)
+
+	if err := cityReq.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	alerts, err := h.alertUseCase.GetAlerts(c, city)
+	if err != nil {
+		h.logger.WarnContext(c, "unable to get alerts", "city", city, "error", err)
+		writeError(c, err)
+		return
+	}
+
+	resp := response.AlertsResponse{
+		City:   city,
+		Alerts: make([]response.AlertResponse, 0, len(alerts)),
+	}
+	for _, alert := range alerts {
+		resp.Alerts = append(resp.Alerts, response.AlertResponse{
+			Headline:    alert.Headline,
+			Event:       alert.Event,
+			Severity:    alert.Severity,
+			Urgency:     alert.Urgency,
+			Areas:       alert.Areas,
+			Description: alert.Description,
+			Instruction: alert.Instruction,
+			Effective:   optionalTime(alert.Effective),
+			Expires:     optionalTime(alert.Expires),
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/in"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/handler/http/middleware"
+	"weather-api/internal/adapter/handler/http/request"
+	"weather-api/internal/adapter/handler/http/response"
+)
+
+// APIKeyHandler serves the admin endpoints that manage API keys.
+type APIKeyHandler struct {
+	apiKeyUseCase in.APIKeyUseCase
+	logger        *slog.Logger
+}
+
+func NewAPIKeyHandler(apiKeyUseCase in.APIKeyUseCase, logger *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyUseCase: apiKeyUseCase, logger: logger}
+}
+
+func (h *APIKeyHandler) IssueKey(c *gin.Context) {
+	var req request.IssueAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, httperrors.ErrInvalidInput)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	key, plaintext, err := h.apiKeyUseCase.IssueAPIKey(c, middleware.AdminActor(c), out.IssueAPIKeyOptions{
+		Owner:             req.Owner,
+		Plan:              req.Plan,
+		RequestsPerMinute: req.RequestsPerMinute,
+		MonthlyQuota:      req.MonthlyQuota,
+	})
+	if err != nil {
+		h.logger.WarnContext(c, "unable to issue api key", "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response.IssuedAPIKeyResponse{APIKeyResponse: apiKeyResponse(key), Key: plaintext})
+}
+
+func (h *APIKeyHandler) ListKeys(c *gin.Context) {
+	keys, err := h.apiKeyUseCase.ListAPIKeys(c, middleware.AdminActor(c))
+	if err != nil {
+		h.logger.WarnContext(c, "unable to list api keys", "error", err)
+		writeError(c, err)
+		return
+	}
+
+	resp := make([]response.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		resp = append(resp, apiKeyResponse(key))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *APIKeyHandler) GetUsage(c *gin.Context) {
+	id, ok := apiKeyID(c)
+	if !ok {
+		return
+	}
+
+	usage, err := h.apiKeyUseCase.GetAPIKeyUsage(c, middleware.AdminActor(c), id)
+	if err != nil {
+		h.logger.WarnContext(c, "unable to get api key usage", "api_key_id", id, "error", err)
+		writeError(c, err)
+		return
+	}
+
+	resp := make([]response.APIKeyUsageResponse, 0, len(usage))
+	for _, month := range usage {
+		resp = append(resp, response.APIKeyUsageResponse{
+			Month:    month.Month.Format("2006-01"),
+			Requests: month.Requests,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	id, ok := apiKeyID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyUseCase.RevokeAPIKey(c, middleware.AdminActor(c), id); err != nil {
+		h.logger.WarnContext(c, "unable to revoke api key", "api_key_id", id, "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
+
+func apiKeyResponse(key domain.APIKey) response.APIKeyResponse {
+	return response.APIKeyResponse{
+		ID:                key.ID,
+		Prefix:            key.Prefix,
+		Owner:             key.Owner,
+		Plan:              string(key.Plan),
+		RequestsPerMinute: key.RequestsPerMinute,
+		MonthlyQuota:      key.MonthlyQuota,
+		MonthlyUsage:      key.MonthlyUsage,
+		CreatedAt:         key.CreatedAt,
+		LastUsedAt:        key.LastUsedAt,
+		RevokedAt:         key.RevokedAt,
+	}
+}
+
+func apiKeyID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		writeError(c, httperrors.ErrInvalidAPIKeyID)
+		return 0, false
+	}
+	return id, true
+}
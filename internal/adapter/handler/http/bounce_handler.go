@@ -0,0 +1,41 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/handler/http/request"
+)
+
+type BounceHandler struct {
+	bounceUseCase in.BounceUseCase
+	logger        *slog.Logger
+}
+
+func NewBounceHandler(bounceUseCase in.BounceUseCase, logger *slog.Logger) *BounceHandler {
+	return &BounceHandler{bounceUseCase: bounceUseCase, logger: logger}
+}
+
+func (h *BounceHandler) HandleWebhook(c *gin.Context) {
+	var req request.BounceWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, httperrors.ErrInvalidInput)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	suppressed, err := h.bounceUseCase.HandleBounces(c, req.BounceEvents())
+	if err != nil {
+		h.logger.WarnContext(c, "unable to handle bounce events", "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"received": len(req.Events), "suppressed": suppressed})
+}
@@ -1,16 +1,43 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"weather-api/internal/core/domain"
+)
+
+var (
+	ErrInvalidInput    = errors.New("invalid input")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrTooManyRequests = errors.New("too many requests")
+)
 
+// Request validation errors name the offending field so they are reported as
+// field-level problem details.
 var (
-	ErrInvalidInput           = errors.New("invalid input")
-	ErrInvalidEmail           = errors.New("invalid email format")
-	ErrInvalidFrequency       = errors.New("invalid frequency")
-	ErrCityRequired           = errors.New("city parameter is required")
-	ErrEmailRequired          = errors.New("email is required")
-	ErrTokenRequired          = errors.New("token is required")
-	ErrCityNotFound           = errors.New("city not found")
-	ErrEmailAlreadySubscribed = errors.New("email already subscribed")
-	ErrTokenNotFound          = errors.New("token not found")
-	ErrInvalidToken           = errors.New("invalid token")
+	ErrInvalidEmail          = domain.ValidationError{Field: "email", Message: "invalid email format"}
+	ErrInvalidFrequency      = domain.ValidationError{Field: "frequency", Message: "invalid frequency"}
+	ErrInvalidWeekday        = domain.ValidationError{Field: "weekday", Message: "weekday is required for weekly frequency"}
+	ErrInvalidIntervalHours  = domain.ValidationError{Field: "interval_hours", Message: "interval_hours must be between 1 and 23"}
+	ErrInvalidCronExpression = domain.ValidationError{Field: "cron_expression", Message: "invalid cron expression"}
+	ErrCronTooFrequent       = domain.ValidationError{Field: "cron_expression", Message: "cron expression must not fire more than once per hour"}
+	ErrInvalidTimezone       = domain.ValidationError{Field: "timezone", Message: "invalid timezone"}
+	ErrInvalidQuietHours     = domain.ValidationError{Field: "quiet_hours", Message: "quiet hours must be distinct HH:MM times"}
+	ErrIncompleteQuietHours  = domain.ValidationError{Field: "quiet_hours", Message: "quiet_hours_start and quiet_hours_end must be set together"}
+	ErrInvalidLimit          = domain.ValidationError{Field: "limit", Message: "limit must be between 1 and 500"}
+	ErrInvalidOffset         = domain.ValidationError{Field: "offset", Message: "offset must not be negative"}
+	ErrInvalidSubscriptionID = domain.ValidationError{Field: "id", Message: "invalid subscription id"}
+	ErrCityRequired          = domain.ValidationError{Field: "city", Message: "city parameter is required"}
+	ErrEmailRequired         = domain.ValidationError{Field: "email", Message: "email is required"}
+	ErrTokenRequired         = domain.ValidationError{Field: "token", Message: "token is required"}
+	ErrInvalidBounceEvents   = domain.ValidationError{Field: "events", Message: "events must contain between 1 and 1000 entries"}
+	ErrInvalidBounceType     = domain.ValidationError{Field: "type", Message: "type must be hard_bounce, soft_bounce or complaint"}
+	ErrInvalidWebhookURL     = domain.ValidationError{Field: "url", Message: "url must be an absolute https URL"}
+	ErrWebhookSecretTooShort = domain.ValidationError{Field: "secret", Message: "secret must be at least 16 characters"}
+	ErrInvalidPushEndpoint   = domain.ValidationError{Field: "endpoint", Message: "endpoint must be an absolute https URL"}
+	ErrInvalidPushKeys       = domain.ValidationError{Field: "keys", Message: "keys must contain a base64url p256dh public key and auth secret"}
+	ErrOwnerRequired         = domain.ValidationError{Field: "owner", Message: "owner is required"}
+	ErrInvalidPlan           = domain.ValidationError{Field: "plan", Message: "plan must be free, standard or unlimited"}
+	ErrInvalidRateLimit      = domain.ValidationError{Field: "requests_per_minute", Message: "requests_per_minute must not be negative"}
+	ErrInvalidQuota          = domain.ValidationError{Field: "monthly_quota", Message: "monthly_quota must not be negative"}
+	ErrInvalidAPIKeyID       = domain.ValidationError{Field: "id", Message: "invalid api key id"}
 )
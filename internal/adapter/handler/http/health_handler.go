@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+
+	"weather-api/internal/adapter/handler/http/response"
+	"weather-api/internal/core/domain"
+)
+
+type HealthHandler struct {
+	healthUseCase in.HealthUseCase
+}
+
+func NewHealthHandler(healthUseCase in.HealthUseCase) *HealthHandler {
+	return &HealthHandler{healthUseCase: healthUseCase}
+}
+
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	writeHealthReport(c, h.healthUseCase.Liveness(c))
+}
+
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	writeHealthReport(c, h.healthUseCase.Readiness(c))
+}
+
+func writeHealthReport(c *gin.Context, report domain.HealthReport) {
+	resp := response.HealthResponse{Status: string(report.Status)}
+	if len(report.Components) > 0 {
+		resp.Components = make(map[string]response.ComponentHealthResponse, len(report.Components))
+	}
+	for _, component := range report.Components {
+		resp.Components[component.Name] = response.ComponentHealthResponse{
+			Status:    string(component.Status),
+			Critical:  component.Critical,
+			LatencyMS: component.Latency.Milliseconds(),
+			CheckedAt: component.CheckedAt,
+		}
+	}
+
+	status := http.StatusOK
+	if report.Status == domain.HealthStatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, resp)
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+)
+
+const (
+	AdminActorKey  = "admin_actor"
+	AdminKeyHeader = "X-Admin-Key"
+)
+
+type AdminAuthOptions struct {
+	APIKey   string
+	Username string
+	Password string
+}
+
+func (o AdminAuthOptions) Enabled() bool {
+	return o.APIKey != "" || (o.Username != "" && o.Password != "")
+}
+
+// AdminAuth accepts either the X-Admin-Key header or HTTP basic auth and stores
+// the authenticated actor in the gin context for audit logging.
+func AdminAuth(opts AdminAuthOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := c.GetHeader(AdminKeyHeader); key != "" && opts.APIKey != "" && secureEqual(key, opts.APIKey) {
+			c.Set(AdminActorKey, "api-key")
+			c.Next()
+			return
+		}
+
+		if user, pass, ok := c.Request.BasicAuth(); ok && opts.Username != "" && opts.Password != "" &&
+			secureEqual(user, opts.Username) && secureEqual(pass, opts.Password) {
+			c.Set(AdminActorKey, user)
+			c.Next()
+			return
+		}
+
+		c.Header("WWW-Authenticate", `Basic realm="admin"`)
+		_ = c.Error(httperrors.ErrUnauthorized)
+		c.Abort()
+	}
+}
+
+func AdminActor(c *gin.Context) string {
+	return c.GetString(AdminActorKey)
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/ratelimit"
+)
+
+const (
+	APIKeyHeader     = "X-API-Key"
+	APIKeyContextKey = "api_key"
+)
+
+type APIKeyAuthOptions struct {
+	// Required rejects requests without a key. Otherwise they pass through as
+	// anonymous requests and only the per-IP limits apply.
+	Required bool
+	Limiter  ratelimit.Limiter
+	Logger   *slog.Logger
+}
+
+// APIKeyAuth authenticates the X-API-Key header, applies the key's per-minute
+// rate limit and counts the request against the key's monthly quota. Requests
+// rejected by the rate limit do not count towards the quota.
+func APIKeyAuth(apiKeys in.APIKeyUseCase, opts APIKeyAuthOptions) gin.HandlerFunc {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(c *gin.Context) {
+		plaintext := c.GetHeader(APIKeyHeader)
+		if plaintext == "" {
+			if opts.Required {
+				_ = c.Error(httperrors.ErrUnauthorized)
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		key, err := apiKeys.Authenticate(c, plaintext)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Set(APIKeyContextKey, key)
+
+		if opts.Limiter != nil && key.RequestsPerMinute > 0 {
+			rule := RateLimitRule{
+				Name:  "api_key",
+				Limit: ratelimit.Limit{Requests: key.RequestsPerMinute, Per: time.Minute},
+				Key:   func(*gin.Context) string { return strconv.FormatInt(key.ID, 10) },
+			}
+			if !limitRequest(c, opts.Limiter, logger, []RateLimitRule{rule}) {
+				return
+			}
+		}
+
+		key, err = apiKeys.RecordUsage(c, key)
+		if key.MonthlyQuota > 0 {
+			c.Header("X-Quota-Limit", strconv.FormatInt(key.MonthlyQuota, 10))
+			c.Header("X-Quota-Remaining", strconv.FormatInt(max(0, key.MonthlyQuota-key.MonthlyUsage), 10))
+		}
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyQuotaExceeded):
+			c.Header("Retry-After", strconv.Itoa(seconds(time.Until(nextMonth(time.Now())))))
+			_ = c.Error(err)
+			c.Abort()
+			return
+		case err != nil:
+			// Losing a usage count is better than failing the request.
+			logger.ErrorContext(c, "unable to record api key usage", "api_key_id", key.ID, "error", err)
+		}
+		c.Set(APIKeyContextKey, key)
+		c.Next()
+	}
+}
+
+// APIKeyFromContext returns the key that authenticated the request, if any.
+func APIKeyFromContext(c *gin.Context) (domain.APIKey, bool) {
+	key, ok := c.Get(APIKeyContextKey)
+	if !ok {
+		return domain.APIKey{}, false
+	}
+	apiKey, ok := key.(domain.APIKey)
+	return apiKey, ok
+}
+
+// Anonymous applies a rate limit rule only to requests without an API key;
+// keyed requests are limited per key instead.
+func Anonymous(key func(c *gin.Context) string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		if _, ok := APIKeyFromContext(c); ok {
+			return ""
+		}
+		return key(c)
+	}
+}
+
+func nextMonth(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
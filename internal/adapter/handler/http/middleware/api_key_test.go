@@ -0,0 +1,127 @@
+//go:build unit
+// +build unit
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/adapter/handler/http/response"
+	"weather-api/internal/adapter/ratelimit"
+	"weather-api/internal/util/logger"
+)
+
+type fakeAPIKeys struct {
+	in.APIKeyUseCase
+	keys  map[string]domain.APIKey
+	usage map[int64]int64
+}
+
+func (f *fakeAPIKeys) Authenticate(_ context.Context, plaintext string) (domain.APIKey, error) {
+	key, ok := f.keys[plaintext]
+	if !ok {
+		return domain.APIKey{}, domain.ErrInvalidAPIKey
+	}
+	return key, nil
+}
+
+func (f *fakeAPIKeys) RecordUsage(_ context.Context, key domain.APIKey) (domain.APIKey, error) {
+	f.usage[key.ID]++
+	key.MonthlyUsage = f.usage[key.ID]
+	if key.QuotaExceeded() {
+		return key, domain.ErrAPIKeyQuotaExceeded
+	}
+	return key, nil
+}
+
+func newAPIKeyRouter(apiKeys *fakeAPIKeys, required bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewMemoryLimiter()
+	r := gin.New()
+	r.Use(Problems(logger.Discard()))
+	r.GET("/weather",
+		APIKeyAuth(apiKeys, APIKeyAuthOptions{Required: required, Limiter: limiter, Logger: logger.Discard()}),
+		RateLimit(limiter, logger.Discard(), RateLimitRule{
+			Name:  "ip",
+			Limit: ratelimit.Limit{Requests: 1, Per: time.Hour},
+			Key:   Anonymous(ClientIP),
+		}),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	return r
+}
+
+func getWeather(r http.Handler, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/weather", nil)
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func problemCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	var problem response.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return problem.Code
+}
+
+func TestAPIKeyAuth_AnonymousAndInvalidKeys(t *testing.T) {
+	apiKeys := &fakeAPIKeys{keys: map[string]domain.APIKey{}, usage: map[int64]int64{}}
+
+	assert.Equal(t, http.StatusOK, getWeather(newAPIKeyRouter(apiKeys, false), "").Code)
+
+	invalid := getWeather(newAPIKeyRouter(apiKeys, false), "wk_unknown")
+	assert.Equal(t, http.StatusUnauthorized, invalid.Code)
+	assert.Equal(t, "invalid_api_key", problemCode(t, invalid))
+
+	assert.Equal(t, http.StatusUnauthorized, getWeather(newAPIKeyRouter(apiKeys, true), "").Code)
+}
+
+func TestAPIKeyAuth_LimitsPerKeyInsteadOfPerIP(t *testing.T) {
+	apiKeys := &fakeAPIKeys{
+		keys:  map[string]domain.APIKey{"wk_partner": {ID: 1, RequestsPerMinute: 2}},
+		usage: map[int64]int64{},
+	}
+	r := newAPIKeyRouter(apiKeys, false)
+
+	first := getWeather(r, "wk_partner")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+	require.Equal(t, http.StatusOK, getWeather(r, "wk_partner").Code, "the per-IP limit does not apply")
+
+	limited := getWeather(r, "wk_partner")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "rate_limited", problemCode(t, limited))
+	assert.Equal(t, int64(2), apiKeys.usage[1], "rate limited requests are not counted")
+}
+
+func TestAPIKeyAuth_RejectsOverQuota(t *testing.T) {
+	apiKeys := &fakeAPIKeys{
+		keys:  map[string]domain.APIKey{"wk_partner": {ID: 1, MonthlyQuota: 1}},
+		usage: map[int64]int64{},
+	}
+	r := newAPIKeyRouter(apiKeys, false)
+
+	ok := getWeather(r, "wk_partner")
+	require.Equal(t, http.StatusOK, ok.Code)
+	assert.Equal(t, "1", ok.Header().Get("X-Quota-Limit"))
+	assert.Equal(t, "0", ok.Header().Get("X-Quota-Remaining"))
+
+	exceeded := getWeather(r, "wk_partner")
+	assert.Equal(t, http.StatusTooManyRequests, exceeded.Code)
+	assert.Equal(t, "quota_exceeded", problemCode(t, exceeded))
+	assert.NotEmpty(t, exceeded.Header().Get("Retry-After"))
+}
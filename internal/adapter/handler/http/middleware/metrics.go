@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics counts requests and observes their latency by method, route
+// template and status. Route templates keep the label set bounded.
+func Metrics(reg prometheus.Registerer) gin.HandlerFunc {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled",
+	}, []string{"method", "route", "status"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of HTTP requests",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+	reg.MustRegister(requests, duration)
+
+	return func(c *gin.Context) {
+		started := time.Now()
+		c.Next()
+
+		labels := prometheus.Labels{
+			"method": c.Request.Method,
+			"route":  routeTemplate(c),
+			"status": strconv.Itoa(c.Writer.Status()),
+		}
+		requests.With(labels).Inc()
+		duration.With(labels).Observe(time.Since(started).Seconds())
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/handler/http/response"
+	"weather-api/internal/core/domain"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:weather-api:problem:"
+)
+
+var problemTypes = []struct {
+	err    error
+	status int
+	code   string
+	title  string
+}{
+	{httperrors.ErrInvalidInput, http.StatusBadRequest, "invalid_body", "Malformed request body"},
+	{httperrors.ErrUnauthorized, http.StatusUnauthorized, "unauthorized", "Unauthorized"},
+	{httperrors.ErrTooManyRequests, http.StatusTooManyRequests, "rate_limited", "Too many requests"},
+	{domain.ErrInvalidToken, http.StatusBadRequest, "invalid_token", "Invalid token"},
+	{domain.ErrTokenNotFound, http.StatusNotFound, "token_not_found", "Token not found"},
+	{domain.ErrCityNotFound, http.StatusNotFound, "city_not_found", "City not found"},
+	{domain.ErrSubscriptionNotFound, http.StatusNotFound, "subscription_not_found", "Subscription not found"},
+	{domain.ErrSubscriberNotFound, http.StatusNotFound, "subscriber_not_found", "Subscriber not found"},
+	{domain.ErrEmailAlreadySubscribed, http.StatusConflict, "already_subscribed", "Email already subscribed"},
+	{domain.ErrSubscriptionAlreadyConfirmed, http.StatusConflict, "already_confirmed", "Subscription already confirmed"},
+	{domain.ErrEmailSuppressed, http.StatusUnprocessableEntity, "email_suppressed", "Email address is suppressed"},
+	{domain.ErrInvalidAPIKey, http.StatusUnauthorized, "invalid_api_key", "Invalid API key"},
+	{domain.ErrAPIKeyNotFound, http.StatusNotFound, "api_key_not_found", "API key not found"},
+	{domain.ErrAPIKeyQuotaExceeded, http.StatusTooManyRequests, "quota_exceeded", "Monthly quota exceeded"},
+	{domain.ErrSendAlreadyQueued, http.StatusConflict, "send_already_queued", "Send already queued"},
+}
+
+// Problems writes the last error a handler attached with c.Error as an
+// application/problem+json response. Handlers that already wrote a response
+// are left alone. Unknown errors become a 500 without internal details.
+func Problems(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		problem := NewProblem(err)
+		problem.Instance = c.Request.URL.Path
+		problem.RequestID = GetRequestID(c)
+		if problem.Status >= http.StatusInternalServerError {
+			logger.ErrorContext(c.Request.Context(), "request failed",
+				"method", c.Request.Method, "route", c.FullPath(), "error", err)
+		}
+
+		c.Header("Content-Type", ProblemContentType)
+		c.AbortWithStatusJSON(problem.Status, problem)
+	}
+}
+
+// NewProblem maps domain and validation errors to problem details with a
+// stable code.
+func NewProblem(err error) response.Problem {
+	var validationErr domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return response.Problem{
+			Type:   problemTypePrefix + "validation_failed",
+			Title:  "Validation failed",
+			Status: http.StatusBadRequest,
+			Detail: validationErr.Message,
+			Code:   "validation_failed",
+			Errors: []response.FieldError{{Field: validationErr.Field, Message: validationErr.Message}},
+		}
+	}
+
+	for _, problemType := range problemTypes {
+		if errors.Is(err, problemType.err) {
+			return response.Problem{
+				Type:   problemTypePrefix + problemType.code,
+				Title:  problemType.title,
+				Status: problemType.status,
+				Detail: problemType.err.Error(),
+				Code:   problemType.code,
+			}
+		}
+	}
+
+	return response.Problem{
+		Type:   problemTypePrefix + "internal_error",
+		Title:  "Internal server error",
+		Status: http.StatusInternalServerError,
+		Code:   "internal_error",
+	}
+}
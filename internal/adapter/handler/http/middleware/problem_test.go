@@ -0,0 +1,118 @@
+//go:build unit
+// +build unit
+
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/handler/http/response"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/util/logger"
+)
+
+func serveProblem(t *testing.T, err error, header http.Header) (*httptest.ResponseRecorder, response.Problem) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID(), Problems(logger.Discard()))
+	r.GET("/weather", func(c *gin.Context) {
+		_ = c.Error(err)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/weather", nil)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var problem response.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	return rec, problem
+}
+
+func TestProblems_ValidationError(t *testing.T) {
+	rec, problem := serveProblem(t, httperrors.ErrCityRequired, nil)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "validation_failed", problem.Code)
+	assert.Equal(t, "city parameter is required", problem.Detail)
+	assert.Equal(t, []response.FieldError{{Field: "city", Message: "city parameter is required"}}, problem.Errors)
+	assert.Equal(t, "/weather", problem.Instance)
+}
+
+func TestProblems_WrappedDomainError(t *testing.T) {
+	err := fmt.Errorf("unable to confirm subscription 7: %w", domain.ErrSubscriptionAlreadyConfirmed)
+	rec, problem := serveProblem(t, err, nil)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "already_confirmed", problem.Code)
+	assert.Equal(t, "urn:weather-api:problem:already_confirmed", problem.Type)
+	assert.Equal(t, "subscription already confirmed", problem.Detail)
+	assert.Empty(t, problem.Errors)
+}
+
+func TestProblems_UnknownErrorHidesDetails(t *testing.T) {
+	rec, problem := serveProblem(t, errors.New("pq: connection refused"), nil)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "internal_error", problem.Code)
+	assert.Empty(t, problem.Detail)
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+}
+
+func TestProblems_RequestID(t *testing.T) {
+	rec, problem := serveProblem(t, domain.ErrCityNotFound, http.Header{RequestIDHeader: {"abc-123"}})
+	assert.Equal(t, "abc-123", problem.RequestID)
+	assert.Equal(t, "abc-123", rec.Header().Get(RequestIDHeader))
+
+	rec, problem = serveProblem(t, domain.ErrCityNotFound, http.Header{RequestIDHeader: {"bad id\n"}})
+	assert.Len(t, problem.RequestID, 32)
+	assert.Equal(t, problem.RequestID, rec.Header().Get(RequestIDHeader))
+}
+
+func TestProblems_KeepsWrittenResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Problems(logger.Discard()))
+	r.GET("/", func(c *gin.Context) {
+		c.JSON(http.StatusAccepted, gin.H{"message": "queued"})
+		_ = c.Error(errors.New("logged only"))
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.JSONEq(t, `{"message":"queued"}`, rec.Body.String())
+}
+
+func TestRequestID_PropagatesToRequestContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID())
+	var fromContext string
+	r.GET("/", func(c *gin.Context) {
+		fromContext = logger.RequestID(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "abc-123", fromContext)
+}
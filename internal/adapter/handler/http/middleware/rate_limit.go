@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/ratelimit"
+)
+
+// maxKeyedBodySize caps how much of a request body JSONField reads.
+const maxKeyedBodySize = 1 << 20
+
+// RateLimitRule limits requests that share the value returned by Key. Requests
+// with an empty key are not limited by the rule.
+type RateLimitRule struct {
+	Name  string
+	Limit ratelimit.Limit
+	Key   func(c *gin.Context) string
+}
+
+// RateLimit takes a token from the bucket of every rule and rejects the request
+// with 429 once one of them is empty. The RateLimit-* headers describe the rule
+// closest to its limit. Limiter errors let the request through so an outage
+// does not take the API down with it.
+func RateLimit(limiter ratelimit.Limiter, logger *slog.Logger, rules ...RateLimitRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limitRequest(c, limiter, logger, rules) {
+			c.Next()
+		}
+	}
+}
+
+// limitRequest applies rules to the request and reports whether it may
+// proceed. Rejected requests are aborted with ErrTooManyRequests.
+func limitRequest(c *gin.Context, limiter ratelimit.Limiter, logger *slog.Logger, rules []RateLimitRule) bool {
+	ctx := c.Request.Context()
+
+	var tightest *ratelimit.Result
+	var tightestLimit ratelimit.Limit
+	for _, rule := range rules {
+		if !rule.Limit.Enabled() {
+			continue
+		}
+		value := rule.Key(c)
+		if value == "" {
+			continue
+		}
+
+		res, err := limiter.Allow(ctx, rule.Name+":"+hashKey(value), rule.Limit)
+		if err != nil {
+			logger.ErrorContext(ctx, "rate limit check failed", "rule", rule.Name, "error", err)
+			continue
+		}
+		if tightest == nil || !res.Allowed || res.Remaining < tightest.Remaining {
+			tightest, tightestLimit = &res, rule.Limit
+		}
+		if !res.Allowed {
+			break
+		}
+	}
+	if tightest == nil {
+		return true
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(seconds(tightest.Reset)))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", tightestLimit.Requests, seconds(tightestLimit.Per)))
+	if !tightest.Allowed {
+		c.Header("Retry-After", strconv.Itoa(max(1, seconds(tightest.RetryAfter))))
+		_ = c.Error(httperrors.ErrTooManyRequests)
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// ClientIP keys a rule by the client address as resolved by gin's trusted
+// proxy settings.
+func ClientIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// JSONField keys a rule by a top-level string field of the JSON body, compared
+// case-insensitively. The body is restored for the handler.
+func JSONField(field string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxKeyedBodySize))
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+		if err != nil {
+			return ""
+		}
+
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(body, &fields) != nil {
+			return ""
+		}
+		var value string
+		if json.Unmarshal(fields[field], &value) != nil {
+			return ""
+		}
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+}
+
+// hashKey keeps client addresses and emails out of limiter keys.
+func hashKey(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,80 @@
+//go:build unit
+// +build unit
+
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/adapter/handler/http/response"
+	"weather-api/internal/adapter/ratelimit"
+	"weather-api/internal/util/logger"
+)
+
+func newRateLimitedRouter(rules ...RateLimitRule) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Problems(logger.Discard()))
+	r.POST("/subscribe", RateLimit(ratelimit.NewMemoryLimiter(), logger.Discard(), rules...), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+	return r
+}
+
+func subscribe(r http.Handler, remoteAddr, email string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{"email":"`+email+`","city":"Kyiv"}`))
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_RejectsWithProblemAndHeaders(t *testing.T) {
+	r := newRateLimitedRouter(RateLimitRule{Name: "ip", Limit: ratelimit.Limit{Requests: 2, Per: time.Minute}, Key: ClientIP})
+
+	first := subscribe(r, "192.0.2.1:1234", "a@example.com")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", first.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+	assert.Contains(t, first.Body.String(), "a@example.com", "the handler still reads the body")
+
+	require.Equal(t, http.StatusOK, subscribe(r, "192.0.2.1:1234", "b@example.com").Code)
+
+	denied := subscribe(r, "192.0.2.1:1234", "c@example.com")
+	require.Equal(t, http.StatusTooManyRequests, denied.Code)
+	assert.Equal(t, "30", denied.Header().Get("Retry-After"))
+	assert.Equal(t, "0", denied.Header().Get("RateLimit-Remaining"))
+	var problem response.Problem
+	require.NoError(t, json.Unmarshal(denied.Body.Bytes(), &problem))
+	assert.Equal(t, "rate_limited", problem.Code)
+
+	assert.Equal(t, http.StatusOK, subscribe(r, "192.0.2.2:1234", "c@example.com").Code)
+}
+
+func TestRateLimit_KeysByNormalizedEmail(t *testing.T) {
+	r := newRateLimitedRouter(
+		RateLimitRule{Name: "ip", Limit: ratelimit.Limit{Requests: 10, Per: time.Minute}, Key: ClientIP},
+		RateLimitRule{Name: "email", Limit: ratelimit.Limit{Requests: 1, Per: time.Hour}, Key: JSONField("email")},
+	)
+
+	require.Equal(t, http.StatusOK, subscribe(r, "192.0.2.1:1234", "user@example.com").Code)
+
+	denied := subscribe(r, "192.0.2.2:1234", " User@Example.com ")
+	assert.Equal(t, http.StatusTooManyRequests, denied.Code, "a new address does not reset the email limit")
+	assert.Equal(t, "1;w=3600", denied.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusOK, subscribe(r, "192.0.2.1:1234", "other@example.com").Code)
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+)
+
+const WebhookSecretHeader = "X-Webhook-Secret"
+
+// WebhookSecret rejects inbound webhook calls that do not carry the shared secret.
+func WebhookSecret(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if provided := c.GetHeader(WebhookSecretHeader); provided == "" || !secureEqual(provided, secret) {
+			_ = c.Error(httperrors.ErrUnauthorized)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
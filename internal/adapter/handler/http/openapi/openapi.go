@@ -0,0 +1,31 @@
+// Package openapi serves the OpenAPI 3 document of the versioned API and a
+// documentation page rendered from it.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	//go:embed openapi.json
+	spec []byte
+
+	//go:embed docs.html
+	docs []byte
+)
+
+// Spec returns the raw OpenAPI document.
+func Spec() []byte {
+	return spec
+}
+
+func ServeSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", spec)
+}
+
+func ServeDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docs)
+}
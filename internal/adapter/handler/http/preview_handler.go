@@ -0,0 +1,69 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"weather-api/internal/core/ports/in"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/gin-gonic/gin"
+
+	"weather-api/internal/adapter/handler/http/middleware"
+	"weather-api/internal/adapter/handler/http/request"
+)
+
+type PreviewHandler struct {
+	previewUseCase in.PreviewUseCase
+	logger         *slog.Logger
+}
+
+func NewPreviewHandler(previewUseCase in.PreviewUseCase, logger *slog.Logger) *PreviewHandler {
+	return &PreviewHandler{previewUseCase: previewUseCase, logger: logger}
+}
+
+func (h *PreviewHandler) PreviewUpdate(c *gin.Context) {
+	token, ok := previewToken(c)
+	if !ok {
+		return
+	}
+
+	email, err := h.previewUseCase.PreviewUpdate(c, middleware.AdminActor(c), token)
+	if err != nil {
+		h.logger.WarnContext(c, "unable to preview update email", "error", err)
+		writeError(c, err)
+		return
+	}
+	writeEmailPreview(c, email)
+}
+
+func (h *PreviewHandler) PreviewConfirmation(c *gin.Context) {
+	token, ok := previewToken(c)
+	if !ok {
+		return
+	}
+
+	email, err := h.previewUseCase.PreviewConfirmation(c, middleware.AdminActor(c), token)
+	if err != nil {
+		h.logger.WarnContext(c, "unable to preview confirmation email", "error", err)
+		writeError(c, err)
+		return
+	}
+	writeEmailPreview(c, email)
+}
+
+func previewToken(c *gin.Context) (string, bool) {
+	token := c.Query("token")
+	if err := request.NewTokenRequest(token).Validate(); err != nil {
+		writeError(c, err)
+		return "", false
+	}
+	return token, true
+}
+
+// writeEmailPreview renders the email body as a page; recipient and subject go
+// into headers so the HTML is exactly what would be sent.
+func writeEmailPreview(c *gin.Context, email out.SendEmailOptions) {
+	c.Header("X-Email-To", email.To)
+	c.Header("X-Email-Subject", email.Subject)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(email.Body))
+}
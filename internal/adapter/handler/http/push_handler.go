@@ -0,0 +1,67 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/handler/http/request"
+)
+
+type PushHandler struct {
+	pushUseCase    in.PushUseCase
+	vapidPublicKey string
+	logger         *slog.Logger
+}
+
+func NewPushHandler(pushUseCase in.PushUseCase, vapidPublicKey string, logger *slog.Logger) *PushHandler {
+	return &PushHandler{pushUseCase: pushUseCase, vapidPublicKey: vapidPublicKey, logger: logger}
+}
+
+// PublicKey returns the VAPID key browsers pass to pushManager.subscribe.
+func (h *PushHandler) PublicKey(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"public_key": h.vapidPublicKey})
+}
+
+func (h *PushHandler) Register(c *gin.Context) {
+	token := c.Param("token")
+	if err := request.NewTokenRequest(token).Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	var req request.PushSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, httperrors.ErrInvalidInput)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if err := h.pushUseCase.RegisterPush(c, token, req.PushSubscription()); err != nil {
+		h.logger.WarnContext(c, "unable to register push subscription", "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Push notifications enabled"})
+}
+
+func (h *PushHandler) Remove(c *gin.Context) {
+	token := c.Param("token")
+	if err := request.NewTokenRequest(token).Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if err := h.pushUseCase.RemovePush(c, token); err != nil {
+		h.logger.WarnContext(c, "unable to remove push subscription", "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Push notifications disabled"})
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"strings"
+	"weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/core/domain"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 500
+)
+
+type SearchSubscriptionsRequest struct {
+	Email  string `form:"email"`
+	City   string `form:"city"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+func (r *SearchSubscriptionsRequest) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	r.City = strings.TrimSpace(r.City)
+	if r.Limit == 0 {
+		r.Limit = defaultSearchLimit
+	}
+	if r.Limit < 0 || r.Limit > maxSearchLimit {
+		return errors.ErrInvalidLimit
+	}
+	if r.Offset < 0 {
+		return errors.ErrInvalidOffset
+	}
+	return nil
+}
+
+type TriggerSendRequest struct {
+	Frequency domain.Frequency `json:"frequency"`
+}
+
+func (r *TriggerSendRequest) Validate() error {
+	if !r.Frequency.IsValid() {
+		return errors.ErrInvalidFrequency
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package request
+
+import (
+	"strings"
+	"weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/core/domain"
+)
+
+type IssueAPIKeyRequest struct {
+	Owner string            `json:"owner"`
+	Plan  domain.APIKeyPlan `json:"plan"`
+	// RequestsPerMinute and MonthlyQuota override the plan's limits; 0 means
+	// unlimited.
+	RequestsPerMinute *int   `json:"requests_per_minute"`
+	MonthlyQuota      *int64 `json:"monthly_quota"`
+}
+
+func (r *IssueAPIKeyRequest) Validate() error {
+	r.Owner = strings.TrimSpace(r.Owner)
+	if r.Owner == "" {
+		return errors.ErrOwnerRequired
+	}
+	if r.Plan == "" {
+		r.Plan = domain.APIKeyPlanFree
+	}
+	if !r.Plan.IsValid() {
+		return errors.ErrInvalidPlan
+	}
+	if r.RequestsPerMinute != nil && *r.RequestsPerMinute < 0 {
+		return errors.ErrInvalidRateLimit
+	}
+	if r.MonthlyQuota != nil && *r.MonthlyQuota < 0 {
+		return errors.ErrInvalidQuota
+	}
+	return nil
+}
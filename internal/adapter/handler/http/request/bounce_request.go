@@ -0,0 +1,62 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/core/domain"
+)
+
+const maxBounceEvents = 1000
+
+type BounceEventRequest struct {
+	Email     string            `json:"email"`
+	Type      domain.BounceType `json:"type"`
+	Reason    string            `json:"reason"`
+	Timestamp *time.Time        `json:"timestamp,omitempty"`
+}
+
+type BounceWebhookRequest struct {
+	Events []BounceEventRequest `json:"events"`
+}
+
+func (r *BounceWebhookRequest) Validate() error {
+	if len(r.Events) == 0 || len(r.Events) > maxBounceEvents {
+		return errors.ErrInvalidBounceEvents
+	}
+	for i := range r.Events {
+		event := &r.Events[i]
+		event.Email = strings.TrimSpace(event.Email)
+		if !isValidEmail(event.Email) {
+			return eventError(i, errors.ErrInvalidEmail)
+		}
+		if !event.Type.IsValid() {
+			return eventError(i, errors.ErrInvalidBounceType)
+		}
+	}
+	return nil
+}
+
+// eventError points a validation error at the event it was found in.
+func eventError(index int, err domain.ValidationError) domain.ValidationError {
+	err.Field = fmt.Sprintf("events[%d].%s", index, err.Field)
+	return err
+}
+
+func (r *BounceWebhookRequest) BounceEvents() []domain.BounceEvent {
+	events := make([]domain.BounceEvent, 0, len(r.Events))
+	for _, event := range r.Events {
+		occurredAt := time.Now()
+		if event.Timestamp != nil {
+			occurredAt = *event.Timestamp
+		}
+		events = append(events, domain.BounceEvent{
+			Email:      event.Email,
+			Type:       event.Type,
+			Reason:     event.Reason,
+			OccurredAt: occurredAt,
+		})
+	}
+	return events
+}
@@ -0,0 +1,51 @@
+package request
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/core/domain"
+)
+
+const (
+	p256dhKeySize  = 65
+	authSecretSize = 16
+)
+
+// PushSubscriptionRequest is the JSON form of a browser PushSubscription.
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+func (r *PushSubscriptionRequest) Validate() error {
+	r.Endpoint = strings.TrimSpace(r.Endpoint)
+	parsed, err := url.Parse(r.Endpoint)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return errors.ErrInvalidPushEndpoint
+	}
+	if decodedLen(r.Keys.P256dh) != p256dhKeySize || decodedLen(r.Keys.Auth) != authSecretSize {
+		return errors.ErrInvalidPushKeys
+	}
+	return nil
+}
+
+func (r *PushSubscriptionRequest) PushSubscription() domain.PushSubscription {
+	return domain.PushSubscription{
+		Endpoint: r.Endpoint,
+		P256dh:   r.Keys.P256dh,
+		Auth:     r.Keys.Auth,
+	}
+}
+
+func decodedLen(value string) int {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return -1
+	}
+	return len(decoded)
+}
@@ -2,14 +2,36 @@ package request
 
 import (
 	"strings"
+	"time"
 	"weather-api/internal/adapter/handler/http/errors"
 	"weather-api/internal/core/domain"
+	"weather-api/internal/util/cronutil"
 )
 
+const minCustomInterval = time.Hour
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
 type SubscribeRequest struct {
-	Email     string           `json:"email"`
-	City      string           `json:"city"`
-	Frequency domain.Frequency `json:"frequency"`
+	Email          string           `json:"email"`
+	City           string           `json:"city"`
+	Frequency      domain.Frequency `json:"frequency"`
+	Weekday        string           `json:"weekday,omitempty"`
+	IntervalHours  int              `json:"interval_hours,omitempty"`
+	CronExpression string           `json:"cron_expression,omitempty"`
+	Timezone       string           `json:"timezone,omitempty"`
+	QuietStart     string           `json:"quiet_hours_start,omitempty"`
+	QuietEnd       string           `json:"quiet_hours_end,omitempty"`
+	QuietSummary   bool             `json:"quiet_hours_summary"`
+	SevereAlerts   bool             `json:"severe_alerts"`
 }
 
 func (r *SubscribeRequest) Validate() error {
@@ -25,10 +47,85 @@ func (r *SubscribeRequest) Validate() error {
 		return errors.ErrCityRequired
 	}
 
-	if r.Frequency != domain.FrequencyDaily && r.Frequency != domain.FrequencyHourly {
+	if !r.Frequency.IsValid() {
 		return errors.ErrInvalidFrequency
 	}
 
+	if err := r.validateSchedule(); err != nil {
+		return err
+	}
+
+	return r.validateQuietHours()
+}
+
+func (r *SubscribeRequest) Schedule() domain.Schedule {
+	return domain.Schedule{
+		Weekday:        weekdays[strings.ToLower(strings.TrimSpace(r.Weekday))],
+		IntervalHours:  r.IntervalHours,
+		CronExpression: strings.TrimSpace(r.CronExpression),
+	}
+}
+
+func (r *SubscribeRequest) QuietHours() domain.QuietHours {
+	start, startOK := domain.ParseClock(r.QuietStart)
+	end, endOK := domain.ParseClock(r.QuietEnd)
+	if !startOK || !endOK {
+		return domain.QuietHours{}
+	}
+	return domain.QuietHours{
+		Start:       start,
+		End:         end,
+		SendSummary: r.QuietSummary,
+	}
+}
+
+func (r *SubscribeRequest) validateQuietHours() error {
+	if timezone := strings.TrimSpace(r.Timezone); timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return errors.ErrInvalidTimezone
+		}
+	}
+
+	start, end := strings.TrimSpace(r.QuietStart), strings.TrimSpace(r.QuietEnd)
+	if start == "" && end == "" {
+		return nil
+	}
+	if start == "" || end == "" {
+		return errors.ErrIncompleteQuietHours
+	}
+	if _, ok := domain.ParseClock(start); !ok {
+		return errors.ErrInvalidQuietHours
+	}
+	if _, ok := domain.ParseClock(end); !ok {
+		return errors.ErrInvalidQuietHours
+	}
+	if start == end {
+		return errors.ErrInvalidQuietHours
+	}
+
+	return nil
+}
+
+func (r *SubscribeRequest) validateSchedule() error {
+	switch r.Frequency {
+	case domain.FrequencyWeekly:
+		if _, ok := weekdays[strings.ToLower(strings.TrimSpace(r.Weekday))]; !ok {
+			return errors.ErrInvalidWeekday
+		}
+	case domain.FrequencyInterval:
+		if r.IntervalHours < 1 || r.IntervalHours > 23 {
+			return errors.ErrInvalidIntervalHours
+		}
+	case domain.FrequencyCustom:
+		interval, err := cronutil.MinInterval(strings.TrimSpace(r.CronExpression), time.Now())
+		if err != nil {
+			return errors.ErrInvalidCronExpression
+		}
+		if interval < minCustomInterval {
+			return errors.ErrCronTooFrequent
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,26 @@
+package request
+
+import (
+	"net/url"
+	"strings"
+	"weather-api/internal/adapter/handler/http/errors"
+)
+
+const minWebhookSecretLength = 16
+
+type WebhookRequest struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func (r *WebhookRequest) Validate() error {
+	r.URL = strings.TrimSpace(r.URL)
+	parsed, err := url.Parse(r.URL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return errors.ErrInvalidWebhookURL
+	}
+	if len(r.Secret) < minWebhookSecretLength {
+		return errors.ErrWebhookSecretTooShort
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package response
+
+import "time"
+
+type AdminSubscriptionResponse struct {
+	ID           int64      `json:"id"`
+	Email        string     `json:"email"`
+	City         string     `json:"city"`
+	Frequency    string     `json:"frequency"`
+	Timezone     string     `json:"timezone"`
+	Confirmed    bool       `json:"confirmed"`
+	Paused       bool       `json:"paused"`
+	SevereAlerts bool       `json:"severe_alerts"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+	LastSentAt   *time.Time `json:"last_sent_at,omitempty"`
+}
+
+type DeliveryResponse struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CityStatsResponse struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Subscribers   int    `json:"subscribers"`
+	Subscriptions int    `json:"subscriptions"`
+}
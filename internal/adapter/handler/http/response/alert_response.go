@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type AlertResponse struct {
+	Headline    string     `json:"headline"`
+	Event       string     `json:"event"`
+	Severity    string     `json:"severity"`
+	Urgency     string     `json:"urgency"`
+	Areas       string     `json:"areas"`
+	Description string     `json:"description"`
+	Instruction string     `json:"instruction,omitempty"`
+	Effective   *time.Time `json:"effective,omitempty"`
+	Expires     *time.Time `json:"expires,omitempty"`
+}
+
+type AlertsResponse struct {
+	City   string          `json:"city"`
+	Alerts []AlertResponse `json:"alerts"`
+}
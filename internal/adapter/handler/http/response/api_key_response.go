@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+type APIKeyResponse struct {
+	ID                int64      `json:"id"`
+	Prefix            string     `json:"prefix"`
+	Owner             string     `json:"owner"`
+	Plan              string     `json:"plan"`
+	RequestsPerMinute int        `json:"requests_per_minute"`
+	MonthlyQuota      int64      `json:"monthly_quota"`
+	MonthlyUsage      int64      `json:"monthly_usage"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IssuedAPIKeyResponse is the only response that contains the key itself.
+type IssuedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type APIKeyUsageResponse struct {
+	Month    string `json:"month"`
+	Requests int64  `json:"requests"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+type ComponentHealthResponse struct {
+	Status    string    `json:"status"`
+	Critical  bool      `json:"critical"`
+	LatencyMS int64     `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type HealthResponse struct {
+	Status     string                             `json:"status"`
+	Components map[string]ComponentHealthResponse `json:"components,omitempty"`
+}
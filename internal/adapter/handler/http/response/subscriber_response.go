@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type SubscriptionResponse struct {
+	City         string     `json:"city"`
+	Frequency    string     `json:"frequency"`
+	Confirmed    bool       `json:"confirmed"`
+	SevereAlerts bool       `json:"severe_alerts"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+}
+
+type SubscriberResponse struct {
+	Email         string                 `json:"email"`
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
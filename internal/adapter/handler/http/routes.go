@@ -0,0 +1,126 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"weather-api/internal/adapter/handler/http/middleware"
+	"weather-api/internal/adapter/handler/http/openapi"
+	"weather-api/internal/adapter/ratelimit"
+)
+
+const (
+	APIPrefix       = "/api/v1"
+	legacyAPIPrefix = "/api"
+)
+
+// APIHandlers are the handlers of the public API. Optional features are left
+// nil when they are disabled and their routes are not registered.
+type APIHandlers struct {
+	Weather      *WeatherHandler
+	Alert        *AlertHandler
+	Subscription *SubscriptionHandler
+	Subscriber   *SubscriberHandler
+	Webhook      *WebhookHandler
+	Push         *PushHandler
+	Bounce       *BounceHandler
+	BounceSecret string
+	// TelegramWebhook receives Bot API updates when the bot runs in webhook mode.
+	TelegramWebhook http.Handler
+	// APIKeys authenticates X-API-Key on the weather endpoint.
+	APIKeys    gin.HandlerFunc
+	RateLimits RateLimits
+}
+
+// RateLimits guard the public routes that reach weather providers or send
+// email. Nil entries leave their route unlimited.
+type RateLimits struct {
+	Weather   gin.HandlerFunc
+	Alerts    gin.HandlerFunc
+	Subscribe gin.HandlerFunc
+}
+
+type RateLimitOptions struct {
+	Weather        ratelimit.Limit
+	Alerts         ratelimit.Limit
+	SubscribeIP    ratelimit.Limit
+	SubscribeEmail ratelimit.Limit
+}
+
+// NewRateLimits limits weather lookups per client IP and subscriptions both per
+// client IP and per target email, so one client cannot flood an inbox from
+// many addresses. Weather lookups with an API key are limited per key by
+// APIKeys instead. The v1 and legacy routes share buckets.
+func NewRateLimits(limiter ratelimit.Limiter, opts RateLimitOptions, logger *slog.Logger) RateLimits {
+	var limits RateLimits
+	if opts.Weather.Enabled() {
+		limits.Weather = middleware.RateLimit(limiter, logger,
+			middleware.RateLimitRule{Name: "weather:ip", Limit: opts.Weather, Key: middleware.Anonymous(middleware.ClientIP)})
+	}
+	if opts.Alerts.Enabled() {
+		limits.Alerts = middleware.RateLimit(limiter, logger,
+			middleware.RateLimitRule{Name: "alerts:ip", Limit: opts.Alerts, Key: middleware.ClientIP})
+	}
+	if opts.SubscribeIP.Enabled() || opts.SubscribeEmail.Enabled() {
+		limits.Subscribe = middleware.RateLimit(limiter, logger,
+			middleware.RateLimitRule{Name: "subscribe:ip", Limit: opts.SubscribeIP, Key: middleware.ClientIP},
+			middleware.RateLimitRule{Name: "subscribe:email", Limit: opts.SubscribeEmail, Key: middleware.JSONField("email")})
+	}
+	return limits
+}
+
+// RegisterAPI serves the public API under /api/v1 together with its OpenAPI
+// document, and keeps the unversioned /api routes as deprecated aliases.
+func RegisterAPI(r gin.IRouter, h APIHandlers) {
+	v1 := r.Group(APIPrefix)
+	registerAPIRoutes(v1, h)
+	v1.GET("/openapi.json", openapi.ServeSpec)
+	v1.GET("/docs", openapi.ServeDocs)
+
+	legacy := r.Group(legacyAPIPrefix, middleware.Deprecated(legacyAPIPrefix, APIPrefix))
+	registerAPIRoutes(legacy, h)
+}
+
+func registerAPIRoutes(api gin.IRoutes, h APIHandlers) {
+	api.GET("/weather", chain(h.APIKeys, h.RateLimits.Weather, h.Weather.GetWeather)...)
+	api.GET("/alerts", chain(h.RateLimits.Alerts, h.Alert.GetAlerts)...)
+	api.POST("/subscribe", chain(h.RateLimits.Subscribe, h.Subscription.Subscribe)...)
+	api.GET("/confirm/:token", h.Subscription.Confirm)
+	api.GET("/unsubscribe/:token", h.Subscription.Unsubscribe)
+	api.GET("/subscriber/:token", h.Subscriber.GetSubscriber)
+	api.GET("/subscriber/:token/unsubscribe", h.Subscriber.UnsubscribeAll)
+	api.PUT("/subscriptions/:token/webhook", h.Webhook.Register)
+	api.DELETE("/subscriptions/:token/webhook", h.Webhook.Remove)
+
+	if h.Push != nil {
+		api.GET("/push/public-key", h.Push.PublicKey)
+		api.PUT("/subscriptions/:token/push", h.Push.Register)
+		api.DELETE("/subscriptions/:token/push", h.Push.Remove)
+	}
+	if h.Bounce != nil {
+		api.POST("/webhooks/bounces", middleware.WebhookSecret(h.BounceSecret), h.Bounce.HandleWebhook)
+	}
+	if h.TelegramWebhook != nil {
+		api.POST("/telegram/webhook", gin.WrapH(h.TelegramWebhook))
+	}
+}
+
+// chain drops the middleware of disabled features.
+func chain(handlers ...gin.HandlerFunc) []gin.HandlerFunc {
+	enabled := make([]gin.HandlerFunc, 0, len(handlers))
+	for _, handler := range handlers {
+		if handler != nil {
+			enabled = append(enabled, handler)
+		}
+	}
+	return enabled
+}
+
+// writeError hands err to the Problems middleware, which renders it as
+// problem details.
+func writeError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,152 @@
+//go:build unit
+// +build unit
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/adapter/handler/http/openapi"
+	"weather-api/internal/adapter/handler/http/request"
+	"weather-api/internal/adapter/handler/http/response"
+	"weather-api/internal/util/logger"
+)
+
+type openAPIDocument struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func newContractRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterAPI(r, APIHandlers{
+		Weather:         &WeatherHandler{},
+		Alert:           &AlertHandler{},
+		Subscription:    &SubscriptionHandler{},
+		Subscriber:      &SubscriberHandler{},
+		Webhook:         &WebhookHandler{},
+		Push:            NewPushHandler(nil, "public-key", logger.Discard()),
+		Bounce:          &BounceHandler{},
+		BounceSecret:    "secret",
+		TelegramWebhook: http.NotFoundHandler(),
+	})
+	return r
+}
+
+func loadSpec(t *testing.T) openAPIDocument {
+	var doc openAPIDocument
+	require.NoError(t, json.Unmarshal(openapi.Spec(), &doc))
+	require.Len(t, doc.Servers, 1)
+	require.Equal(t, APIPrefix, doc.Servers[0].URL)
+	return doc
+}
+
+func TestRouterMatchesOpenAPISpec(t *testing.T) {
+	doc := loadSpec(t)
+
+	var specRoutes []string
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			specRoutes = append(specRoutes, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	var routerRoutes, legacyRoutes []string
+	for _, route := range newContractRouter().Routes() {
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		if rest, ok := strings.CutPrefix(path, APIPrefix); ok {
+			routerRoutes = append(routerRoutes, route.Method+" "+rest)
+		} else if rest, ok := strings.CutPrefix(path, legacyAPIPrefix); ok {
+			legacyRoutes = append(legacyRoutes, route.Method+" "+rest)
+		}
+	}
+
+	sort.Strings(specRoutes)
+	sort.Strings(routerRoutes)
+	assert.Equal(t, specRoutes, routerRoutes)
+
+	for _, route := range routerRoutes {
+		if route == "GET /openapi.json" || route == "GET /docs" {
+			continue
+		}
+		assert.Contains(t, legacyRoutes, route, "missing deprecated alias")
+	}
+}
+
+func TestOpenAPISchemasMatchTypes(t *testing.T) {
+	doc := loadSpec(t)
+
+	types := map[string]any{
+		"SubscribeRequest":        request.SubscribeRequest{},
+		"WebhookRequest":          request.WebhookRequest{},
+		"PushSubscriptionRequest": request.PushSubscriptionRequest{},
+		"BounceWebhookRequest":    request.BounceWebhookRequest{},
+		"BounceEventRequest":      request.BounceEventRequest{},
+		"WeatherResponse":         response.WeatherResponse{},
+		"AlertsResponse":          response.AlertsResponse{},
+		"AlertResponse":           response.AlertResponse{},
+		"SubscriberResponse":      response.SubscriberResponse{},
+		"SubscriptionResponse":    response.SubscriptionResponse{},
+		"Problem":                 response.Problem{},
+		"FieldError":              response.FieldError{},
+	}
+	for name, value := range types {
+		schema, ok := doc.Components.Schemas[name]
+		require.True(t, ok, "schema %s is missing", name)
+
+		var properties []string
+		for property := range schema.Properties {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		assert.Equal(t, jsonFields(reflect.TypeOf(value)), properties, name)
+	}
+}
+
+func TestDeprecatedAliasLinksSuccessor(t *testing.T) {
+	r := newContractRouter()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/push/public-key", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v1/push/public-key>; rel="successor-version"`, rec.Header().Get("Link"))
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/push/public-key", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+}
+
+func jsonFields(typ reflect.Type) []string {
+	var fields []string
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
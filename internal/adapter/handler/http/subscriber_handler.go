@@ -0,0 +1,71 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+
+	"weather-api/internal/adapter/handler/http/request"
+	"weather-api/internal/adapter/handler/http/response"
+)
+
+type SubscriberHandler struct {
+	manageUseCase in.ManageSubscriberUseCase
+	logger        *slog.Logger
+}
+
+func NewSubscriberHandler(manageUseCase in.ManageSubscriberUseCase, logger *slog.Logger) *SubscriberHandler {
+	return &SubscriberHandler{manageUseCase: manageUseCase, logger: logger}
+}
+
+func (h *SubscriberHandler) GetSubscriber(c *gin.Context) {
+	token := c.Param("token")
+	tokenReq := request.NewTokenRequest(token)
+
+	if err := tokenReq.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	subscriber, err := h.manageUseCase.GetSubscriber(c, token)
+	if err != nil {
+		h.logger.WarnContext(c, "unable to get subscriber", "error", err)
+		writeError(c, err)
+		return
+	}
+
+	resp := response.SubscriberResponse{
+		Email:         subscriber.Email,
+		Subscriptions: make([]response.SubscriptionResponse, 0, len(subscriber.Subscriptions)),
+	}
+	for _, sub := range subscriber.Subscriptions {
+		resp.Subscriptions = append(resp.Subscriptions, response.SubscriptionResponse{
+			City:         sub.City.Name,
+			Frequency:    string(sub.Frequency),
+			Confirmed:    sub.IsConfirmed,
+			SevereAlerts: sub.SevereAlerts,
+			NextRunAt:    optionalTime(sub.NextRunAt),
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriberHandler) UnsubscribeAll(c *gin.Context) {
+	token := c.Param("token")
+	tokenReq := request.NewTokenRequest(token)
+
+	if err := tokenReq.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if err := h.manageUseCase.UnsubscribeAll(c, token); err != nil {
+		h.logger.WarnContext(c, "unable to unsubscribe subscriber", "error", err)
+		writeError(c, err)
+		return
+	}
+	h.logger.DebugContext(c, "removed all subscriptions of subscriber")
+	c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed from all cities"})
+}
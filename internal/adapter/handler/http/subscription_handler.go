@@ -1,9 +1,9 @@
 package http
 
 import (
-	"errors"
-	"log"
+	"log/slog"
 	"net/http"
+	"strings"
 	"weather-api/internal/core/ports/in"
 	"weather-api/internal/core/ports/out"
 
@@ -11,24 +11,26 @@ import (
 
 	httperrors "weather-api/internal/adapter/handler/http/errors"
 	"weather-api/internal/adapter/handler/http/request"
-	"weather-api/internal/core/domain"
 )
 
 type SubscriptionHandler struct {
 	subscribeUseCase   in.SubscribeUseCase
 	confirmUseCase     in.ConfirmSubscriptionUseCase
 	unsubscribeUseCase in.UnsubscribeUseCase
+	logger             *slog.Logger
 }
 
 func NewSubscriptionHandler(
 	subscribeUseCase in.SubscribeUseCase,
 	confirmUseCase in.ConfirmSubscriptionUseCase,
 	unsubscribeUseCase in.UnsubscribeUseCase,
+	logger *slog.Logger,
 ) *SubscriptionHandler {
 	return &SubscriptionHandler{
 		subscribeUseCase:   subscribeUseCase,
 		confirmUseCase:     confirmUseCase,
 		unsubscribeUseCase: unsubscribeUseCase,
+		logger:             logger,
 	}
 }
 
@@ -36,37 +38,34 @@ func (h *SubscriptionHandler) Subscribe(c *gin.Context) {
 	var req request.SubscribeRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("Invalid subscription request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": httperrors.ErrInvalidInput.Error()})
+		h.logger.DebugContext(c, "invalid subscription request", "error", err)
+		writeError(c, httperrors.ErrInvalidInput)
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		log.Printf("Validation error: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		h.logger.DebugContext(c, "subscription request failed validation", "error", err)
+		writeError(c, err)
 		return
 	}
 
-	log.Printf("Received subscription request for city: %s, frequency: %s", req.City, req.Frequency)
+	h.logger.DebugContext(c, "received subscription request", "city", req.City, "frequency", req.Frequency)
 
 	_, err := h.subscribeUseCase.Subscribe(c, out.SubscribeOptions{
-		Email:     req.Email,
-		City:      req.City,
-		Frequency: req.Frequency,
+		Email:        req.Email,
+		City:         req.City,
+		Frequency:    req.Frequency,
+		Schedule:     req.Schedule(),
+		Timezone:     strings.TrimSpace(req.Timezone),
+		QuietHours:   req.QuietHours(),
+		SevereAlerts: req.SevereAlerts,
 	})
 	if err != nil {
-		log.Printf("Unable to process subscription: %v", err)
-		switch {
-		case errors.Is(err, domain.ErrEmailAlreadySubscribed):
-			c.JSON(http.StatusConflict, gin.H{"error": httperrors.ErrEmailAlreadySubscribed.Error()})
-		case errors.Is(err, domain.ErrCityNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": httperrors.ErrCityNotFound.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		h.logger.WarnContext(c, "unable to process subscription", "error", err)
+		writeError(c, err)
 		return
 	}
-	log.Printf("Successfully processed subscription request")
+	h.logger.DebugContext(c, "processed subscription request")
 	c.JSON(http.StatusOK, gin.H{"message": "Subscription successful. Confirmation email sent."})
 }
 
@@ -75,25 +74,18 @@ func (h *SubscriptionHandler) Confirm(c *gin.Context) {
 	tokenReq := request.NewTokenRequest(token)
 
 	if err := tokenReq.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	log.Printf("Received confirmation request")
+	h.logger.DebugContext(c, "received confirmation request")
 
 	if err := h.confirmUseCase.ConfirmSubscription(c, token); err != nil {
-		log.Printf("Unable to confirm subscription: %v", err)
-		switch {
-		case errors.Is(err, domain.ErrInvalidToken):
-			c.JSON(http.StatusBadRequest, gin.H{"error": httperrors.ErrInvalidToken.Error()})
-		case errors.Is(err, domain.ErrTokenNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": httperrors.ErrTokenNotFound.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		h.logger.WarnContext(c, "unable to confirm subscription", "error", err)
+		writeError(c, err)
 		return
 	}
-	log.Printf("Successfully confirmed subscription")
+	h.logger.DebugContext(c, "confirmed subscription")
 	c.JSON(http.StatusOK, gin.H{"message": "Subscription confirmed"})
 }
 
@@ -102,24 +94,17 @@ func (h *SubscriptionHandler) Unsubscribe(c *gin.Context) {
 	tokenReq := request.NewTokenRequest(token)
 
 	if err := tokenReq.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	log.Printf("Received unsubscribe request")
+	h.logger.DebugContext(c, "received unsubscribe request")
 
 	if err := h.unsubscribeUseCase.Unsubscribe(c, token); err != nil {
-		log.Printf("Unable to unsubscribe: %v", err)
-		switch {
-		case errors.Is(err, domain.ErrInvalidToken):
-			c.JSON(http.StatusBadRequest, gin.H{"error": httperrors.ErrInvalidToken.Error()})
-		case errors.Is(err, domain.ErrTokenNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": httperrors.ErrTokenNotFound.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		h.logger.WarnContext(c, "unable to unsubscribe", "error", err)
+		writeError(c, err)
 		return
 	}
-	log.Printf("Successfully processed unsubscribe request")
+	h.logger.DebugContext(c, "processed unsubscribe request")
 	c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed"})
 }
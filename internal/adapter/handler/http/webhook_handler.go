@@ -0,0 +1,61 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"weather-api/internal/core/ports/in"
+
+	"github.com/gin-gonic/gin"
+
+	httperrors "weather-api/internal/adapter/handler/http/errors"
+	"weather-api/internal/adapter/handler/http/request"
+)
+
+type WebhookHandler struct {
+	webhookUseCase in.WebhookUseCase
+	logger         *slog.Logger
+}
+
+func NewWebhookHandler(webhookUseCase in.WebhookUseCase, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{webhookUseCase: webhookUseCase, logger: logger}
+}
+
+func (h *WebhookHandler) Register(c *gin.Context) {
+	token := c.Param("token")
+	if err := request.NewTokenRequest(token).Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	var req request.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, httperrors.ErrInvalidInput)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if err := h.webhookUseCase.RegisterWebhook(c, token, req.URL, req.Secret); err != nil {
+		h.logger.WarnContext(c, "unable to register webhook", "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook registered"})
+}
+
+func (h *WebhookHandler) Remove(c *gin.Context) {
+	token := c.Param("token")
+	if err := request.NewTokenRequest(token).Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if err := h.webhookUseCase.RemoveWebhook(c, token); err != nil {
+		h.logger.WarnContext(c, "unable to remove webhook", "error", err)
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook removed"})
+}
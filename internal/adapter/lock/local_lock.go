@@ -0,0 +1,61 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+// LocalLocker coordinates within a single process. It keeps the single-instance
+// behaviour when no shared backend is configured. Tokens tell acquisitions of
+// the same lock apart so a stale lease cannot release a newer one.
+type LocalLocker struct {
+	mu     sync.Mutex
+	held   map[string]bool
+	tokens map[string]int64
+}
+
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{
+		held:   make(map[string]bool),
+		tokens: make(map[string]int64),
+	}
+}
+
+func (l *LocalLocker) TryAcquire(_ context.Context, name string, _ time.Duration) (out.Lease, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[name] {
+		return nil, domain.ErrLockNotAcquired
+	}
+	l.held[name] = true
+	l.tokens[name]++
+	return &localLease{locker: l, name: name, token: l.tokens[name]}, nil
+}
+
+type localLease struct {
+	locker *LocalLocker
+	name   string
+	token  int64
+}
+
+func (l *localLease) Renew(context.Context) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	if !l.locker.held[l.name] || l.locker.tokens[l.name] != l.token {
+		return domain.ErrLockLost
+	}
+	return nil
+}
+
+func (l *localLease) Release(context.Context) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	if l.locker.tokens[l.name] == l.token {
+		delete(l.locker.held, l.name)
+	}
+	return nil
+}
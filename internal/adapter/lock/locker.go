@@ -0,0 +1,27 @@
+package lock
+
+import (
+	"database/sql"
+	"fmt"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	BackendLocal    = "local"
+	BackendPostgres = "postgres"
+	BackendRedis    = "redis"
+)
+
+func NewLocker(backend string, db *sql.DB, client *redis.Client, instanceID string) (out.Locker, error) {
+	switch backend {
+	case BackendLocal:
+		return NewLocalLocker(), nil
+	case BackendPostgres:
+		return NewPostgresLocker(db), nil
+	case BackendRedis:
+		return NewRedisLocker(client, instanceID), nil
+	}
+	return nil, fmt.Errorf("unsupported leader election backend %q", backend)
+}
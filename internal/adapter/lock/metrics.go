@@ -0,0 +1,39 @@
+package lock
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type LeaderMetrics struct {
+	instanceID string
+	leader     *prometheus.GaugeVec
+	lost       *prometheus.CounterVec
+}
+
+func NewLeaderMetrics(reg prometheus.Registerer, instanceID string) *LeaderMetrics {
+	m := &LeaderMetrics{
+		instanceID: instanceID,
+		leader: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "scheduler_leader",
+			Help: "Whether this instance currently holds the scheduler lock (1) or not (0)",
+		}, []string{"lock", "instance"}),
+		lost: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scheduler_leadership_lost_total",
+			Help: "Number of times this instance lost the scheduler lock while holding it",
+		}, []string{"lock", "instance"}),
+	}
+	reg.MustRegister(m.leader, m.lost)
+	return m
+}
+
+func (m *LeaderMetrics) SetLeader(lock string, leader bool) {
+	value := 0.0
+	if leader {
+		value = 1
+	}
+	m.leader.WithLabelValues(lock, m.instanceID).Set(value)
+}
+
+func (m *LeaderMetrics) LeadershipLost(lock string) {
+	m.lost.WithLabelValues(lock, m.instanceID).Inc()
+}
@@ -0,0 +1,84 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+// PostgresLocker uses session-level advisory locks. The lock lives as long as
+// the dedicated connection, so the ttl is not used and renewal is a liveness check.
+type PostgresLocker struct {
+	db *sql.DB
+}
+
+func NewPostgresLocker(db *sql.DB) *PostgresLocker {
+	return &PostgresLocker{db: db}
+}
+
+func (l *PostgresLocker) TryAcquire(ctx context.Context, name string, _ time.Duration) (out.Lease, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open connection for advisory lock %s: %w", name, err)
+	}
+
+	key := advisoryKey(name)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("acquire advisory lock %s: %w", name, err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, domain.ErrLockNotAcquired
+	}
+
+	return &postgresLease{conn: conn, name: name, key: key}, nil
+}
+
+type postgresLease struct {
+	conn *sql.Conn
+	name string
+	key  int64
+}
+
+func (l *postgresLease) Renew(ctx context.Context) error {
+	var held bool
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM pg_locks
+            WHERE locktype = 'advisory' AND objsubid = 1 AND pid = pg_backend_pid() AND granted
+              AND ((classid::bigint << 32) | objid::bigint) = $1
+        )
+    `
+	if err := l.conn.QueryRowContext(ctx, query, l.key).Scan(&held); err != nil {
+		return fmt.Errorf("%w: advisory lock %s: %v", domain.ErrLockLost, l.name, err)
+	}
+	if !held {
+		return domain.ErrLockLost
+	}
+	return nil
+}
+
+// Release unlocks and closes the dedicated connection. If the unlock fails the
+// connection is discarded instead of returned to the pool, because the
+// session would otherwise keep holding the lock.
+func (l *postgresLease) Release(ctx context.Context) error {
+	if _, err := l.conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, l.key); err != nil {
+		_ = l.conn.Raw(func(any) error { return driver.ErrBadConn })
+		_ = l.conn.Close()
+		return fmt.Errorf("release advisory lock %s: %w", l.name, err)
+	}
+	return l.conn.Close()
+}
+
+func advisoryKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
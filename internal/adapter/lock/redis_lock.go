@@ -0,0 +1,93 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "lock:"
+
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type RedisLocker struct {
+	client     *redis.Client
+	instanceID string
+}
+
+func NewRedisLocker(client *redis.Client, instanceID string) *RedisLocker {
+	return &RedisLocker{
+		client:     client,
+		instanceID: instanceID,
+	}
+}
+
+// TryAcquire stores a value unique to this acquisition, so only this lease can
+// renew or release the lock, not a restarted instance with the same ID.
+func (l *RedisLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (out.Lease, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("generate redis lock value for %s: %w", name, err)
+	}
+	value := l.instanceID + ":" + hex.EncodeToString(b)
+
+	key := redisKeyPrefix + name
+	acquired, err := l.client.SetNX(ctx, key, value, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("acquire redis lock %s: %w", name, err)
+	}
+	if !acquired {
+		return nil, domain.ErrLockNotAcquired
+	}
+
+	return &redisLease{
+		client: l.client,
+		key:    key,
+		value:  value,
+		ttl:    ttl,
+	}, nil
+}
+
+type redisLease struct {
+	client *redis.Client
+	key    string
+	value  string
+	ttl    time.Duration
+}
+
+func (l *redisLease) Renew(ctx context.Context) error {
+	renewed, err := renewScript.Run(ctx, l.client, []string{l.key}, l.value, l.ttl.Milliseconds()).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("renew redis lock %s: %w", l.key, err)
+	}
+	if renewed == 0 {
+		return domain.ErrLockLost
+	}
+	return nil
+}
+
+func (l *redisLease) Release(ctx context.Context) error {
+	if err := releaseScript.Run(ctx, l.client, []string{l.key}, l.value).Err(); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("release redis lock %s: %w", l.key, err)
+	}
+	return nil
+}
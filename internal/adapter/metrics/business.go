@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"time"
+	"weather-api/internal/core/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type BusinessMetrics struct {
+	created      *prometheus.CounterVec
+	confirmed    *prometheus.CounterVec
+	removed      prometheus.Counter
+	updatesSent  *prometheus.CounterVec
+	schedulerRun *prometheus.HistogramVec
+}
+
+func NewBusinessMetrics(reg prometheus.Registerer) *BusinessMetrics {
+	m := &BusinessMetrics{
+		created: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "subscriptions_created_total",
+			Help: "Subscriptions created, confirmed or not",
+		}, []string{"frequency"}),
+		confirmed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "subscriptions_confirmed_total",
+			Help: "Subscriptions confirmed by link, Telegram or an admin",
+		}, []string{"frequency"}),
+		removed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "subscriptions_unsubscribed_total",
+			Help: "Subscriptions removed by unsubscribing or by an admin",
+		}),
+		updatesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_updates_sent_total",
+			Help: "Weather updates delivered per subscription frequency and channel",
+		}, []string{"frequency", "channel"}),
+		schedulerRun: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "scheduler_run_duration_seconds",
+			Help:    "Duration of scheduled update runs",
+			Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300},
+		}, []string{"job", "outcome"}),
+	}
+	reg.MustRegister(m.created, m.confirmed, m.removed, m.updatesSent, m.schedulerRun)
+	return m
+}
+
+func (m *BusinessMetrics) SubscriptionCreated(frequency domain.Frequency) {
+	m.created.WithLabelValues(string(frequency)).Inc()
+}
+
+func (m *BusinessMetrics) SubscriptionConfirmed(frequency domain.Frequency) {
+	m.confirmed.WithLabelValues(string(frequency)).Inc()
+}
+
+func (m *BusinessMetrics) SubscriptionsRemoved(count int) {
+	m.removed.Add(float64(count))
+}
+
+func (m *BusinessMetrics) UpdateSent(frequency domain.Frequency, channel domain.Channel) {
+	m.updatesSent.WithLabelValues(string(frequency), string(channel)).Inc()
+}
+
+func (m *BusinessMetrics) SchedulerRun(job string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.schedulerRun.WithLabelValues(job, outcome).Observe(duration.Seconds())
+}
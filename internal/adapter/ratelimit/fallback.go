@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// FallbackLimiter uses primary and switches to fallback for requests where
+// primary fails, e.g. while Redis is unreachable. Limits then only hold per
+// replica until primary recovers.
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	degraded atomic.Bool
+	logger   *slog.Logger
+}
+
+func NewFallbackLimiter(primary, fallback Limiter, logger *slog.Logger) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, fallback: fallback, logger: logger}
+}
+
+func (f *FallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := f.primary.Allow(ctx, key, limit)
+	if err == nil {
+		if f.degraded.CompareAndSwap(true, false) {
+			f.logger.InfoContext(ctx, "rate limiter recovered")
+		}
+		return res, nil
+	}
+
+	if f.degraded.CompareAndSwap(false, true) {
+		f.logger.WarnContext(ctx, "rate limiter unavailable, using in-memory fallback", "error", err)
+	}
+	return f.fallback.Allow(ctx, key, limit)
+}
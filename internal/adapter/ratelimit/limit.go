@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket that holds Requests tokens and refills completely
+// over Per, so bursts of up to Requests are allowed and the sustained rate is
+// Requests per Per.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseLimit parses "10/1m" style limits. An empty string or "0" disables
+// the limit.
+func ParseLimit(spec string) (Limit, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "0" {
+		return Limit{}, nil
+	}
+	requests, per, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("limit %q is not in requests/duration form, e.g. 10/1m", spec)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("limit %q must allow a positive number of requests", spec)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(per))
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("limit %q must have a positive duration", spec)
+	}
+	return Limit{Requests: n, Per: d}, nil
+}
+
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Per)
+}
+
+// tokensPerMilli is the refill rate of the bucket.
+func (l Limit) tokensPerMilli() float64 {
+	return float64(l.Requests) / float64(l.Per.Milliseconds())
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until the next request would be allowed; zero
+	// when this one was.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+type Limiter interface {
+	// Allow takes one token from the bucket of key.
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// result describes a bucket left with tokens after a request.
+func result(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.tokensPerMilli()
+	r := Result{
+		Allowed:   allowed,
+		Limit:     limit.Requests,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     millis((float64(limit.Requests) - tokens) / rate),
+	}
+	if !allowed {
+		r.RetryAfter = millis((1 - tokens) / rate)
+	}
+	return r
+}
+
+func millis(ms float64) time.Duration {
+	return time.Duration(math.Ceil(ms)) * time.Millisecond
+}
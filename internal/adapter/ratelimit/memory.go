@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxMemoryBuckets bounds the memory limiter. Once reached, buckets that have
+// refilled completely are dropped; they are indistinguishable from new ones.
+const maxMemoryBuckets = 100_000
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := float64(now.Sub(b.last).Milliseconds())
+	if elapsed > 0 {
+		b.tokens = min(float64(b.limit.Requests), b.tokens+elapsed*b.limit.tokensPerMilli())
+		b.last = now
+	}
+}
+
+// MemoryLimiter keeps token buckets in process memory. Limits only hold per
+// replica, so it is meant for single instances and as a fallback.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (m *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	b, ok := m.buckets[key]
+	if !ok {
+		if len(m.buckets) >= maxMemoryBuckets {
+			m.dropFull(now)
+		}
+		b = &bucket{tokens: float64(limit.Requests), last: now}
+		m.buckets[key] = b
+	}
+	b.limit = limit
+	b.refill(now)
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return result(limit, allowed, b.tokens), nil
+}
+
+func (m *MemoryLimiter) dropFull(now time.Time) {
+	for key, b := range m.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Requests) {
+			delete(m.buckets, key)
+		}
+	}
+}
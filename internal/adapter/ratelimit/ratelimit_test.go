@@ -0,0 +1,116 @@
+//go:build unit
+// +build unit
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather-api/internal/util/logger"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("10/1m")
+	require.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 10, Per: time.Minute}, limit)
+
+	for _, disabled := range []string{"", "0"} {
+		limit, err := ParseLimit(disabled)
+		require.NoError(t, err)
+		assert.False(t, limit.Enabled())
+	}
+
+	for _, invalid := range []string{"10", "0/1m", "-1/1m", "10/0s", "ten/1m", "10/minute"} {
+		_, err := ParseLimit(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func testLimiters(t *testing.T, now func() time.Time) map[string]Limiter {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	memory := NewMemoryLimiter()
+	memory.now = now
+	redisLimiter := NewRedisLimiter(client)
+	redisLimiter.now = now
+	return map[string]Limiter{"memory": memory, "redis": redisLimiter}
+}
+
+func TestLimiter_TokenBucket(t *testing.T) {
+	c := &clock{now: time.Unix(1_700_000_000, 0)}
+	limit := Limit{Requests: 2, Per: time.Minute}
+
+	for name, limiter := range testLimiters(t, c.Now) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			c.now = time.Unix(1_700_000_000, 0)
+
+			first, err := limiter.Allow(ctx, "client", limit)
+			require.NoError(t, err)
+			assert.True(t, first.Allowed)
+			assert.Equal(t, 2, first.Limit)
+			assert.Equal(t, 1, first.Remaining)
+			assert.Equal(t, 30*time.Second, first.Reset)
+
+			second, err := limiter.Allow(ctx, "client", limit)
+			require.NoError(t, err)
+			assert.True(t, second.Allowed)
+			assert.Equal(t, 0, second.Remaining)
+
+			denied, err := limiter.Allow(ctx, "client", limit)
+			require.NoError(t, err)
+			assert.False(t, denied.Allowed)
+			assert.Equal(t, 30*time.Second, denied.RetryAfter)
+
+			other, err := limiter.Allow(ctx, "other", limit)
+			require.NoError(t, err)
+			assert.True(t, other.Allowed, "buckets are per key")
+
+			c.now = c.now.Add(30 * time.Second)
+			refilled, err := limiter.Allow(ctx, "client", limit)
+			require.NoError(t, err)
+			assert.True(t, refilled.Allowed)
+			assert.Equal(t, 0, refilled.Remaining)
+		})
+	}
+}
+
+type failingLimiter struct{ err error }
+
+func (f *failingLimiter) Allow(context.Context, string, Limit) (Result, error) {
+	return Result{}, f.err
+}
+
+func TestFallbackLimiter_UsesFallbackWhilePrimaryFails(t *testing.T) {
+	primary := &failingLimiter{err: errors.New("connection refused")}
+	limiter := NewFallbackLimiter(primary, NewMemoryLimiter(), logger.Discard())
+	limit := Limit{Requests: 1, Per: time.Hour}
+
+	res, err := limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed, "the fallback still limits")
+	assert.True(t, limiter.degraded.Load())
+
+	primary.err = nil
+	_, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.False(t, limiter.degraded.Load())
+}
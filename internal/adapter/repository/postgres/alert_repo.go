@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"weather-api/internal/core/domain"
+)
+
+type AlertRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewAlertRepository(db *sql.DB, logger *slog.Logger) *AlertRepository {
+	return &AlertRepository{db: db, logger: logger}
+}
+
+func (r *AlertRepository) Save(ctx context.Context, alert domain.Alert) (int64, bool, error) {
+	// The no-op update makes RETURNING yield the existing row on conflict;
+	// xmax is only zero for rows inserted by this statement.
+	query := `
+        INSERT INTO alerts (city_id, fingerprint, headline, event, severity, urgency,
+                            areas, description, instruction, effective, expires)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (city_id, fingerprint) DO UPDATE SET fingerprint = EXCLUDED.fingerprint
+        RETURNING id, xmax = 0
+    `
+	var id int64
+	var inserted bool
+	err := r.db.QueryRowContext(ctx, query,
+		alert.CityID,
+		alert.Fingerprint(),
+		alert.Headline,
+		alert.Event,
+		alert.Severity,
+		alert.Urgency,
+		alert.Areas,
+		alert.Description,
+		alert.Instruction,
+		nullTime(alert.Effective),
+		nullTime(alert.Expires),
+	).Scan(&id, &inserted)
+	if err != nil {
+		msg := fmt.Sprintf("unable to save alert: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return 0, false, errors.New(msg)
+	}
+
+	r.logger.DebugContext(ctx, "stored alert", "alert_id", id, "new", inserted)
+	return id, inserted, nil
+}
+
+func (r *AlertRepository) GetDeliveredRecipients(ctx context.Context, alertID int64) (recipients map[string]bool, err error) {
+	query := `SELECT recipient FROM alert_deliveries WHERE alert_id = $1`
+	rows, err := r.db.QueryContext(ctx, query, alertID)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get recipients of alert %d: %v", alertID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	recipients = make(map[string]bool)
+	for rows.Next() {
+		var recipient string
+		if err := rows.Scan(&recipient); err != nil {
+			return nil, err
+		}
+		recipients[recipient] = true
+	}
+	return recipients, rows.Err()
+}
+
+func (r *AlertRepository) MarkDelivered(ctx context.Context, alertID int64, recipient string) error {
+	query := `
+        INSERT INTO alert_deliveries (alert_id, recipient)
+        VALUES ($1, $2)
+        ON CONFLICT (alert_id, recipient) DO NOTHING
+    `
+	if _, err := r.db.ExecContext(ctx, query, alertID, recipient); err != nil {
+		msg := fmt.Sprintf("unable to mark alert %d delivered: %v", alertID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
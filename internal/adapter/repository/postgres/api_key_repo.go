@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"weather-api/internal/core/domain"
+)
+
+const apiKeyColumns = `k.id, k.prefix, k.key_hash, k.owner, k.plan, k.requests_per_minute, k.monthly_quota,
+               COALESCE(u.requests, 0), k.created_at, k.last_used_at, k.revoked_at`
+
+type APIKeyRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewAPIKeyRepository(db *sql.DB, logger *slog.Logger) *APIKeyRepository {
+	return &APIKeyRepository{db: db, logger: logger}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	query := `
+        INSERT INTO api_keys (key_hash, prefix, owner, plan, requests_per_minute, monthly_quota)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at
+    `
+	err := r.db.QueryRowContext(ctx, query, key.Hash, key.Prefix, key.Owner, key.Plan, key.RequestsPerMinute, key.MonthlyQuota).
+		Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		msg := fmt.Sprintf("unable to create api key for %s: %v", key.Owner, err)
+		r.logger.ErrorContext(ctx, msg)
+		return domain.APIKey{}, errors.New(msg)
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByID(ctx context.Context, id int64, month time.Time) (domain.APIKey, error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys k
+        LEFT JOIN api_key_usage u ON u.api_key_id = k.id AND u.month = $2
+        WHERE k.id = $1
+    `
+	keys, err := r.queryAPIKeys(ctx, query, id, month)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get api key %d: %v", id, err)
+		r.logger.ErrorContext(ctx, msg)
+		return domain.APIKey{}, errors.New(msg)
+	}
+	if len(keys) == 0 {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	return keys[0], nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string, month time.Time) (domain.APIKey, error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys k
+        LEFT JOIN api_key_usage u ON u.api_key_id = k.id AND u.month = $2
+        WHERE k.key_hash = $1
+    `
+	keys, err := r.queryAPIKeys(ctx, query, hash, month)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get api key by hash: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return domain.APIKey{}, errors.New(msg)
+	}
+	if len(keys) == 0 {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	return keys[0], nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, month time.Time) ([]domain.APIKey, error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys k
+        LEFT JOIN api_key_usage u ON u.api_key_id = k.id AND u.month = $1
+        ORDER BY k.id
+    `
+	keys, err := r.queryAPIKeys(ctx, query, month)
+	if err != nil {
+		msg := fmt.Sprintf("unable to list api keys: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey keeps the key and its usage for auditing; revoking twice keeps
+// the first revocation time.
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1`, id)
+	if err != nil {
+		msg := fmt.Sprintf("unable to revoke api key %d: %v", id, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	if revoked, err := result.RowsAffected(); err == nil && revoked == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) IncrementAPIKeyUsage(ctx context.Context, id int64, month time.Time) (int64, error) {
+	query := `
+        WITH used AS (
+            UPDATE api_keys SET last_used_at = NOW() WHERE id = $1
+        )
+        INSERT INTO api_key_usage (api_key_id, month, requests)
+        VALUES ($1, $2, 1)
+        ON CONFLICT (api_key_id, month) DO UPDATE
+        SET requests = api_key_usage.requests + 1, updated_at = NOW()
+        RETURNING requests
+    `
+	var requests int64
+	if err := r.db.QueryRowContext(ctx, query, id, month).Scan(&requests); err != nil {
+		msg := fmt.Sprintf("unable to increment usage of api key %d for %s: %v", id, month.Format("2006-01"), err)
+		r.logger.ErrorContext(ctx, msg)
+		return 0, errors.New(msg)
+	}
+	return requests, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyUsage(ctx context.Context, id int64) ([]domain.APIKeyUsage, error) {
+	query := `SELECT month, requests FROM api_key_usage WHERE api_key_id = $1 ORDER BY month DESC`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get usage of api key %d: %v", id, err)
+		r.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	defer rows.Close()
+
+	var usage []domain.APIKeyUsage
+	for rows.Next() {
+		var month domain.APIKeyUsage
+		if err := rows.Scan(&month.Month, &month.Requests); err != nil {
+			msg := fmt.Sprintf("unable to scan usage of api key %d: %v", id, err)
+			r.logger.ErrorContext(ctx, msg)
+			return nil, errors.New(msg)
+		}
+		usage = append(usage, month)
+	}
+	if err := rows.Err(); err != nil {
+		msg := fmt.Sprintf("unable to read usage of api key %d: %v", id, err)
+		r.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	return usage, nil
+}
+
+func (r *APIKeyRepository) queryAPIKeys(ctx context.Context, query string, args ...any) ([]domain.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		var key domain.APIKey
+		var lastUsedAt, revokedAt sql.NullTime
+		if err := rows.Scan(
+			&key.ID,
+			&key.Prefix,
+			&key.Hash,
+			&key.Owner,
+			&key.Plan,
+			&key.RequestsPerMinute,
+			&key.MonthlyQuota,
+			&key.MonthlyUsage,
+			&key.CreatedAt,
+			&lastUsedAt,
+			&revokedAt,
+		); err != nil {
+			return nil, err
+		}
+		if lastUsedAt.Valid {
+			key.LastUsedAt = &lastUsedAt.Time
+		}
+		if revokedAt.Valid {
+			key.RevokedAt = &revokedAt.Time
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
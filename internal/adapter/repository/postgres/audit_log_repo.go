@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+)
+
+type AuditLogRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewAuditLogRepository(db *sql.DB, logger *slog.Logger) *AuditLogRepository {
+	return &AuditLogRepository{db: db, logger: logger}
+}
+
+func (r *AuditLogRepository) Record(ctx context.Context, entry domain.AuditEntry) error {
+	query := `
+        INSERT INTO admin_audit_log (actor, action, target, details)
+        VALUES ($1, $2, $3, $4)
+    `
+	if _, err := r.db.ExecContext(ctx, query, entry.Actor, entry.Action, entry.Target, entry.Details); err != nil {
+		msg := fmt.Sprintf("unable to record admin action %s: %v", entry.Action, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
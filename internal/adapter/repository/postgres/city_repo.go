@@ -5,49 +5,81 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/domain"
 )
 
 type CityRepo struct {
-	db *sql.DB
+	db     *sql.DB
+	logger *slog.Logger
 }
 
-func NewCityRepository(db *sql.DB) *CityRepo {
-	return &CityRepo{db: db}
+func NewCityRepository(db *sql.DB, logger *slog.Logger) *CityRepo {
+	return &CityRepo{db: db, logger: logger}
 }
 
 func (r *CityRepo) Create(ctx context.Context, city domain.City) (domain.City, error) {
-	log.Printf("Attempting to create city: %s", city.Name)
+	r.logger.DebugContext(ctx, "creating city", "city", city.Name)
 
 	query := `INSERT INTO cities (name) VALUES ($1) RETURNING id`
 	err := r.db.QueryRowContext(ctx, query, city.Name).Scan(&city.ID)
 	if err != nil {
 		msg := fmt.Sprintf("unable to create city %s: %v", city.Name, err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return domain.City{}, errors.New(msg)
 	}
 
-	log.Printf("Successfully created city: %s with ID: %d", city.Name, city.ID)
+	r.logger.DebugContext(ctx, "created city", "city", city.Name, "city_id", city.ID)
 	return city, nil
 }
 
 func (r *CityRepo) GetByName(ctx context.Context, name string) (domain.City, error) {
-	log.Printf("Attempting to get city by name: %s", name)
+	r.logger.DebugContext(ctx, "looking up city", "city", name)
 
 	var city domain.City
 	query := `SELECT id, name FROM cities WHERE name = $1`
 	err := r.db.QueryRowContext(ctx, query, name).Scan(&city.ID, &city.Name)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			log.Printf("City not found: %s", name)
+			r.logger.DebugContext(ctx, "city not found", "city", name)
 			return domain.City{}, domain.ErrCityNotFound
 		}
 		msg := fmt.Sprintf("unable to get city %s: %v", name, err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return domain.City{}, errors.New(msg)
 	}
 
-	log.Printf("Successfully found city: %s with ID: %d", city.Name, city.ID)
+	r.logger.DebugContext(ctx, "found city", "city", city.Name, "city_id", city.ID)
 	return city, nil
 }
+
+func (r *CityRepo) ListWithSubscriberCounts(ctx context.Context) (stats []domain.CityStats, err error) {
+	query := `
+        SELECT c.id, c.name, COUNT(DISTINCT s.subscriber_id), COUNT(s.id)
+        FROM cities c
+        LEFT JOIN subscriptions s ON s.city_id = c.id
+        GROUP BY c.id, c.name
+        ORDER BY COUNT(DISTINCT s.subscriber_id) DESC, c.name
+    `
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		msg := fmt.Sprintf("unable to list cities: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for rows.Next() {
+		var cityStats domain.CityStats
+		if err := rows.Scan(&cityStats.City.ID, &cityStats.City.Name, &cityStats.Subscribers, &cityStats.Subscriptions); err != nil {
+			return nil, err
+		}
+		stats = append(stats, cityStats)
+	}
+
+	return stats, rows.Err()
+}
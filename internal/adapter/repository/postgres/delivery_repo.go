@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+)
+
+type DeliveryRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewDeliveryRepository(db *sql.DB, logger *slog.Logger) *DeliveryRepository {
+	return &DeliveryRepository{db: db, logger: logger}
+}
+
+func (r *DeliveryRepository) RecordDelivery(ctx context.Context, delivery domain.Delivery) error {
+	query := `
+        INSERT INTO deliveries (subscription_id, status, error, created_at)
+        VALUES ($1, $2, $3, COALESCE($4, NOW()))
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.SubscriptionID,
+		delivery.Status,
+		delivery.Error,
+		nullTime(delivery.CreatedAt),
+	)
+	if err != nil {
+		msg := fmt.Sprintf("unable to record delivery for subscription %d: %v", delivery.SubscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *DeliveryRepository) GetDeliveries(ctx context.Context, subscriptionID int64, limit int) (deliveries []domain.Delivery, err error) {
+	query := `
+        SELECT id, subscription_id, status, error, created_at
+        FROM deliveries
+        WHERE subscription_id = $1
+        ORDER BY created_at DESC, id DESC
+        LIMIT $2
+    `
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID, limit)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get deliveries for subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for rows.Next() {
+		var delivery domain.Delivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.Status,
+			&delivery.Error,
+			&delivery.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
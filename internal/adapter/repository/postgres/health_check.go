@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+type HealthCheck struct {
+	db *sql.DB
+}
+
+func NewHealthCheck(db *sql.DB) *HealthCheck {
+	return &HealthCheck{db: db}
+}
+
+func (h *HealthCheck) Name() string {
+	return "postgres"
+}
+
+func (h *HealthCheck) Check(ctx context.Context) error {
+	return h.db.PingContext(ctx)
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+)
+
+// maxObservations caps the rows kept per subscription. Observations are
+// only taken when an update is sent, so a subscription whose updates keep
+// failing would otherwise collect them forever.
+const maxObservations = 48
+
+type WeatherObservationRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewWeatherObservationRepository(db *sql.DB, logger *slog.Logger) *WeatherObservationRepository {
+	return &WeatherObservationRepository{db: db, logger: logger}
+}
+
+func (r *WeatherObservationRepository) SaveObservation(ctx context.Context, subscriptionID int64, observation domain.WeatherObservation) error {
+	query := `
+        INSERT INTO quiet_hours_observations (subscription_id, temperature, humidity, description, observed_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		subscriptionID,
+		observation.Weather.Temperature,
+		observation.Weather.Humidity,
+		observation.Weather.Description,
+		observation.ObservedAt,
+	)
+	if err != nil {
+		msg := fmt.Sprintf("unable to save observation: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	query = `
+        DELETE FROM quiet_hours_observations
+        WHERE subscription_id = $1 AND id NOT IN (
+            SELECT id FROM quiet_hours_observations
+            WHERE subscription_id = $1
+            ORDER BY observed_at DESC
+            LIMIT $2
+        )
+    `
+	if _, err := r.db.ExecContext(ctx, query, subscriptionID, maxObservations); err != nil {
+		msg := fmt.Sprintf("unable to trim observations of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *WeatherObservationRepository) TakeObservations(ctx context.Context, subscriptionID int64) ([]domain.WeatherObservation, error) {
+	query := `
+        DELETE FROM quiet_hours_observations
+        WHERE subscription_id = $1
+        RETURNING temperature, humidity, description, observed_at
+    `
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	var observations []domain.WeatherObservation
+	for rows.Next() {
+		var obs domain.WeatherObservation
+		if err := rows.Scan(
+			&obs.Weather.Temperature,
+			&obs.Weather.Humidity,
+			&obs.Weather.Description,
+			&obs.ObservedAt,
+		); err != nil {
+			return nil, err
+		}
+		observations = append(observations, obs)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return observations, nil
+}
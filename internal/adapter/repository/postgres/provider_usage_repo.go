@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type ProviderUsageRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewProviderUsageRepository(db *sql.DB, logger *slog.Logger) *ProviderUsageRepository {
+	return &ProviderUsageRepository{db: db, logger: logger}
+}
+
+func (r *ProviderUsageRepository) IncrementUsage(ctx context.Context, provider string, month time.Time) (int64, error) {
+	query := `
+        INSERT INTO provider_usage (provider, month, requests)
+        VALUES ($1, $2, 1)
+        ON CONFLICT (provider, month) DO UPDATE
+        SET requests = provider_usage.requests + 1, updated_at = NOW()
+        RETURNING requests
+    `
+	var requests int64
+	if err := r.db.QueryRowContext(ctx, query, provider, month).Scan(&requests); err != nil {
+		msg := fmt.Sprintf("unable to increment usage of %s for %s: %v", provider, month.Format("2006-01"), err)
+		r.logger.ErrorContext(ctx, msg)
+		return 0, errors.New(msg)
+	}
+	return requests, nil
+}
+
+func (r *ProviderUsageRepository) GetUsage(ctx context.Context, provider string, month time.Time) (int64, error) {
+	query := `SELECT requests FROM provider_usage WHERE provider = $1 AND month = $2`
+	var requests int64
+	err := r.db.QueryRowContext(ctx, query, provider, month).Scan(&requests)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		msg := fmt.Sprintf("unable to get usage of %s for %s: %v", provider, month.Format("2006-01"), err)
+		r.logger.ErrorContext(ctx, msg)
+		return 0, errors.New(msg)
+	}
+	return requests, nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+)
+
+type PushRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewPushRepository(db *sql.DB, logger *slog.Logger) *PushRepository {
+	return &PushRepository{db: db, logger: logger}
+}
+
+// SavePushSubscription registers or replaces the browser push subscription of
+// a subscription and resumes it if an expired endpoint had paused it.
+func (r *PushRepository) SavePushSubscription(ctx context.Context, subscriptionID int64, push domain.PushSubscription) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		query := `
+            INSERT INTO push_subscriptions (subscription_id, endpoint, p256dh, auth)
+            VALUES ($1, $2, $3, $4)
+            ON CONFLICT (subscription_id) DO UPDATE
+            SET endpoint = EXCLUDED.endpoint, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth, updated_at = now()
+        `
+		if _, err := tx.ExecContext(ctx, query, subscriptionID, push.Endpoint, push.P256dh, push.Auth); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE subscriptions SET is_paused = false, updated_at = now() WHERE id = $1`, subscriptionID)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("unable to save push subscription of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// DeletePushSubscription switches the subscription back to email delivery.
+func (r *PushRepository) DeletePushSubscription(ctx context.Context, subscriptionID int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM push_subscriptions WHERE subscription_id = $1`, subscriptionID); err != nil {
+		msg := fmt.Sprintf("unable to delete push subscription of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// ExpirePushSubscription removes an endpoint the push service no longer
+// accepts and pauses the subscription, since the subscriber opted out of
+// email, until the browser registers again.
+func (r *PushRepository) ExpirePushSubscription(ctx context.Context, subscriptionID int64) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM push_subscriptions WHERE subscription_id = $1`, subscriptionID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE subscriptions SET is_paused = true, updated_at = now() WHERE id = $1`, subscriptionID)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("unable to expire push subscription of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
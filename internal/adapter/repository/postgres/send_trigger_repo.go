@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+)
+
+type SendTriggerRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewSendTriggerRepository(db *sql.DB, logger *slog.Logger) *SendTriggerRepository {
+	return &SendTriggerRepository{db: db, logger: logger}
+}
+
+func (r *SendTriggerRepository) Enqueue(ctx context.Context, frequency domain.Frequency) (bool, error) {
+	query := `
+        INSERT INTO send_triggers (frequency)
+        VALUES ($1)
+        ON CONFLICT (frequency) DO NOTHING
+    `
+	res, err := r.db.ExecContext(ctx, query, frequency)
+	if err != nil {
+		msg := fmt.Sprintf("unable to queue %s send: %v", frequency, err)
+		r.logger.ErrorContext(ctx, msg)
+		return false, errors.New(msg)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		msg := fmt.Sprintf("unable to queue %s send: %v", frequency, err)
+		r.logger.ErrorContext(ctx, msg)
+		return false, errors.New(msg)
+	}
+	return rows > 0, nil
+}
+
+func (r *SendTriggerRepository) TakePending(ctx context.Context) (frequencies []domain.Frequency, err error) {
+	query := `DELETE FROM send_triggers RETURNING frequency`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		msg := fmt.Sprintf("unable to take queued sends: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for rows.Next() {
+		var frequency domain.Frequency
+		if err := rows.Scan(&frequency); err != nil {
+			return nil, err
+		}
+		frequencies = append(frequencies, frequency)
+	}
+	return frequencies, rows.Err()
+}
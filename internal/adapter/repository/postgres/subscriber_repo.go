@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+)
+
+type SubscriberRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewSubscriberRepository(db *sql.DB, logger *slog.Logger) *SubscriberRepository {
+	return &SubscriberRepository{db: db, logger: logger}
+}
+
+func (r *SubscriberRepository) Create(ctx context.Context, subscriber domain.Subscriber) (domain.Subscriber, error) {
+	r.logger.DebugContext(ctx, "creating subscriber")
+	query := `
+        INSERT INTO subscribers (email, token) VALUES ($1, $2)
+        ON CONFLICT (email) DO UPDATE SET updated_at = now()
+        RETURNING id, token, created_at, updated_at
+    `
+	err := r.db.QueryRowContext(ctx, query, subscriber.Email, subscriber.Token).
+		Scan(&subscriber.ID, &subscriber.Token, &subscriber.CreatedAt, &subscriber.UpdatedAt)
+	if err != nil {
+		msg := fmt.Sprintf("unable to create subscriber: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return domain.Subscriber{}, errors.New(msg)
+	}
+	r.logger.DebugContext(ctx, "created subscriber", "subscriber_id", subscriber.ID)
+	return subscriber, nil
+}
+
+func (r *SubscriberRepository) GetByEmail(ctx context.Context, email string) (domain.Subscriber, error) {
+	query := `SELECT id, email, token, created_at, updated_at FROM subscribers WHERE email = $1`
+	return r.getOne(ctx, query, email)
+}
+
+func (r *SubscriberRepository) GetByToken(ctx context.Context, token string) (domain.Subscriber, error) {
+	query := `SELECT id, email, token, created_at, updated_at FROM subscribers WHERE token = $1`
+	return r.getOne(ctx, query, token)
+}
+
+func (r *SubscriberRepository) getOne(ctx context.Context, query string, arg string) (domain.Subscriber, error) {
+	var subscriber domain.Subscriber
+	err := r.db.QueryRowContext(ctx, query, arg).
+		Scan(&subscriber.ID, &subscriber.Email, &subscriber.Token, &subscriber.CreatedAt, &subscriber.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Subscriber{}, domain.ErrSubscriberNotFound
+		}
+		msg := fmt.Sprintf("unable to get subscriber: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return domain.Subscriber{}, errors.New(msg)
+	}
+	return subscriber, nil
+}
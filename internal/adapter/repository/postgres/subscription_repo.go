@@ -5,111 +5,269 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 )
 
+const subscriptionColumns = `s.id, s.subscriber_id, sb.token, s.email, s.city_id, c.name,
+               s.frequency, s.weekday, s.interval_hours, s.cron_expression, s.timezone,
+               s.quiet_start, s.quiet_end, s.quiet_summary, s.token,
+               s.is_confirmed, s.is_paused, s.severe_alerts, s.next_run_at, s.last_sent_at,
+               s.telegram_chat_id, w.url, w.secret, w.consecutive_failures, w.disabled_at,
+               p.endpoint, p.p256dh, p.auth`
+
 type SubscriptionRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	logger *slog.Logger
 }
 
-func NewSubscriptionRepo(db *sql.DB) *SubscriptionRepository {
-	return &SubscriptionRepository{db: db}
+func NewSubscriptionRepo(db *sql.DB, logger *slog.Logger) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db, logger: logger}
 }
 
 func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, sub domain.Subscription) error {
-	log.Printf("Creating subscription for city")
-	query := `INSERT INTO subscriptions (email, city_id, frequency, token, is_confirmed) VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.db.ExecContext(ctx, query, sub.Email, sub.CityID, sub.Frequency, sub.Token, sub.IsConfirmed)
+	r.logger.DebugContext(ctx, "creating subscription")
+	query := `
+        INSERT INTO subscriptions (subscriber_id, email, city_id, frequency, weekday, interval_hours,
+                                   cron_expression, timezone, quiet_start, quiet_end, quiet_summary,
+                                   token, is_confirmed, severe_alerts, telegram_chat_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		sub.SubscriberID,
+		sub.Email,
+		sub.CityID,
+		sub.Frequency,
+		sql.NullInt16{Int16: int16(sub.Schedule.Weekday), Valid: sub.Frequency == domain.FrequencyWeekly},
+		sql.NullInt16{Int16: int16(sub.Schedule.IntervalHours), Valid: sub.Frequency == domain.FrequencyInterval},
+		sql.NullString{String: sub.Schedule.CronExpression, Valid: sub.Frequency == domain.FrequencyCustom},
+		timezoneOrUTC(sub.Timezone),
+		sql.NullInt16{Int16: int16(sub.QuietHours.Start), Valid: sub.QuietHours.Enabled()},
+		sql.NullInt16{Int16: int16(sub.QuietHours.End), Valid: sub.QuietHours.Enabled()},
+		sub.QuietHours.SendSummary,
+		sub.Token,
+		sub.IsConfirmed,
+		sub.SevereAlerts,
+		sql.NullInt64{Int64: sub.TelegramChatID, Valid: sub.TelegramChatID != 0},
+	)
 	if err != nil {
 		msg := fmt.Sprintf("unable to create subscription: %v", err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
-	log.Printf("Successfully created subscription")
+	r.logger.DebugContext(ctx, "created subscription")
 	return nil
 }
 
 func (r *SubscriptionRepository) GetSubscriptionByToken(ctx context.Context, token string) (domain.Subscription, error) {
-	log.Printf("Looking up subscription")
-	var sub domain.Subscription
-	query := `SELECT token FROM subscriptions WHERE token = $1`
-	err := r.db.QueryRowContext(ctx, query, token).Scan(&sub.Token)
+	r.logger.DebugContext(ctx, "looking up subscription")
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions s
+        JOIN cities c ON s.city_id = c.id
+        JOIN subscribers sb ON s.subscriber_id = sb.id
+        LEFT JOIN subscription_webhooks w ON w.subscription_id = s.id
+        LEFT JOIN push_subscriptions p ON p.subscription_id = s.id
+        WHERE s.token = $1
+    `
+	subscriptions, err := r.querySubscriptions(ctx, query, token)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			log.Printf("No subscription found")
-			return domain.Subscription{}, domain.ErrSubscriptionNotFound
-		}
 		msg := fmt.Sprintf("error getting subscription: %v", err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return domain.Subscription{}, errors.New(msg)
 	}
-	log.Printf("Found subscription")
-	return sub, nil
+	if len(subscriptions) == 0 {
+		r.logger.DebugContext(ctx, "subscription not found")
+		return domain.Subscription{}, domain.ErrSubscriptionNotFound
+	}
+	r.logger.DebugContext(ctx, "found subscription")
+	return subscriptions[0], nil
+}
+
+func (r *SubscriptionRepository) GetSubscriptionByID(ctx context.Context, id int64) (domain.Subscription, error) {
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions s
+        JOIN cities c ON s.city_id = c.id
+        JOIN subscribers sb ON s.subscriber_id = sb.id
+        LEFT JOIN subscription_webhooks w ON w.subscription_id = s.id
+        LEFT JOIN push_subscriptions p ON p.subscription_id = s.id
+        WHERE s.id = $1
+    `
+	subscriptions, err := r.querySubscriptions(ctx, query, id)
+	if err != nil {
+		msg := fmt.Sprintf("error getting subscription %d: %v", id, err)
+		r.logger.ErrorContext(ctx, msg)
+		return domain.Subscription{}, errors.New(msg)
+	}
+	if len(subscriptions) == 0 {
+		return domain.Subscription{}, domain.ErrSubscriptionNotFound
+	}
+	return subscriptions[0], nil
+}
+
+func (r *SubscriptionRepository) SearchSubscriptions(ctx context.Context, opts out.SearchSubscriptionsOptions) ([]domain.Subscription, error) {
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions s
+        JOIN cities c ON s.city_id = c.id
+        JOIN subscribers sb ON s.subscriber_id = sb.id
+        LEFT JOIN subscription_webhooks w ON w.subscription_id = s.id
+        LEFT JOIN push_subscriptions p ON p.subscription_id = s.id
+        WHERE ($1 = '' OR s.email ILIKE '%' || $1 || '%')
+          AND ($2 = '' OR c.name ILIKE $2)
+        ORDER BY s.id
+        LIMIT $3 OFFSET $4
+    `
+	return r.querySubscriptions(ctx, query, opts.Email, opts.City, opts.Limit, opts.Offset)
 }
 
 func (r *SubscriptionRepository) UpdateSubscription(ctx context.Context, sub domain.Subscription) error {
-	log.Printf("Updating subscription")
-	query := `UPDATE subscriptions SET is_confirmed = $1 WHERE token = $2`
-	result, err := r.db.ExecContext(ctx, query, sub.IsConfirmed, sub.Token)
+	r.logger.DebugContext(ctx, "updating subscription")
+	query := `UPDATE subscriptions SET is_confirmed = $1, next_run_at = $2, updated_at = now() WHERE token = $3`
+	result, err := r.db.ExecContext(ctx, query, sub.IsConfirmed, nullTime(sub.NextRunAt), sub.Token)
 	if err != nil {
 		msg := fmt.Sprintf("unable to update subscription: %v", err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		msg := fmt.Sprintf("error getting rows affected: %v", err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
 	if rowsAffected == 0 {
-		log.Printf("No subscription found to update")
+		r.logger.DebugContext(ctx, "no subscription to update")
 		return domain.ErrSubscriptionNotFound
 	}
 
-	log.Printf("Successfully updated subscription")
+	r.logger.DebugContext(ctx, "updated subscription")
 	return nil
 }
 
 func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, token string) error {
-	log.Printf("Deleting subscription")
+	r.logger.DebugContext(ctx, "deleting subscription")
 	query := `DELETE FROM subscriptions WHERE token = $1`
 	result, err := r.db.ExecContext(ctx, query, token)
 	if err != nil {
 		msg := fmt.Sprintf("unable to delete subscription: %v", err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		msg := fmt.Sprintf("error getting rows affected: %v", err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
 	if rowsAffected == 0 {
-		log.Printf("No subscription found to delete")
+		r.logger.DebugContext(ctx, "no subscription to delete")
 		return domain.ErrSubscriptionNotFound
 	}
 
-	log.Printf("Successfully deleted subscription")
+	r.logger.DebugContext(ctx, "deleted subscription")
 	return nil
 }
 
 func (r *SubscriptionRepository) GetSubscriptionsByFrequency(ctx context.Context, frequency string) ([]domain.Subscription, error) {
 	query := `
-        SELECT s.id, s.email, s.city_id, c.name as city_name,
-               s.frequency, s.token, s.is_confirmed
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions s
+        JOIN cities c ON s.city_id = c.id
+        JOIN subscribers sb ON s.subscriber_id = sb.id
+        LEFT JOIN subscription_webhooks w ON w.subscription_id = s.id
+        LEFT JOIN push_subscriptions p ON p.subscription_id = s.id
+        WHERE s.frequency = $1 AND s.is_confirmed = true AND s.is_paused = false
+        ORDER BY s.email, c.name
+    `
+	return r.querySubscriptions(ctx, query, frequency)
+}
+
+func (r *SubscriptionRepository) GetDueSubscriptions(ctx context.Context, now time.Time) ([]domain.Subscription, error) {
+	query := `
+        SELECT ` + subscriptionColumns + `
         FROM subscriptions s
         JOIN cities c ON s.city_id = c.id
-        WHERE s.frequency = $1 AND s.is_confirmed = true
+        JOIN subscribers sb ON s.subscriber_id = sb.id
+        LEFT JOIN subscription_webhooks w ON w.subscription_id = s.id
+        LEFT JOIN push_subscriptions p ON p.subscription_id = s.id
+        WHERE s.is_confirmed = true AND s.is_paused = false AND s.next_run_at IS NOT NULL AND s.next_run_at <= $1
+        ORDER BY s.email, c.name
     `
-	rows, err := r.db.QueryContext(ctx, query, frequency)
+	return r.querySubscriptions(ctx, query, now)
+}
+
+func (r *SubscriptionRepository) MarkSent(ctx context.Context, opts out.MarkSentOptions) error {
+	query := `UPDATE subscriptions SET last_sent_at = $1, next_run_at = $2 WHERE id = $3`
+	if _, err := r.db.ExecContext(ctx, query, opts.SentAt, nullTime(opts.NextRunAt), opts.SubscriptionID); err != nil {
+		msg := fmt.Sprintf("unable to mark subscription %d as sent: %v", opts.SubscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) GetSubscriptionsBySubscriber(ctx context.Context, subscriberID int64) ([]domain.Subscription, error) {
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions s
+        JOIN cities c ON s.city_id = c.id
+        JOIN subscribers sb ON s.subscriber_id = sb.id
+        LEFT JOIN subscription_webhooks w ON w.subscription_id = s.id
+        LEFT JOIN push_subscriptions p ON p.subscription_id = s.id
+        WHERE s.subscriber_id = $1
+        ORDER BY c.name, s.frequency
+    `
+	return r.querySubscriptions(ctx, query, subscriberID)
+}
+
+func (r *SubscriptionRepository) DeleteSubscriptionsBySubscriber(ctx context.Context, subscriberID int64) error {
+	r.logger.DebugContext(ctx, "deleting subscriptions for subscriber", "subscriber_id", subscriberID)
+	query := `DELETE FROM subscriptions WHERE subscriber_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, subscriberID); err != nil {
+		msg := fmt.Sprintf("unable to delete subscriptions: %v", err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) UpdateNextRun(ctx context.Context, subscriptionID int64, nextRunAt time.Time) error {
+	query := `UPDATE subscriptions SET next_run_at = $1 WHERE id = $2`
+	if _, err := r.db.ExecContext(ctx, query, nullTime(nextRunAt), subscriptionID); err != nil {
+		msg := fmt.Sprintf("unable to update next run of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) PauseSubscriptionsByEmail(ctx context.Context, email string) (int64, error) {
+	query := `UPDATE subscriptions SET is_paused = true, updated_at = now() WHERE LOWER(email) = LOWER($1) AND is_paused = false`
+	result, err := r.db.ExecContext(ctx, query, email)
+	if err != nil {
+		msg := fmt.Sprintf("unable to pause subscriptions of %s: %v", email, err)
+		r.logger.ErrorContext(ctx, msg)
+		return 0, errors.New(msg)
+	}
+	paused, err := result.RowsAffected()
+	if err != nil {
+		msg := fmt.Sprintf("unable to count paused subscriptions of %s: %v", email, err)
+		r.logger.ErrorContext(ctx, msg)
+		return 0, errors.New(msg)
+	}
+	return paused, nil
+}
+
+func (r *SubscriptionRepository) querySubscriptions(ctx context.Context, query string, args ...any) ([]domain.Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,19 +281,80 @@ func (r *SubscriptionRepository) GetSubscriptionsByFrequency(ctx context.Context
 	for rows.Next() {
 		var sub domain.Subscription
 		var city domain.City
+		var subscriber domain.Subscriber
+		var weekday, intervalHours, quietStart, quietEnd sql.NullInt16
+		var cronExpression, webhookURL, webhookSecret sql.NullString
+		var webhookFailures sql.NullInt32
+		var telegramChatID sql.NullInt64
+		var pushEndpoint, pushP256dh, pushAuth sql.NullString
+		var nextRunAt, lastSentAt, webhookDisabledAt sql.NullTime
 		err := rows.Scan(
 			&sub.ID,
+			&sub.SubscriberID,
+			&subscriber.Token,
 			&sub.Email,
 			&sub.CityID,
 			&city.Name,
 			&sub.Frequency,
+			&weekday,
+			&intervalHours,
+			&cronExpression,
+			&sub.Timezone,
+			&quietStart,
+			&quietEnd,
+			&sub.QuietHours.SendSummary,
 			&sub.Token,
 			&sub.IsConfirmed,
+			&sub.IsPaused,
+			&sub.SevereAlerts,
+			&nextRunAt,
+			&lastSentAt,
+			&telegramChatID,
+			&webhookURL,
+			&webhookSecret,
+			&webhookFailures,
+			&webhookDisabledAt,
+			&pushEndpoint,
+			&pushP256dh,
+			&pushAuth,
 		)
 		if err != nil {
 			return nil, err
 		}
+		sub.Schedule = domain.Schedule{
+			Weekday:        time.Weekday(weekday.Int16),
+			IntervalHours:  int(intervalHours.Int16),
+			CronExpression: cronExpression.String,
+		}
+		sub.QuietHours.Start = int(quietStart.Int16)
+		sub.QuietHours.End = int(quietEnd.Int16)
+		sub.NextRunAt = nextRunAt.Time
+		if lastSentAt.Valid {
+			sub.LastSentAt = &lastSentAt.Time
+		}
+		sub.TelegramChatID = telegramChatID.Int64
+		if webhookURL.Valid {
+			sub.Webhook = &domain.Webhook{
+				URL:                 webhookURL.String,
+				Secret:              webhookSecret.String,
+				ConsecutiveFailures: int(webhookFailures.Int32),
+			}
+			if webhookDisabledAt.Valid {
+				sub.Webhook.DisabledAt = &webhookDisabledAt.Time
+			}
+		}
+		if pushEndpoint.Valid {
+			sub.Push = &domain.PushSubscription{
+				Endpoint: pushEndpoint.String,
+				P256dh:   pushP256dh.String,
+				Auth:     pushAuth.String,
+			}
+		}
+		city.ID = sub.CityID
+		subscriber.ID = sub.SubscriberID
+		subscriber.Email = sub.Email
 		sub.City = &city
+		sub.Subscriber = &subscriber
 		subscriptions = append(subscriptions, sub)
 	}
 
@@ -146,17 +365,30 @@ func (r *SubscriptionRepository) GetSubscriptionsByFrequency(ctx context.Context
 	return subscriptions, nil
 }
 
+func (r *SubscriptionRepository) GetAlertSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions s
+        JOIN cities c ON s.city_id = c.id
+        JOIN subscribers sb ON s.subscriber_id = sb.id
+        LEFT JOIN subscription_webhooks w ON w.subscription_id = s.id
+        LEFT JOIN push_subscriptions p ON p.subscription_id = s.id
+        WHERE s.severe_alerts = true AND s.is_confirmed = true AND s.is_paused = false
+    `
+	return r.querySubscriptions(ctx, query)
+}
+
 func (r *SubscriptionRepository) IsTokenExists(ctx context.Context, token string) (bool, error) {
-	log.Printf("Checking if token exists: %s", token)
+	r.logger.DebugContext(ctx, "checking token", "token", token)
 	query := `SELECT EXISTS(SELECT 1 FROM subscriptions WHERE token = $1)`
 	var exists bool
 	err := r.db.QueryRowContext(ctx, query, token).Scan(&exists)
 	if err != nil {
 		msg := fmt.Sprintf("unable to check token existence: %v", err)
-		log.Print(msg)
+		r.logger.ErrorContext(ctx, msg)
 		return false, errors.New(msg)
 	}
-	log.Printf("Token existence check result: %v", exists)
+	r.logger.DebugContext(ctx, "checked token", "exists", exists)
 	return exists, nil
 }
 
@@ -174,3 +406,10 @@ func (r *SubscriptionRepository) IsSubscriptionExists(ctx context.Context, opts
 	}
 	return exists, nil
 }
+
+func timezoneOrUTC(timezone string) string {
+	if timezone == "" {
+		return "UTC"
+	}
+	return timezone
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"weather-api/internal/core/domain"
+)
+
+type SuppressionRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewSuppressionRepository(db *sql.DB, logger *slog.Logger) *SuppressionRepository {
+	return &SuppressionRepository{db: db, logger: logger}
+}
+
+// Suppress adds the address to the suppression list. A complaint overrides an
+// earlier bounce so the stronger reason is kept.
+func (r *SuppressionRepository) Suppress(ctx context.Context, suppression domain.Suppression) error {
+	query := `
+        INSERT INTO email_suppressions (email, reason, details)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (email) DO UPDATE
+        SET reason = EXCLUDED.reason, details = EXCLUDED.details
+        WHERE email_suppressions.reason <> $4
+    `
+	email := strings.ToLower(strings.TrimSpace(suppression.Email))
+	if _, err := r.db.ExecContext(ctx, query, email, suppression.Reason, suppression.Details, domain.BounceTypeComplaint); err != nil {
+		msg := fmt.Sprintf("unable to suppress %s: %v", email, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *SuppressionRepository) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM email_suppressions WHERE email = $1)`
+	var suppressed bool
+	if err := r.db.QueryRowContext(ctx, query, strings.ToLower(strings.TrimSpace(email))).Scan(&suppressed); err != nil {
+		msg := fmt.Sprintf("unable to check suppression of %s: %v", email, err)
+		r.logger.ErrorContext(ctx, msg)
+		return false, errors.New(msg)
+	}
+	return suppressed, nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+)
+
+type WebhookRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewWebhookRepository(db *sql.DB, logger *slog.Logger) *WebhookRepository {
+	return &WebhookRepository{db: db, logger: logger}
+}
+
+// SaveWebhook registers or replaces the webhook of a subscription. A new
+// registration re-enables a disabled webhook and resumes the subscription.
+func (r *WebhookRepository) SaveWebhook(ctx context.Context, subscriptionID int64, webhook domain.Webhook) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		query := `
+            INSERT INTO subscription_webhooks (subscription_id, url, secret)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (subscription_id) DO UPDATE
+            SET url = EXCLUDED.url, secret = EXCLUDED.secret, consecutive_failures = 0,
+                disabled_at = NULL, updated_at = now()
+        `
+		if _, err := tx.ExecContext(ctx, query, subscriptionID, webhook.URL, webhook.Secret); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE subscriptions SET is_paused = false, updated_at = now() WHERE id = $1`, subscriptionID)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("unable to save webhook of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// DeleteWebhook switches the subscription back to email delivery.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, subscriptionID int64) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM subscription_webhooks WHERE subscription_id = $1`, subscriptionID)
+		if err != nil {
+			return err
+		}
+		if deleted, err := result.RowsAffected(); err != nil || deleted == 0 {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE subscriptions SET is_paused = false, updated_at = now() WHERE id = $1`, subscriptionID)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("unable to delete webhook of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) RecordFailure(ctx context.Context, subscriptionID int64) (int, error) {
+	query := `
+        UPDATE subscription_webhooks
+        SET consecutive_failures = consecutive_failures + 1, updated_at = now()
+        WHERE subscription_id = $1
+        RETURNING consecutive_failures
+    `
+	var failures int
+	if err := r.db.QueryRowContext(ctx, query, subscriptionID).Scan(&failures); err != nil {
+		msg := fmt.Sprintf("unable to record webhook failure of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return 0, errors.New(msg)
+	}
+	return failures, nil
+}
+
+func (r *WebhookRepository) ResetFailures(ctx context.Context, subscriptionID int64) error {
+	query := `UPDATE subscription_webhooks SET consecutive_failures = 0, updated_at = now() WHERE subscription_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, subscriptionID); err != nil {
+		msg := fmt.Sprintf("unable to reset webhook failures of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// DisableWebhook stops deliveries to the webhook and pauses the subscription
+// until a webhook is registered again.
+func (r *WebhookRepository) DisableWebhook(ctx context.Context, subscriptionID int64) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		query := `UPDATE subscription_webhooks SET disabled_at = now(), updated_at = now() WHERE subscription_id = $1`
+		if _, err := tx.ExecContext(ctx, query, subscriptionID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE subscriptions SET is_paused = true, updated_at = now() WHERE id = $1`, subscriptionID)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("unable to disable webhook of subscription %d: %v", subscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) RecordDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	query := `
+        INSERT INTO webhook_deliveries (subscription_id, message_id, attempt, status_code, error, duration_ms)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	if _, err := r.db.ExecContext(ctx, query, delivery.SubscriptionID, delivery.MessageID, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.Duration.Milliseconds()); err != nil {
+		msg := fmt.Sprintf("unable to record webhook delivery for subscription %d: %v", delivery.SubscriptionID, err)
+		r.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,225 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/in"
+	"weather-api/internal/core/ports/out"
+)
+
+const (
+	// SecretTokenHeader carries the secret_token set with setWebhook.
+	SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+	pollRetryDelay = 5 * time.Second
+	helpText       = "Commands:\n" +
+		"/subscribe <city> <daily|hourly> - receive weather updates here\n" +
+		"/weather <city> - current weather\n" +
+		"/list - your subscriptions\n" +
+		"/unsubscribe [city] - stop updates for a city, or all of them"
+)
+
+// Bot answers chat commands using the same use cases as the HTTP API.
+type Bot struct {
+	client           *Client
+	subscribeUseCase in.SubscribeUseCase
+	confirmUseCase   in.ConfirmSubscriptionUseCase
+	weatherUseCase   in.WeatherUseCase
+	chatUseCase      in.TelegramChatUseCase
+	logger           *slog.Logger
+}
+
+func NewBot(
+	client *Client,
+	subscribeUseCase in.SubscribeUseCase,
+	confirmUseCase in.ConfirmSubscriptionUseCase,
+	weatherUseCase in.WeatherUseCase,
+	chatUseCase in.TelegramChatUseCase,
+	logger *slog.Logger,
+) *Bot {
+	return &Bot{
+		client:           client,
+		subscribeUseCase: subscribeUseCase,
+		confirmUseCase:   confirmUseCase,
+		weatherUseCase:   weatherUseCase,
+		chatUseCase:      chatUseCase,
+		logger:           logger,
+	}
+}
+
+// Poll receives updates with getUpdates long polling until ctx is cancelled.
+func (b *Bot) Poll(ctx context.Context, timeout time.Duration) {
+	b.logger.InfoContext(ctx, "telegram bot is polling for updates")
+	var offset int64
+	for ctx.Err() == nil {
+		updates, err := b.client.GetUpdates(ctx, offset, timeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			b.logger.ErrorContext(ctx, "unable to get telegram updates", "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(pollRetryDelay):
+			}
+			continue
+		}
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			b.HandleUpdate(ctx, update)
+		}
+	}
+	b.logger.InfoContext(ctx, "telegram bot stopped polling")
+}
+
+// WebhookHandler receives updates pushed by Telegram. Requests must carry
+// secret in the X-Telegram-Bot-Api-Secret-Token header.
+func (b *Bot) WebhookHandler(secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided := r.Header.Get(SecretTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var update Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b.HandleUpdate(r.Context(), update)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (b *Bot) HandleUpdate(ctx context.Context, update Update) {
+	if update.Message == nil || update.Message.Text == "" {
+		return
+	}
+	chatID := update.Message.Chat.ID
+	reply := b.reply(ctx, chatID, update.Message.Text)
+	if err := b.client.SendMessage(ctx, chatID, reply); err != nil {
+		b.logger.ErrorContext(ctx, "unable to reply to telegram chat", "chat_id", chatID, "error", err)
+	}
+}
+
+func (b *Bot) reply(ctx context.Context, chatID int64, text string) string {
+	command, args := parseCommand(text)
+	switch command {
+	case "/subscribe":
+		return b.subscribe(ctx, chatID, args)
+	case "/weather":
+		return b.weather(ctx, args)
+	case "/unsubscribe":
+		return b.unsubscribe(ctx, chatID, args)
+	case "/list":
+		return b.list(ctx, chatID)
+	default:
+		return helpText
+	}
+}
+
+func (b *Bot) subscribe(ctx context.Context, chatID int64, args []string) string {
+	if len(args) < 2 {
+		return "Usage: /subscribe <city> <daily|hourly>"
+	}
+	frequency := domain.Frequency(strings.ToLower(args[len(args)-1]))
+	if frequency != domain.FrequencyDaily && frequency != domain.FrequencyHourly {
+		return "Frequency must be daily or hourly"
+	}
+	city := strings.Join(args[:len(args)-1], " ")
+
+	token, err := b.subscribeUseCase.Subscribe(ctx, out.SubscribeOptions{
+		City:           city,
+		Frequency:      frequency,
+		TelegramChatID: chatID,
+	})
+	if err != nil {
+		b.logger.WarnContext(ctx, "unable to subscribe telegram chat", "chat_id", chatID, "error", err)
+		switch {
+		case errors.Is(err, domain.ErrEmailAlreadySubscribed):
+			return fmt.Sprintf("You already receive %s updates for %s", frequency, city)
+		case errors.Is(err, domain.ErrCityNotFound):
+			return fmt.Sprintf("City %s not found", city)
+		default:
+			return "Something went wrong, please try again later"
+		}
+	}
+
+	// The chat itself proves ownership, so there is no confirmation link.
+	if err := b.confirmUseCase.ConfirmSubscription(ctx, token); err != nil {
+		b.logger.WarnContext(ctx, "unable to confirm telegram subscription", "chat_id", chatID, "error", err)
+		return "Something went wrong, please try again later"
+	}
+	return fmt.Sprintf("Subscribed to %s updates for %s", frequency, city)
+}
+
+func (b *Bot) weather(ctx context.Context, args []string) string {
+	if len(args) == 0 {
+		return "Usage: /weather <city>"
+	}
+	city := strings.Join(args, " ")
+
+	weather, err := b.weatherUseCase.GetWeather(ctx, city)
+	if err != nil {
+		if errors.Is(err, domain.ErrCityNotFound) {
+			return fmt.Sprintf("City %s not found", city)
+		}
+		b.logger.WarnContext(ctx, "unable to get weather", "city", city, "error", err)
+		return "Weather is unavailable right now, please try again later"
+	}
+	return formatWeather(city, weather)
+}
+
+func (b *Bot) unsubscribe(ctx context.Context, chatID int64, args []string) string {
+	city := strings.Join(args, " ")
+	removed, err := b.chatUseCase.Unsubscribe(ctx, chatID, city)
+	if err != nil {
+		b.logger.WarnContext(ctx, "unable to unsubscribe telegram chat", "chat_id", chatID, "error", err)
+		return "Something went wrong, please try again later"
+	}
+	switch {
+	case removed == 0 && city != "":
+		return fmt.Sprintf("You have no subscriptions for %s", city)
+	case removed == 0:
+		return "You have no subscriptions"
+	default:
+		return fmt.Sprintf("Removed %d subscription(s)", removed)
+	}
+}
+
+func (b *Bot) list(ctx context.Context, chatID int64) string {
+	subscriptions, err := b.chatUseCase.ListSubscriptions(ctx, chatID)
+	if err != nil {
+		b.logger.WarnContext(ctx, "unable to list telegram chat subscriptions", "chat_id", chatID, "error", err)
+		return "Something went wrong, please try again later"
+	}
+	if len(subscriptions) == 0 {
+		return "You have no subscriptions"
+	}
+
+	lines := make([]string, 0, len(subscriptions)+1)
+	lines = append(lines, "Your subscriptions:")
+	for _, sub := range subscriptions {
+		lines = append(lines, fmt.Sprintf("%s - %s", sub.City.Name, sub.Frequency))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseCommand splits "/command@BotName arg1 arg2" into "/command" and its args.
+func parseCommand(text string) (string, []string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	command, _, _ := strings.Cut(strings.ToLower(fields[0]), "@")
+	return command, fields[1:]
+}
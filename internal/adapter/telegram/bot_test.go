@@ -0,0 +1,202 @@
+//go:build unit
+// +build unit
+
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBotAPI serves queued updates and records sent messages.
+type fakeBotAPI struct {
+	mu      sync.Mutex
+	updates []Update
+	sent    []map[string]any
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var payload map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/bottest-token/getUpdates"):
+		result, _ := json.Marshal(f.updates)
+		f.updates = nil
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": json.RawMessage(result)})
+	case strings.HasSuffix(r.URL.Path, "/bottest-token/sendMessage"):
+		f.sent = append(f.sent, payload)
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": false, "description": "Not Found"})
+	}
+}
+
+func (f *fakeBotAPI) texts() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	texts := make([]string, 0, len(f.sent))
+	for _, msg := range f.sent {
+		texts = append(texts, msg["text"].(string))
+	}
+	return texts
+}
+
+type stubSubscribe struct{ opts []out.SubscribeOptions }
+
+func (s *stubSubscribe) Subscribe(_ context.Context, opts out.SubscribeOptions) (string, error) {
+	if opts.City == "Atlantis" {
+		return "", domain.ErrCityNotFound
+	}
+	s.opts = append(s.opts, opts)
+	return "token", nil
+}
+
+type stubConfirm struct{ tokens []string }
+
+func (s *stubConfirm) ConfirmSubscription(_ context.Context, token string) error {
+	s.tokens = append(s.tokens, token)
+	return nil
+}
+
+type stubWeather struct{}
+
+func (stubWeather) GetWeather(context.Context, string) (domain.Weather, error) {
+	return domain.Weather{Temperature: 21.5, Humidity: 40, Description: "Sunny"}, nil
+}
+
+type stubChats struct{}
+
+func (stubChats) ListSubscriptions(context.Context, int64) ([]domain.Subscription, error) {
+	return []domain.Subscription{{City: &domain.City{Name: "Kyiv"}, Frequency: domain.FrequencyDaily}}, nil
+}
+
+func (stubChats) Unsubscribe(context.Context, int64, string) (int, error) {
+	return 1, nil
+}
+
+func newTestBot(t *testing.T) (*Bot, *fakeBotAPI, *stubSubscribe, *stubConfirm) {
+	api := &fakeBotAPI{}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := NewClient(ClientOptions{BaseURL: server.URL, Token: "test-token", HTTPClient: server.Client()})
+	subscribe := &stubSubscribe{}
+	confirm := &stubConfirm{}
+	return NewBot(client, subscribe, confirm, stubWeather{}, stubChats{}, logger.Discard()), api, subscribe, confirm
+}
+
+func message(chatID int64, text string) Update {
+	return Update{UpdateID: 1, Message: &Message{Chat: Chat{ID: chatID}, Text: text}}
+}
+
+func TestBot_Commands(t *testing.T) {
+	bot, api, subscribe, confirm := newTestBot(t)
+	ctx := context.Background()
+
+	bot.HandleUpdate(ctx, message(42, "/subscribe@WeatherBot New York daily"))
+	bot.HandleUpdate(ctx, message(42, "/subscribe Atlantis hourly"))
+	bot.HandleUpdate(ctx, message(42, "/subscribe Kyiv weekly"))
+	bot.HandleUpdate(ctx, message(42, "/weather Kyiv"))
+	bot.HandleUpdate(ctx, message(42, "/list"))
+	bot.HandleUpdate(ctx, message(42, "/unsubscribe"))
+	bot.HandleUpdate(ctx, message(42, "/start"))
+
+	require.Len(t, subscribe.opts, 1)
+	assert.Equal(t, out.SubscribeOptions{City: "New York", Frequency: domain.FrequencyDaily, TelegramChatID: 42}, subscribe.opts[0])
+	assert.Equal(t, []string{"token"}, confirm.tokens)
+	assert.Equal(t, []string{
+		"Subscribed to daily updates for New York",
+		"City Atlantis not found",
+		"Frequency must be daily or hourly",
+		"Kyiv: 21.5°C, humidity 40%, Sunny",
+		"Your subscriptions:\nKyiv - daily",
+		"Removed 1 subscription(s)",
+		helpText,
+	}, api.texts())
+}
+
+func TestBot_Poll(t *testing.T) {
+	bot, api, _, _ := newTestBot(t)
+	api.updates = []Update{message(7, "/weather Lviv")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		bot.Poll(ctx, 0)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return len(api.texts()) == 1 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+	assert.Equal(t, []string{"Lviv: 21.5°C, humidity 40%, Sunny"}, api.texts())
+}
+
+func TestBot_WebhookHandlerRequiresSecret(t *testing.T) {
+	bot, api, _, _ := newTestBot(t)
+	handler := bot.WebhookHandler("hook-secret")
+	body := `{"update_id": 3, "message": {"chat": {"id": 9}, "text": "/list"}}`
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/telegram/webhook", strings.NewReader(body)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/telegram/webhook", strings.NewReader(body))
+	req.Header.Set(SecretTokenHeader, "hook-secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"Your subscriptions:\nKyiv - daily"}, api.texts())
+}
+
+func TestNotifier_SendsDigestToChat(t *testing.T) {
+	api := &fakeBotAPI{}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	client := NewClient(ClientOptions{BaseURL: server.URL, Token: "test-token", HTTPClient: server.Client()})
+
+	err := NewNotifier(client).Notify(context.Background(), domain.WeatherDigest{
+		Channel: domain.ChannelTelegram,
+		Updates: []domain.WeatherUpdate{{
+			Subscription: domain.Subscription{City: &domain.City{Name: "Kyiv"}, TelegramChatID: 42},
+			Weather:      domain.Weather{Temperature: 18, Humidity: 60, Description: "Cloudy"},
+		}},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, api.sent, 1)
+	assert.Equal(t, float64(42), api.sent[0]["chat_id"])
+	assert.Equal(t, "Weather update\n\nKyiv: 18.0°C, humidity 60%, Cloudy", api.sent[0]["text"])
+}
+
+func TestNotifier_SendsAlertToChat(t *testing.T) {
+	api := &fakeBotAPI{}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	client := NewClient(ClientOptions{BaseURL: server.URL, Token: "test-token", HTTPClient: server.Client()})
+
+	err := NewNotifier(client).NotifyAlert(context.Background(),
+		domain.Subscription{City: &domain.City{Name: "Kyiv"}, TelegramChatID: 42},
+		domain.Alert{Headline: "Storm warning", Event: "Storm", Severity: "Severe"})
+
+	require.NoError(t, err)
+	require.Len(t, api.sent, 1)
+	assert.Equal(t, float64(42), api.sent[0]["chat_id"])
+	assert.Equal(t, "Severe alert for Kyiv\n\nStorm warning", api.sent[0]["text"])
+}
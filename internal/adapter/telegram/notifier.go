@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"weather-api/internal/core/domain"
+)
+
+// Notifier delivers weather digests and alerts as chat messages.
+type Notifier struct {
+	client *Client
+}
+
+func NewNotifier(client *Client) *Notifier {
+	return &Notifier{client: client}
+}
+
+func (n *Notifier) Notify(ctx context.Context, digest domain.WeatherDigest) error {
+	if len(digest.Updates) == 0 {
+		return nil
+	}
+	chatID := digest.Updates[0].Subscription.TelegramChatID
+	if chatID == 0 {
+		return errors.New("digest has no telegram chat")
+	}
+
+	if err := n.client.SendMessage(ctx, chatID, formatDigest(digest)); err != nil {
+		return fmt.Errorf("unable to send weather update to chat %d: %w", chatID, err)
+	}
+	return nil
+}
+
+func (n *Notifier) NotifyAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error {
+	if subscription.TelegramChatID == 0 {
+		return errors.New("subscription has no telegram chat")
+	}
+
+	if err := n.client.SendMessage(ctx, subscription.TelegramChatID, formatAlert(subscription.City.Name, alert)); err != nil {
+		return fmt.Errorf("unable to send weather alert to chat %d: %w", subscription.TelegramChatID, err)
+	}
+	return nil
+}
+
+func formatAlert(city string, alert domain.Alert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s alert for %s\n\n%s", alert.Severity, city, alert.Headline)
+	if alert.Instruction != "" {
+		b.WriteString("\n\n")
+		b.WriteString(alert.Instruction)
+	}
+	if !alert.Expires.IsZero() {
+		fmt.Fprintf(&b, "\n\nUntil %s", alert.Expires.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+	return b.String()
+}
+
+func formatDigest(digest domain.WeatherDigest) string {
+	var b strings.Builder
+	b.WriteString("Weather update")
+	for _, update := range digest.Updates {
+		b.WriteString("\n\n")
+		b.WriteString(formatWeather(update.Subscription.City.Name, update.Weather))
+		if summary := update.Overnight; summary != nil {
+			fmt.Fprintf(&b, "\nOvernight (%s–%s): %.1f°C to %.1f°C",
+				summary.From.Format("15:04"), summary.To.Format("15:04"),
+				summary.MinTemperature, summary.MaxTemperature)
+		}
+	}
+	return b.String()
+}
+
+func formatWeather(city string, weather domain.Weather) string {
+	return fmt.Sprintf("%s: %.1f°C, humidity %d%%, %s", city, weather.Temperature, weather.Humidity, weather.Description)
+}
@@ -3,14 +3,28 @@ package weather
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("weather-api/internal/adapter/weather")
+
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
 )
 
 type ProviderHandler struct {
 	provider out.WeatherProvider
 	next     *ProviderHandler
+	metrics  *ProviderMetrics
+	logger   *slog.Logger
 }
 
 func (h *ProviderHandler) SetNext(handler *ProviderHandler) *ProviderHandler {
@@ -19,15 +33,16 @@ func (h *ProviderHandler) SetNext(handler *ProviderHandler) *ProviderHandler {
 }
 
 func (h *ProviderHandler) HandleGetWeather(ctx context.Context, city string) (domain.Weather, error) {
-	weather, err := h.provider.GetWeather(ctx, city)
+	weather, err := h.getWeather(ctx, city)
 	if err == nil {
-		log.Printf("Successfully got weatherapi from %s", h.provider.Name())
+		h.logger.DebugContext(ctx, "got weather", "provider", h.provider.Name(), "city", city)
 		return weather, nil
 	}
 
-	log.Printf("Provider %s error: %v. Trying next provider...", h.provider.Name(), err)
+	h.logger.WarnContext(ctx, "provider failed, trying next", "provider", h.provider.Name(), "city", city, "error", err)
 
 	if h.next != nil {
+		h.recordFallback(operationGetWeather)
 		return h.next.HandleGetWeather(ctx, city)
 	}
 
@@ -35,18 +50,62 @@ func (h *ProviderHandler) HandleGetWeather(ctx context.Context, city string) (do
 }
 
 func (h *ProviderHandler) HandleCheckCityExists(ctx context.Context, city string) error {
-	err := h.provider.CheckCityExists(ctx, city)
+	err := h.checkCityExists(ctx, city)
 	if err == nil {
 		return nil
 	}
 
 	if h.next != nil {
+		h.recordFallback(operationCheckCity)
 		return h.next.HandleCheckCityExists(ctx, city)
 	}
 
 	return domain.ErrCityNotFound
 }
 
+func (h *ProviderHandler) recordFallback(operation string) {
+	if h.metrics != nil {
+		h.metrics.fallback(h.provider.Name(), operation)
+	}
+}
+
+// getWeather and checkCityExists wrap a single hop in its own span, ended
+// before the next handler runs, so a trace shows every provider tried.
+func (h *ProviderHandler) getWeather(ctx context.Context, city string) (domain.Weather, error) {
+	ctx, span := h.startSpan(ctx, "weather provider get", city)
+	defer span.End()
+
+	weather, err := h.provider.GetWeather(ctx, city)
+	recordOutcome(span, err)
+	return weather, err
+}
+
+func (h *ProviderHandler) checkCityExists(ctx context.Context, city string) error {
+	ctx, span := h.startSpan(ctx, "weather provider check city", city)
+	defer span.End()
+
+	err := h.provider.CheckCityExists(ctx, city)
+	recordOutcome(span, err)
+	return err
+}
+
+func (h *ProviderHandler) startSpan(ctx context.Context, name, city string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("weather.provider", h.provider.Name()),
+		attribute.String("weather.city", city),
+	))
+}
+
+func recordOutcome(span trace.Span, err error) {
+	if err != nil {
+		span.SetAttributes(attribute.String("weather.outcome", outcomeError))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(attribute.String("weather.outcome", outcomeSuccess))
+}
+
 type ChainWeatherProvider struct {
 	start *ProviderHandler
 }
@@ -55,16 +114,16 @@ func (c *ChainWeatherProvider) Name() string {
 	return "ChainWeatherProvider"
 }
 
-func NewChainWeatherProvider(providers ...out.WeatherProvider) *ChainWeatherProvider {
+func NewChainWeatherProvider(logger *slog.Logger, providers ...out.WeatherProvider) *ChainWeatherProvider {
 	if len(providers) == 0 {
 		return &ChainWeatherProvider{}
 	}
 
-	startHandler := &ProviderHandler{provider: providers[0]}
+	startHandler := &ProviderHandler{provider: providers[0], logger: logger}
 	currentHandler := startHandler
 
 	for i := 1; i < len(providers); i++ {
-		nextHandler := &ProviderHandler{provider: providers[i]}
+		nextHandler := &ProviderHandler{provider: providers[i], logger: logger}
 		currentHandler.SetNext(nextHandler)
 		currentHandler = nextHandler
 	}
@@ -72,6 +131,14 @@ func NewChainWeatherProvider(providers ...out.WeatherProvider) *ChainWeatherProv
 	return &ChainWeatherProvider{start: startHandler}
 }
 
+// WithMetrics counts every fallback from a failed provider to the next one.
+func (c *ChainWeatherProvider) WithMetrics(metrics *ProviderMetrics) *ChainWeatherProvider {
+	for h := c.start; h != nil; h = h.next {
+		h.metrics = metrics
+	}
+	return c
+}
+
 func (c *ChainWeatherProvider) GetWeather(ctx context.Context, city string) (domain.Weather, error) {
 	if c.start == nil {
 		return domain.Weather{}, errors.New("no weatherapi providers in chain")
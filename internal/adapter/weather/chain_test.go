@@ -8,6 +8,12 @@ import (
 	"errors"
 	"testing"
 	"weather-api/internal/core/domain"
+	"weather-api/internal/util/logger"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 type MockFailingProvider struct{}
@@ -46,7 +52,7 @@ func TestChainWeatherProvider_Fallback(t *testing.T) {
 	failingProvider := &MockFailingProvider{}
 	successfulProvider := &MockSuccessfulProvider{}
 
-	chain := NewChainWeatherProvider(failingProvider, successfulProvider)
+	chain := NewChainWeatherProvider(logger.Discard(), failingProvider, successfulProvider)
 
 	weather, err := chain.GetWeather(context.Background(), "Kyiv")
 	if err != nil {
@@ -75,7 +81,7 @@ func TestChainWeatherProvider_AllProvidersFail(t *testing.T) {
 	failingProvider1 := &MockFailingProvider{}
 	failingProvider2 := &MockFailingProvider{}
 
-	chain := NewChainWeatherProvider(failingProvider1, failingProvider2)
+	chain := NewChainWeatherProvider(logger.Discard(), failingProvider1, failingProvider2)
 
 	_, err := chain.GetWeather(context.Background(), "Kyiv")
 	if err == nil {
@@ -95,3 +101,32 @@ func TestChainWeatherProvider_AllProvidersFail(t *testing.T) {
 		t.Errorf("Expected domain.ErrCityNotFound, got: %v", err)
 	}
 }
+
+func TestChainWeatherProvider_SpanPerProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	chain := NewChainWeatherProvider(logger.Discard(), &MockFailingProvider{}, &MockSuccessfulProvider{})
+	if _, err := chain.GetWeather(context.Background(), "Kyiv"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got: %d", len(spans))
+	}
+
+	expected := []struct{ provider, outcome string }{
+		{"MockFailingProvider", outcomeError},
+		{"MockSuccessfulProvider", outcomeSuccess},
+	}
+	for i, span := range spans {
+		attrs := attribute.NewSet(span.Attributes()...)
+		provider, _ := attrs.Value("weather.provider")
+		outcome, _ := attrs.Value("weather.outcome")
+		if provider.AsString() != expected[i].provider || outcome.AsString() != expected[i].outcome {
+			t.Errorf("Span %d: expected %s/%s, got: %s/%s", i,
+				expected[i].provider, expected[i].outcome, provider.AsString(), outcome.AsString())
+		}
+	}
+}
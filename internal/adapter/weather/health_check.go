@@ -0,0 +1,28 @@
+package weather
+
+import (
+	"context"
+	"weather-api/internal/core/ports/out"
+)
+
+// ProviderHealthCheck looks up a well-known city. Every check is a billable
+// provider call, so register it with a generous cache ttl.
+type ProviderHealthCheck struct {
+	provider out.WeatherProvider
+	city     string
+}
+
+func NewProviderHealthCheck(provider out.WeatherProvider, city string) *ProviderHealthCheck {
+	return &ProviderHealthCheck{
+		provider: provider,
+		city:     city,
+	}
+}
+
+func (h *ProviderHealthCheck) Name() string {
+	return "provider:" + h.provider.Name()
+}
+
+func (h *ProviderHealthCheck) Check(ctx context.Context) error {
+	return h.provider.CheckCityExists(ctx, h.city)
+}
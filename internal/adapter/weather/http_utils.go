@@ -3,22 +3,22 @@ package weather
 import (
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 )
 
-func ExecuteRequest(httpClient HTTPDoer, req *http.Request) (*http.Response, error) {
+func ExecuteRequest(httpClient HTTPDoer, logger *slog.Logger, req *http.Request) (*http.Response, error) {
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		msg := fmt.Sprintf("make HTTP request: %v", err)
-		log.Print(msg)
+		logger.ErrorContext(req.Context(), msg)
 		return nil, errors.New(msg)
 	}
 	return resp, nil
 }
 
-func CloseResponse(resp *http.Response) {
+func CloseResponse(logger *slog.Logger, resp *http.Response) {
 	if closeErr := resp.Body.Close(); closeErr != nil {
-		log.Printf("close response body: %v", closeErr)
+		logger.ErrorContext(resp.Request.Context(), "close response body", "error", closeErr)
 	}
 }
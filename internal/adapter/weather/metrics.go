@@ -0,0 +1,139 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	operationGetWeather = "get_weather"
+	operationCheckCity  = "check_city"
+	operationGetAlerts  = "get_alerts"
+)
+
+type ProviderMetrics struct {
+	requests  *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	fallbacks *prometheus.CounterVec
+}
+
+func NewProviderMetrics(reg prometheus.Registerer) *ProviderMetrics {
+	m := &ProviderMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "weather",
+			Name:      "provider_requests_total",
+			Help:      "Requests sent to a weather provider",
+		}, []string{"provider", "operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "weather",
+			Name:      "provider_errors_total",
+			Help:      "Failed weather provider requests by provider error code",
+		}, []string{"provider", "operation", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "weather",
+			Name:      "provider_request_duration_seconds",
+			Help:      "Duration of weather provider requests",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"provider", "operation"}),
+		fallbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "weather",
+			Name:      "provider_fallbacks_total",
+			Help:      "Times the provider chain moved past a failed provider to the next one",
+		}, []string{"provider", "operation"}),
+	}
+	reg.MustRegister(m.requests, m.errors, m.duration, m.fallbacks)
+	return m
+}
+
+func (m *ProviderMetrics) observe(provider, operation string, start time.Time, err error) {
+	m.requests.WithLabelValues(provider, operation).Inc()
+	m.duration.WithLabelValues(provider, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(provider, operation, errorCode(err)).Inc()
+	}
+}
+
+func (m *ProviderMetrics) fallback(provider, operation string) {
+	m.fallbacks.WithLabelValues(provider, operation).Inc()
+}
+
+// errorCode keeps the code label bounded: provider codes are a fixed set per
+// provider and everything else is folded into a handful of buckets.
+func errorCode(err error) string {
+	var providerErr *ProviderError
+	switch {
+	case errors.As(err, &providerErr):
+		return strconv.Itoa(providerErr.Code)
+	case errors.Is(err, ErrQuotaExhausted):
+		return "quota_exhausted"
+	case errors.Is(err, domain.ErrCityNotFound):
+		return "city_not_found"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	return "unknown"
+}
+
+// ProviderWithMetrics records requests, errors and latency of a single provider.
+type ProviderWithMetrics struct {
+	provider out.WeatherProvider
+	metrics  *ProviderMetrics
+}
+
+func NewProviderWithMetrics(provider out.WeatherProvider, metrics *ProviderMetrics) *ProviderWithMetrics {
+	return &ProviderWithMetrics{
+		provider: provider,
+		metrics:  metrics,
+	}
+}
+
+func (p *ProviderWithMetrics) Name() string {
+	return p.provider.Name()
+}
+
+func (p *ProviderWithMetrics) GetWeather(ctx context.Context, city string) (domain.Weather, error) {
+	start := time.Now()
+	weather, err := p.provider.GetWeather(ctx, city)
+	p.metrics.observe(p.provider.Name(), operationGetWeather, start, err)
+	return weather, err
+}
+
+func (p *ProviderWithMetrics) CheckCityExists(ctx context.Context, city string) error {
+	start := time.Now()
+	err := p.provider.CheckCityExists(ctx, city)
+	p.metrics.observe(p.provider.Name(), operationCheckCity, start, err)
+	return err
+}
+
+// AlertProviderWithMetrics records requests, errors and latency of alert lookups.
+type AlertProviderWithMetrics struct {
+	provider AlertProvider
+	metrics  *ProviderMetrics
+}
+
+func NewAlertProviderWithMetrics(provider AlertProvider, metrics *ProviderMetrics) *AlertProviderWithMetrics {
+	return &AlertProviderWithMetrics{
+		provider: provider,
+		metrics:  metrics,
+	}
+}
+
+func (p *AlertProviderWithMetrics) Name() string {
+	return p.provider.Name()
+}
+
+func (p *AlertProviderWithMetrics) GetAlerts(ctx context.Context, city string) ([]domain.Alert, error) {
+	start := time.Now()
+	alerts, err := p.provider.GetAlerts(ctx, city)
+	p.metrics.observe(p.provider.Name(), operationGetAlerts, start, err)
+	return alerts, err
+}
@@ -1,7 +1,7 @@
 package openweathermap
 
 import (
-	"log"
+	"context"
 	"weather-api/internal/adapter/weather"
 )
 
@@ -29,12 +29,12 @@ var openWeatherMapErrors = map[int]OpenWeatherErrorInfo{
 	GatewayTimeout:     {Message: "Weather service temporarily unavailable"},
 }
 
-func (c *Client) mapError(code int, message string) error {
+func (c *Client) mapError(ctx context.Context, code int, message string) error {
 	if errorInfo, exists := openWeatherMapErrors[code]; exists {
-		log.Printf("OpenWeatherMap error - Code: %d, Original: %s", code, message)
+		c.logger.WarnContext(ctx, "provider error", "provider", c.Name(), "code", code, "message", message)
 		return weather.NewProviderError(c.Name(), code, errorInfo.Message)
 	}
 
-	log.Printf("Unknown OpenWeatherMap error - Code: %d, Message: %s", code, message)
+	c.logger.WarnContext(ctx, "unknown provider error", "provider", c.Name(), "code", code, "message", message)
 	return weather.NewProviderError(c.Name(), code, "Weather service temporarily unavailable")
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"weather-api/internal/adapter/weather"
@@ -13,25 +14,33 @@ import (
 const weatherEndpoint = "/weather"
 
 type Client struct {
-	apiKey     string
-	baseURL    string
-	httpClient weather.HTTPDoer
-	logger     weather.ProviderLogger
+	apiKey         string
+	baseURL        string
+	httpClient     weather.HTTPDoer
+	responseLogger weather.ProviderLogger
+	logger         *slog.Logger
 }
 
 type ClientOptions struct {
-	APIKey     string
-	BaseURL    string
-	HTTPClient weather.HTTPDoer
-	Logger     weather.ProviderLogger
+	APIKey         string
+	BaseURL        string
+	HTTPClient     weather.HTTPDoer
+	ResponseLogger weather.ProviderLogger
+	// Logger defaults to slog.Default when nil.
+	Logger *slog.Logger
 }
 
 func NewClient(opts ClientOptions) *Client {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &Client{
-		apiKey:     opts.APIKey,
-		baseURL:    opts.BaseURL,
-		httpClient: opts.HTTPClient,
-		logger:     opts.Logger,
+		apiKey:         opts.APIKey,
+		baseURL:        opts.BaseURL,
+		httpClient:     opts.HTTPClient,
+		responseLogger: opts.ResponseLogger,
+		logger:         logger,
 	}
 }
 
@@ -45,21 +54,21 @@ func (c *Client) GetWeather(ctx context.Context, city string) (domain.Weather, e
 		return domain.Weather{}, err
 	}
 
-	resp, err := weather.ExecuteRequest(c.httpClient, req)
+	resp, err := weather.ExecuteRequest(c.httpClient, c.logger, req)
 	if err != nil {
 		return domain.Weather{}, err
 	}
-	defer weather.CloseResponse(resp)
+	defer weather.CloseResponse(c.logger, resp)
 
 	weatherResp, responseBytes, err := weather.DecodeResponse[Response](resp)
 	if err != nil {
 		return domain.Weather{}, err
 	}
 
-	c.logger.Log(c.Name(), responseBytes)
+	c.responseLogger.Log(c.Name(), responseBytes)
 
 	if code, ok := weatherResp.Cod.(float64); ok && code != 200 {
-		return domain.Weather{}, c.mapError(int(code), weatherResp.Message)
+		return domain.Weather{}, c.mapError(ctx, int(code), weatherResp.Message)
 	}
 
 	return convertToDomain(weatherResp), nil
@@ -71,14 +80,14 @@ func (c *Client) CheckCityExists(ctx context.Context, city string) error {
 		return err
 	}
 
-	resp, err := weather.ExecuteRequest(c.httpClient, req)
+	resp, err := weather.ExecuteRequest(c.httpClient, c.logger, req)
 	if err != nil {
 		return err
 	}
-	defer weather.CloseResponse(resp)
+	defer weather.CloseResponse(c.logger, resp)
 
 	if resp.StatusCode == http.StatusNotFound {
-		return c.mapError(404, "city not found")
+		return c.mapError(ctx, 404, "city not found")
 	}
 
 	return nil
@@ -89,7 +98,7 @@ func (c *Client) createRequest(ctx context.Context, city string) (*http.Request,
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		msg := fmt.Sprintf("creating HTTP request for city %s: %v", city, err)
-		c.logger.Log(c.Name(), []byte(msg))
+		c.responseLogger.Log(c.Name(), []byte(msg))
 		return nil, errors.New(msg)
 	}
 	return req, nil
@@ -2,7 +2,7 @@ package weather
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 )
@@ -12,13 +12,20 @@ type Cache interface {
 	Set(ctx context.Context, city string, weather domain.Weather) error
 	Close() error
 }
+
+type AlertCache interface {
+	GetAlerts(ctx context.Context, city string) ([]domain.Alert, bool, error)
+	SetAlerts(ctx context.Context, city string, alerts []domain.Alert) error
+}
+
 type CachedWeatherProvider struct {
 	cache    Cache
 	upstream out.WeatherProvider
+	logger   *slog.Logger
 }
 
-func NewCachedWeatherProvider(cache Cache, upstream out.WeatherProvider) *CachedWeatherProvider {
-	return &CachedWeatherProvider{cache: cache, upstream: upstream}
+func NewCachedWeatherProvider(cache Cache, upstream out.WeatherProvider, logger *slog.Logger) *CachedWeatherProvider {
+	return &CachedWeatherProvider{cache: cache, upstream: upstream, logger: logger}
 }
 
 func (c *CachedWeatherProvider) GetWeather(ctx context.Context, city string) (domain.Weather, error) {
@@ -30,7 +37,7 @@ func (c *CachedWeatherProvider) GetWeather(ctx context.Context, city string) (do
 		return domain.Weather{}, err
 	}
 	if err := c.cache.Set(ctx, city, data); err != nil {
-		log.Printf("cache weather for city %q: %v", city, err)
+		c.logger.WarnContext(ctx, "unable to cache weather", "city", city, "error", err)
 	}
 	return data, nil
 }
@@ -42,3 +49,29 @@ func (c *CachedWeatherProvider) CheckCityExists(ctx context.Context, city string
 func (c *CachedWeatherProvider) Name() string {
 	return c.upstream.Name()
 }
+
+// CachedAlertProvider serves alert lookups from the cache so repeated
+// requests for a city don't each cost a provider call.
+type CachedAlertProvider struct {
+	cache    AlertCache
+	upstream out.AlertProvider
+	logger   *slog.Logger
+}
+
+func NewCachedAlertProvider(cache AlertCache, upstream out.AlertProvider, logger *slog.Logger) *CachedAlertProvider {
+	return &CachedAlertProvider{cache: cache, upstream: upstream, logger: logger}
+}
+
+func (c *CachedAlertProvider) GetAlerts(ctx context.Context, city string) ([]domain.Alert, error) {
+	if cached, found, err := c.cache.GetAlerts(ctx, city); err == nil && found {
+		return cached, nil
+	}
+	alerts, err := c.upstream.GetAlerts(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cache.SetAlerts(ctx, city, alerts); err != nil {
+		c.logger.WarnContext(ctx, "unable to cache alerts", "city", city, "error", err)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,38 @@
+//go:build unit
+// +build unit
+
+package weather
+
+import (
+	"context"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryAlertCache map[string][]domain.Alert
+
+func (m memoryAlertCache) GetAlerts(_ context.Context, city string) ([]domain.Alert, bool, error) {
+	alerts, found := m[city]
+	return alerts, found, nil
+}
+
+func (m memoryAlertCache) SetAlerts(_ context.Context, city string, alerts []domain.Alert) error {
+	m[city] = alerts
+	return nil
+}
+
+func TestCachedAlertProvider_CachesEmptyResults(t *testing.T) {
+	upstream := &alertProvider{}
+	provider := NewCachedAlertProvider(memoryAlertCache{}, upstream, logger.Discard())
+
+	for i := 0; i < 3; i++ {
+		alerts, err := provider.GetAlerts(context.Background(), "Kyiv")
+		require.NoError(t, err)
+		assert.Empty(t, alerts)
+	}
+	assert.Equal(t, 1, upstream.calls)
+}
@@ -0,0 +1,214 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrQuotaExhausted is returned instead of calling a provider that is past
+// its quota cutoff, so the chain falls back to the next provider.
+var ErrQuotaExhausted = errors.New("provider monthly quota exhausted")
+
+type QuotaTrackerOptions struct {
+	// Limits maps provider names to calls allowed per calendar month (UTC).
+	// Providers without a positive limit are counted but never skipped.
+	Limits map[string]int64
+	// Cutoff is the share of the limit after which a provider is skipped,
+	// leaving the rest as headroom for concurrent calls and other clients.
+	Cutoff float64
+	// Logger defaults to slog.Default when nil.
+	Logger *slog.Logger
+}
+
+type monthlyUsage struct {
+	month time.Time
+	used  int64
+}
+
+// QuotaTracker counts provider calls per month in the usage repository and
+// keeps the current month's totals in memory.
+type QuotaTracker struct {
+	usage     out.ProviderUsageRepository
+	limits    map[string]int64
+	cutoff    float64
+	used      *prometheus.GaugeVec
+	remaining *prometheus.GaugeVec
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu     sync.Mutex
+	counts map[string]monthlyUsage
+}
+
+func NewQuotaTracker(usage out.ProviderUsageRepository, reg prometheus.Registerer, opts QuotaTrackerOptions) *QuotaTracker {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	t := &QuotaTracker{
+		usage:  usage,
+		limits: opts.Limits,
+		cutoff: opts.Cutoff,
+		used: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "weather",
+			Name:      "provider_quota_used",
+			Help:      "Provider calls made in the current month",
+		}, []string{"provider"}),
+		remaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "weather",
+			Name:      "provider_quota_remaining",
+			Help:      "Provider calls left in the current monthly quota",
+		}, []string{"provider"}),
+		logger: logger,
+		now:    time.Now,
+		counts: make(map[string]monthlyUsage),
+	}
+	reg.MustRegister(t.used, t.remaining)
+	return t
+}
+
+// Allow reports whether provider is still below its quota cutoff. When the
+// stored usage cannot be read the provider is allowed rather than taken out
+// of rotation.
+func (t *QuotaTracker) Allow(ctx context.Context, provider string) bool {
+	limit := t.limits[provider]
+	if limit <= 0 {
+		return true
+	}
+	used, err := t.currentUsage(ctx, provider)
+	if err != nil {
+		t.logger.WarnContext(ctx, "unable to read provider usage, allowing call", "provider", provider, "error", err)
+		return true
+	}
+	return float64(used) < float64(limit)*t.cutoff
+}
+
+// Record counts one call to provider in the current month.
+func (t *QuotaTracker) Record(ctx context.Context, provider string) {
+	month := startOfMonth(t.now())
+	// The call has already been made, so count it even if the request that
+	// triggered it was canceled meanwhile.
+	used, err := t.usage.IncrementUsage(context.WithoutCancel(ctx), provider, month)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		t.logger.WarnContext(ctx, "unable to persist provider usage", "provider", provider, "error", err)
+		used = 1
+		if count := t.counts[provider]; count.month.Equal(month) {
+			used = count.used + 1
+		}
+	}
+	t.store(provider, month, used)
+}
+
+func (t *QuotaTracker) currentUsage(ctx context.Context, provider string) (int64, error) {
+	month := startOfMonth(t.now())
+
+	t.mu.Lock()
+	count, ok := t.counts[provider]
+	t.mu.Unlock()
+	if ok && count.month.Equal(month) {
+		return count.used, nil
+	}
+
+	used, err := t.usage.GetUsage(ctx, provider, month)
+	if err != nil {
+		return 0, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if count, ok := t.counts[provider]; ok && count.month.Equal(month) && count.used > used {
+		used = count.used
+	}
+	t.store(provider, month, used)
+	return used, nil
+}
+
+// store must be called with mu held.
+func (t *QuotaTracker) store(provider string, month time.Time, used int64) {
+	t.counts[provider] = monthlyUsage{month: month, used: used}
+	t.used.WithLabelValues(provider).Set(float64(used))
+	if limit := t.limits[provider]; limit > 0 {
+		t.remaining.WithLabelValues(provider).Set(float64(max(limit-used, 0)))
+	}
+}
+
+func startOfMonth(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// QuotaLimitedProvider skips a provider once its monthly quota reaches the
+// tracker's cutoff and counts every call it lets through.
+type QuotaLimitedProvider struct {
+	provider out.WeatherProvider
+	tracker  *QuotaTracker
+}
+
+func NewQuotaLimitedProvider(provider out.WeatherProvider, tracker *QuotaTracker) *QuotaLimitedProvider {
+	return &QuotaLimitedProvider{
+		provider: provider,
+		tracker:  tracker,
+	}
+}
+
+func (p *QuotaLimitedProvider) Name() string {
+	return p.provider.Name()
+}
+
+func (p *QuotaLimitedProvider) GetWeather(ctx context.Context, city string) (domain.Weather, error) {
+	if !p.tracker.Allow(ctx, p.provider.Name()) {
+		return domain.Weather{}, ErrQuotaExhausted
+	}
+	defer p.tracker.Record(ctx, p.provider.Name())
+	return p.provider.GetWeather(ctx, city)
+}
+
+func (p *QuotaLimitedProvider) CheckCityExists(ctx context.Context, city string) error {
+	if !p.tracker.Allow(ctx, p.provider.Name()) {
+		return ErrQuotaExhausted
+	}
+	defer p.tracker.Record(ctx, p.provider.Name())
+	return p.provider.CheckCityExists(ctx, city)
+}
+
+// AlertProvider is an alert source billed under the quota of the provider
+// with the same name.
+type AlertProvider interface {
+	Name() string
+	out.AlertProvider
+}
+
+// QuotaLimitedAlertProvider applies the tracker's cutoff to alert lookups.
+type QuotaLimitedAlertProvider struct {
+	provider AlertProvider
+	tracker  *QuotaTracker
+}
+
+func NewQuotaLimitedAlertProvider(provider AlertProvider, tracker *QuotaTracker) *QuotaLimitedAlertProvider {
+	return &QuotaLimitedAlertProvider{
+		provider: provider,
+		tracker:  tracker,
+	}
+}
+
+func (p *QuotaLimitedAlertProvider) Name() string {
+	return p.provider.Name()
+}
+
+func (p *QuotaLimitedAlertProvider) GetAlerts(ctx context.Context, city string) ([]domain.Alert, error) {
+	if !p.tracker.Allow(ctx, p.provider.Name()) {
+		return nil, ErrQuotaExhausted
+	}
+	defer p.tracker.Record(ctx, p.provider.Name())
+	return p.provider.GetAlerts(ctx, city)
+}
@@ -0,0 +1,153 @@
+//go:build unit
+// +build unit
+
+package weather
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/util/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryUsage struct {
+	mu     sync.Mutex
+	counts map[string]int64
+	err    error
+}
+
+func newMemoryUsage() *memoryUsage {
+	return &memoryUsage{counts: make(map[string]int64)}
+}
+
+func (m *memoryUsage) key(provider string, month time.Time) string {
+	return provider + "/" + month.Format("2006-01")
+}
+
+func (m *memoryUsage) IncrementUsage(_ context.Context, provider string, month time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.counts[m.key(provider, month)]++
+	return m.counts[m.key(provider, month)], nil
+}
+
+func (m *memoryUsage) GetUsage(_ context.Context, provider string, month time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.counts[m.key(provider, month)], nil
+}
+
+func newTestTracker(usage *memoryUsage, limit int64) (*QuotaTracker, *prometheus.Registry) {
+	reg := prometheus.NewRegistry()
+	tracker := NewQuotaTracker(usage, reg, QuotaTrackerOptions{
+		Limits: map[string]int64{"MockSuccessfulProvider": limit},
+		Cutoff: 0.8,
+		Logger: logger.Discard(),
+	})
+	tracker.now = func() time.Time { return time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC) }
+	return tracker, reg
+}
+
+func TestQuotaLimitedProvider_StopsAtCutoff(t *testing.T) {
+	tracker, _ := newTestTracker(newMemoryUsage(), 10)
+	provider := NewQuotaLimitedProvider(&MockSuccessfulProvider{}, tracker)
+
+	for i := 0; i < 8; i++ {
+		_, err := provider.GetWeather(context.Background(), "Kyiv")
+		require.NoError(t, err)
+	}
+
+	_, err := provider.GetWeather(context.Background(), "Kyiv")
+	assert.ErrorIs(t, err, ErrQuotaExhausted)
+	assert.Equal(t, 2.0, testutil.ToFloat64(tracker.remaining.WithLabelValues("MockSuccessfulProvider")))
+	assert.Equal(t, 8.0, testutil.ToFloat64(tracker.used.WithLabelValues("MockSuccessfulProvider")))
+}
+
+type alertProvider struct{ calls int }
+
+func (p *alertProvider) Name() string { return "MockSuccessfulProvider" }
+
+func (p *alertProvider) GetAlerts(context.Context, string) ([]domain.Alert, error) {
+	p.calls++
+	return nil, nil
+}
+
+func TestQuotaLimitedAlertProvider_SharesTheProviderQuota(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := NewProviderMetrics(reg)
+	tracker, _ := newTestTracker(newMemoryUsage(), 10)
+	weather := NewQuotaLimitedProvider(&MockSuccessfulProvider{}, tracker)
+	inner := &alertProvider{}
+	alerts := NewAlertProviderWithMetrics(NewQuotaLimitedAlertProvider(inner, tracker), metrics)
+
+	for i := 0; i < 7; i++ {
+		_, err := weather.GetWeather(context.Background(), "Kyiv")
+		require.NoError(t, err)
+	}
+	_, err := alerts.GetAlerts(context.Background(), "Kyiv")
+	require.NoError(t, err)
+
+	_, err = alerts.GetAlerts(context.Background(), "Kyiv")
+	assert.ErrorIs(t, err, ErrQuotaExhausted)
+	assert.Equal(t, 1, inner.calls)
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.requests.WithLabelValues("MockSuccessfulProvider", operationGetAlerts)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("MockSuccessfulProvider", operationGetAlerts, "quota_exhausted")))
+}
+
+func TestQuotaTracker_LoadsPersistedUsageAndResetsMonthly(t *testing.T) {
+	usage := newMemoryUsage()
+	october := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	usage.counts[usage.key("MockSuccessfulProvider", october)] = 8
+
+	tracker, _ := newTestTracker(usage, 10)
+	assert.False(t, tracker.Allow(context.Background(), "MockSuccessfulProvider"))
+
+	tracker.now = func() time.Time { return time.Date(2026, time.November, 1, 0, 0, 1, 0, time.UTC) }
+	assert.True(t, tracker.Allow(context.Background(), "MockSuccessfulProvider"))
+}
+
+func TestQuotaTracker_KeepsCountingWhenStorageFails(t *testing.T) {
+	usage := newMemoryUsage()
+	usage.err = errors.New("db down")
+	tracker, _ := newTestTracker(usage, 10)
+
+	assert.True(t, tracker.Allow(context.Background(), "MockSuccessfulProvider"))
+	tracker.Record(context.Background(), "MockSuccessfulProvider")
+	tracker.Record(context.Background(), "MockSuccessfulProvider")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(tracker.used.WithLabelValues("MockSuccessfulProvider")))
+}
+
+func TestProviderWithMetrics_CountsErrorsAndFallbacks(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := NewProviderMetrics(reg)
+	tracker, _ := newTestTracker(newMemoryUsage(), 1)
+	tracker.Record(context.Background(), "MockSuccessfulProvider")
+
+	failing := NewProviderWithMetrics(&MockFailingProvider{}, metrics)
+	exhausted := NewProviderWithMetrics(NewQuotaLimitedProvider(&MockSuccessfulProvider{}, tracker), metrics)
+	successful := NewProviderWithMetrics(&MockSuccessfulProvider{}, metrics)
+
+	chain := NewChainWeatherProvider(logger.Discard(), failing, exhausted, successful).WithMetrics(metrics)
+	_, err := chain.GetWeather(context.Background(), "Kyiv")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("MockFailingProvider", operationGetWeather, "unknown")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("MockSuccessfulProvider", operationGetWeather, "quota_exhausted")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.fallbacks.WithLabelValues("MockFailingProvider", operationGetWeather)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.requests.WithLabelValues("MockSuccessfulProvider", operationGetWeather)))
+}
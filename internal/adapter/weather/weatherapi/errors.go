@@ -1,7 +1,7 @@
 package weatherapi
 
 import (
-	"log"
+	"context"
 	"weather-api/internal/adapter/weather"
 )
 
@@ -37,16 +37,16 @@ var weatherAPIErrors = map[int]ErrorInfo{
 	InternalError:       {Message: "Weather service temporarily unavailable"},
 }
 
-func (c *Client) mapError(code int, message string) error {
+func (c *Client) mapError(ctx context.Context, code int, message string) error {
 	if code == 0 {
 		return nil
 	}
 
 	if errorInfo, exists := weatherAPIErrors[code]; exists {
-		log.Printf("WeatherAPI error - Code: %d, Original: %s", code, message)
+		c.logger.WarnContext(ctx, "provider error", "provider", c.Name(), "code", code, "message", message)
 		return weather.NewProviderError(c.Name(), code, errorInfo.Message)
 	}
 
-	log.Printf("Unknown WeatherAPI error - Code: %d, Message: %s", code, message)
+	c.logger.WarnContext(ctx, "unknown provider error", "provider", c.Name(), "code", code, "message", message)
 	return weather.NewProviderError(c.Name(), code, "Weather service temporarily unavailable")
 }
@@ -19,3 +19,25 @@ type currentEnvelope struct {
 type searchItem struct {
 	Name string `json:"name"`
 }
+
+type alertItem struct {
+	Headline    string `json:"headline"`
+	Severity    string `json:"severity"`
+	Urgency     string `json:"urgency"`
+	Areas       string `json:"areas"`
+	Event       string `json:"event"`
+	Effective   string `json:"effective"`
+	Expires     string `json:"expires"`
+	Desc        string `json:"desc"`
+	Instruction string `json:"instruction"`
+}
+
+type forecastEnvelope struct {
+	Alerts struct {
+		Alert []alertItem `json:"alert"`
+	} `json:"alerts"`
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
@@ -4,38 +4,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"time"
 	"weather-api/internal/adapter/weather"
 	"weather-api/internal/core/domain"
 )
 
 const (
-	weatherEndpoint = "/current.json"
-	searchEndpoint  = "/search.json"
+	weatherEndpoint  = "/current.json"
+	searchEndpoint   = "/search.json"
+	forecastEndpoint = "/forecast.json"
 )
 
 type Client struct {
-	apiKey     string
-	baseURL    string
-	httpClient weather.HTTPDoer
-	logger     weather.ProviderLogger
+	apiKey         string
+	baseURL        string
+	httpClient     weather.HTTPDoer
+	responseLogger weather.ProviderLogger
+	logger         *slog.Logger
 }
 
 type ClientOptions struct {
-	APIKey     string
-	BaseURL    string
-	HTTPClient weather.HTTPDoer
-	Logger     weather.ProviderLogger
+	APIKey         string
+	BaseURL        string
+	HTTPClient     weather.HTTPDoer
+	ResponseLogger weather.ProviderLogger
+	// Logger defaults to slog.Default when nil.
+	Logger *slog.Logger
 }
 
 func NewClient(opts ClientOptions) *Client {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &Client{
-		apiKey:     opts.APIKey,
-		baseURL:    opts.BaseURL,
-		httpClient: opts.HTTPClient,
-		logger:     opts.Logger,
+		apiKey:         opts.APIKey,
+		baseURL:        opts.BaseURL,
+		httpClient:     opts.HTTPClient,
+		responseLogger: opts.ResponseLogger,
+		logger:         logger,
 	}
 }
 
@@ -47,6 +57,28 @@ func apiToDomain(w response) domain.Weather {
 	}
 }
 
+func alertToDomain(a alertItem) domain.Alert {
+	return domain.Alert{
+		Headline:    a.Headline,
+		Event:       a.Event,
+		Severity:    a.Severity,
+		Urgency:     a.Urgency,
+		Areas:       a.Areas,
+		Description: a.Desc,
+		Instruction: a.Instruction,
+		Effective:   parseAlertTime(a.Effective),
+		Expires:     parseAlertTime(a.Expires),
+	}
+}
+
+func parseAlertTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 func (c *Client) Name() string {
 	return "WeatherAPI"
 }
@@ -57,63 +89,96 @@ func (c *Client) GetWeather(ctx context.Context, city string) (domain.Weather, e
 		return domain.Weather{}, err
 	}
 
-	resp, err := weather.ExecuteRequest(c.httpClient, req)
+	resp, err := weather.ExecuteRequest(c.httpClient, c.logger, req)
 	if err != nil {
 		return domain.Weather{}, err
 	}
-	defer weather.CloseResponse(resp)
+	defer weather.CloseResponse(c.logger, resp)
 
 	env, responseBytes, err := weather.DecodeResponse[currentEnvelope](resp)
 	if err != nil {
 		return domain.Weather{}, err
 	}
 
-	c.logger.Log(c.Name(), responseBytes)
+	c.responseLogger.Log(c.Name(), responseBytes)
 
 	if env.Error.Code != 0 {
-		log.Printf("API Error detected: %v", env.Error)
-		return domain.Weather{}, c.mapError(env.Error.Code, env.Error.Message)
+		return domain.Weather{}, c.mapError(ctx, env.Error.Code, env.Error.Message)
 	}
 
 	return apiToDomain(env.Current), nil
 }
 
 func (c *Client) CheckCityExists(ctx context.Context, city string) error {
-	log.Printf("Checking if city exists: %s", city)
+	c.logger.DebugContext(ctx, "checking city", "provider", c.Name(), "city", city)
 
 	req, err := c.createRequest(ctx, city, searchEndpoint)
 	if err != nil {
 		return err
 	}
 
-	resp, err := weather.ExecuteRequest(c.httpClient, req)
+	resp, err := weather.ExecuteRequest(c.httpClient, c.logger, req)
 	if err != nil {
 		return err
 	}
-	defer weather.CloseResponse(resp)
+	defer weather.CloseResponse(c.logger, resp)
 
 	results, responseBytes, err := weather.DecodeResponse[[]searchItem](resp)
 	if err != nil {
 		return err
 	}
 
-	c.logger.Log(c.Name(), responseBytes)
+	c.responseLogger.Log(c.Name(), responseBytes)
 
 	if len(*results) == 0 {
-		log.Printf("City not found in WeatherAPI database")
-		return c.mapError(404, "city not found")
+		c.logger.DebugContext(ctx, "city not found", "provider", c.Name(), "city", city)
+		return c.mapError(ctx, 404, "city not found")
 	}
 
-	log.Printf("City %s exists in WeatherAPI database", city)
+	c.logger.DebugContext(ctx, "city exists", "provider", c.Name(), "city", city)
 	return nil
 }
 
+func (c *Client) GetAlerts(ctx context.Context, city string) ([]domain.Alert, error) {
+	req, err := c.createRequest(ctx, city, forecastEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("days", "1")
+	q.Set("alerts", "yes")
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := weather.ExecuteRequest(c.httpClient, c.logger, req)
+	if err != nil {
+		return nil, err
+	}
+	defer weather.CloseResponse(c.logger, resp)
+
+	env, responseBytes, err := weather.DecodeResponse[forecastEnvelope](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	c.responseLogger.Log(c.Name(), responseBytes)
+
+	if env.Error.Code != 0 {
+		return nil, c.mapError(ctx, env.Error.Code, env.Error.Message)
+	}
+
+	alerts := make([]domain.Alert, 0, len(env.Alerts.Alert))
+	for _, a := range env.Alerts.Alert {
+		alerts = append(alerts, alertToDomain(a))
+	}
+	return alerts, nil
+}
+
 func (c *Client) createRequest(ctx context.Context, city, endpoint string) (*http.Request, error) {
 	requestURL := fmt.Sprintf("%s%s?key=%s&q=%s", c.baseURL, endpoint, c.apiKey, url.QueryEscape(city))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		msg := fmt.Sprintf("creating HTTP request for city %s: %v", city, err)
-		c.logger.Log(c.Name(), []byte(msg))
+		c.responseLogger.Log(c.Name(), []byte(msg))
 		return nil, errors.New(msg)
 	}
 	return req, nil
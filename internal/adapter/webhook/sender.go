@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"weather-api/internal/core/domain"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	IDHeader        = "X-Webhook-Id"
+
+	maxErrorBodySize = 512
+)
+
+// HTTPSender posts signed JSON weather updates and alerts to subscriber webhooks.
+type HTTPSender struct {
+	httpClient *http.Client
+}
+
+type payload struct {
+	ID             string          `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	City           string          `json:"city"`
+	Frequency      string          `json:"frequency"`
+	Weather        *weatherPayload `json:"weather,omitempty"`
+	Overnight      *overnightValue `json:"overnight,omitempty"`
+	Alert          *alertPayload   `json:"alert,omitempty"`
+	SentAt         time.Time       `json:"sent_at"`
+}
+
+type weatherPayload struct {
+	Temperature float64 `json:"temperature"`
+	Humidity    int     `json:"humidity"`
+	Description string  `json:"description"`
+}
+
+type alertPayload struct {
+	Headline    string     `json:"headline"`
+	Event       string     `json:"event"`
+	Severity    string     `json:"severity"`
+	Areas       string     `json:"areas,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Instruction string     `json:"instruction,omitempty"`
+	Effective   *time.Time `json:"effective,omitempty"`
+	Expires     *time.Time `json:"expires,omitempty"`
+}
+
+type overnightValue struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	MinTemperature float64   `json:"min_temperature"`
+	MaxTemperature float64   `json:"max_temperature"`
+	Descriptions   []string  `json:"descriptions"`
+}
+
+func NewHTTPSender(httpClient *http.Client) *HTTPSender {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPSender{httpClient: httpClient}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, webhook domain.Webhook, message domain.WebhookMessage) (int, error) {
+	body, err := json.Marshal(newPayload(message))
+	if err != nil {
+		return 0, fmt.Errorf("unable to encode webhook message %s: %w", message.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("unable to create webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(message.SentAt.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, message.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(webhook.Secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("unable to post webhook message %s: %w", message.ID, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return resp.StatusCode, fmt.Errorf("webhook responded with %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.body" keyed with secret.
+// Receivers recompute it to verify the X-Webhook-Signature header.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newPayload(message domain.WebhookMessage) payload {
+	update := message.Update
+	p := payload{
+		ID:             message.ID,
+		SubscriptionID: update.Subscription.ID,
+		Frequency:      string(update.Subscription.Frequency),
+		SentAt:         message.SentAt.UTC(),
+	}
+	if update.Subscription.City != nil {
+		p.City = update.Subscription.City.Name
+	}
+	if alert := message.Alert; alert != nil {
+		p.Alert = &alertPayload{
+			Headline:    alert.Headline,
+			Event:       alert.Event,
+			Severity:    alert.Severity,
+			Areas:       alert.Areas,
+			Description: alert.Description,
+			Instruction: alert.Instruction,
+			Effective:   utcTime(alert.Effective),
+			Expires:     utcTime(alert.Expires),
+		}
+		return p
+	}
+	p.Weather = &weatherPayload{
+		Temperature: update.Weather.Temperature,
+		Humidity:    update.Weather.Humidity,
+		Description: update.Weather.Description,
+	}
+	if update.Overnight != nil {
+		p.Overnight = &overnightValue{
+			From:           update.Overnight.From.UTC(),
+			To:             update.Overnight.To.UTC(),
+			MinTemperature: update.Overnight.MinTemperature,
+			MaxTemperature: update.Overnight.MaxTemperature,
+			Descriptions:   update.Overnight.Descriptions,
+		}
+	}
+	return p
+}
+
+func utcTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
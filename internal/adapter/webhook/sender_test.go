@@ -0,0 +1,95 @@
+//go:build unit
+// +build unit
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_SignsPayload(t *testing.T) {
+	secret := "0123456789abcdef"
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	message := domain.WebhookMessage{
+		ID: "7-1",
+		Update: domain.WeatherUpdate{
+			Subscription: domain.Subscription{ID: 7, City: &domain.City{Name: "Kyiv"}, Frequency: domain.FrequencyDaily},
+			Weather:      domain.Weather{Temperature: 21.5, Humidity: 40, Description: "Sunny"},
+		},
+		SentAt: time.Unix(1700000000, 0),
+	}
+
+	status, err := NewHTTPSender(server.Client()).Send(context.Background(), domain.Webhook{URL: server.URL, Secret: secret}, message)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, "7-1", received.Header.Get(IDHeader))
+	assert.Equal(t, "1700000000", received.Header.Get(TimestampHeader))
+	assert.Equal(t, "sha256="+Sign(secret, "1700000000", body), received.Header.Get(SignatureHeader))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "Kyiv", decoded["city"])
+	assert.Equal(t, "daily", decoded["frequency"])
+	assert.Equal(t, "Sunny", decoded["weather"].(map[string]any)["description"])
+}
+
+func TestHTTPSender_SendsAlertWithoutWeather(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	message := domain.WebhookMessage{
+		ID:     "7-2",
+		Update: domain.WeatherUpdate{Subscription: domain.Subscription{ID: 7, City: &domain.City{Name: "Kyiv"}}},
+		Alert:  &domain.Alert{Headline: "Storm warning", Event: "Storm", Severity: "Severe"},
+		SentAt: time.Unix(1700000000, 0),
+	}
+
+	_, err := NewHTTPSender(server.Client()).Send(context.Background(), domain.Webhook{URL: server.URL, Secret: "secret"}, message)
+
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.NotContains(t, decoded, "weather")
+	assert.Equal(t, "Storm warning", decoded["alert"].(map[string]any)["headline"])
+	assert.NotContains(t, decoded["alert"], "expires")
+}
+
+func TestHTTPSender_ReturnsStatusOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusGone)
+	}))
+	defer server.Close()
+
+	status, err := NewHTTPSender(server.Client()).Send(context.Background(),
+		domain.Webhook{URL: server.URL, Secret: "secret"}, domain.WebhookMessage{ID: "1"})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusGone, status)
+}
+
+func TestSign_KnownVector(t *testing.T) {
+	assert.Equal(t, "1ba6b8171186efc613e8bcc0cbdab2748f24984d7c5a84faa2637afa0e40d224", Sign("key", "1", []byte("{}")))
+}
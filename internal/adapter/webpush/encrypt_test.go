@@ -0,0 +1,36 @@
+//go:build unit
+// +build unit
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Example from RFC 8291, Appendix A.
+func TestEncryptWith_RFC8291Example(t *testing.T) {
+	asPrivateBytes, err := decodeKey("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw")
+	require.NoError(t, err)
+	asPrivate, err := ecdh.P256().NewPrivateKey(asPrivateBytes)
+	require.NoError(t, err)
+	salt, err := decodeKey("DGv6ra1nlYgDCS1FRnbzlw")
+	require.NoError(t, err)
+
+	body, err := encryptWith(
+		[]byte("When I grow up, I want to be a watermelon"),
+		"BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		"BTBZMqHH6r4Tts7J_aSIgg",
+		asPrivate,
+		salt,
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		"DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN",
+		base64.RawURLEncoding.EncodeToString(body))
+}
@@ -0,0 +1,83 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"weather-api/internal/core/domain"
+)
+
+const maxErrorBodySize = 512
+
+type SenderOptions struct {
+	VAPIDPublicKey  string
+	VAPIDPrivateKey string
+	// Subject is the mailto: or https: contact push services may use.
+	Subject string
+	// TTL is how long the push service keeps an undelivered notification.
+	TTL        time.Duration
+	HTTPClient *http.Client
+}
+
+// Sender encrypts notifications and posts them to browser push services.
+type Sender struct {
+	vapid      *vapidSigner
+	ttl        time.Duration
+	httpClient *http.Client
+}
+
+func NewSender(opts SenderOptions) (*Sender, error) {
+	vapid, err := newVAPIDSigner(opts.VAPIDPublicKey, opts.VAPIDPrivateKey, opts.Subject)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Sender{vapid: vapid, ttl: opts.TTL, httpClient: httpClient}, nil
+}
+
+func (s *Sender) Send(ctx context.Context, subscription domain.PushSubscription, notification domain.PushNotification) (int, error) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return 0, fmt.Errorf("unable to encode push notification: %w", err)
+	}
+	body, err := encrypt(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return 0, fmt.Errorf("unable to encrypt push notification: %w", err)
+	}
+	authorization, err := s.vapid.authorization(subscription.Endpoint, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("unable to create push request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(s.ttl.Seconds())))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("unable to post push notification: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return resp.StatusCode, fmt.Errorf("push service responded with %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,170 @@
+//go:build unit
+// +build unit
+
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushService stands in for a browser push service: it holds the user agent
+// keys, verifies the VAPID token and decrypts what it receives.
+type pushService struct {
+	t            *testing.T
+	uaPrivate    *ecdh.PrivateKey
+	authSecret   []byte
+	vapidKey     string
+	status       int
+	notification domain.PushNotification
+	headers      http.Header
+}
+
+func newPushService(t *testing.T, vapidKey string, status int) (*pushService, *httptest.Server) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	service := &pushService{t: t, uaPrivate: uaPrivate, authSecret: authSecret, vapidKey: vapidKey, status: status}
+	server := httptest.NewServer(service)
+	t.Cleanup(server.Close)
+	return service, server
+}
+
+func (p *pushService) subscription(endpoint string) domain.PushSubscription {
+	return domain.PushSubscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(p.uaPrivate.PublicKey().Bytes()),
+		Auth:     base64.URLEncoding.EncodeToString(p.authSecret),
+	}
+}
+
+func (p *pushService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.headers = r.Header.Clone()
+	body, err := io.ReadAll(r.Body)
+	require.NoError(p.t, err)
+
+	p.verifyVAPID(r.Header.Get("Authorization"), "http://"+r.Host)
+	require.NoError(p.t, json.Unmarshal(p.decrypt(body), &p.notification))
+	w.WriteHeader(p.status)
+}
+
+func (p *pushService) verifyVAPID(authorization, audience string) {
+	token, key, ok := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	require.True(p.t, ok, authorization)
+	require.Equal(p.t, p.vapidKey, key)
+
+	parts := strings.Split(token, ".")
+	require.Len(p.t, parts, 3)
+	var claims map[string]any
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(p.t, json.Unmarshal(claimsJSON, &claims))
+	assert.Equal(p.t, audience, claims["aud"])
+	assert.Equal(p.t, "mailto:ops@example.com", claims["sub"])
+
+	publicBytes, _ := base64.RawURLEncoding.DecodeString(key)
+	publicKey := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(publicBytes[1:33]),
+		Y:     new(big.Int).SetBytes(publicBytes[33:]),
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.True(p.t, ecdsa.Verify(publicKey, digest[:],
+		new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])), "invalid VAPID signature")
+}
+
+func (p *pushService) decrypt(body []byte) []byte {
+	salt := body[:16]
+	require.Equal(p.t, uint32(recordSize), binary.BigEndian.Uint32(body[16:20]))
+	idLen := int(body[20])
+	asPublic, err := ecdh.P256().NewPublicKey(body[21 : 21+idLen])
+	require.NoError(p.t, err)
+	ciphertext := body[21+idLen:]
+
+	ecdhSecret, err := p.uaPrivate.ECDH(asPublic)
+	require.NoError(p.t, err)
+	keyInfo := "WebPush: info\x00" + string(p.uaPrivate.PublicKey().Bytes()) + string(asPublic.Bytes())
+	ikm, _ := hkdf.Key(sha256.New, ecdhSecret, p.authSecret, keyInfo, 32)
+	prk, _ := hkdf.Extract(sha256.New, ikm, salt)
+	cek, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	require.NoError(p.t, err)
+	require.Equal(p.t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func newTestSender(t *testing.T, publicKey, privateKey string) *Sender {
+	sender, err := NewSender(SenderOptions{
+		VAPIDPublicKey:  publicKey,
+		VAPIDPrivateKey: privateKey,
+		Subject:         "mailto:ops@example.com",
+		TTL:             time.Hour,
+	})
+	require.NoError(t, err)
+	return sender
+}
+
+func TestSender_EncryptsAndSigns(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	service, server := newPushService(t, publicKey, http.StatusCreated)
+	notification := domain.PushNotification{Title: "Weather in Kyiv", Body: "21.5°C, humidity 40%, Sunny"}
+
+	status, err := newTestSender(t, publicKey, privateKey).Send(context.Background(), service.subscription(server.URL+"/push/abc"), notification)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, notification, service.notification)
+	assert.Equal(t, "aes128gcm", service.headers.Get("Content-Encoding"))
+	assert.Equal(t, "3600", service.headers.Get("TTL"))
+}
+
+func TestSender_ReturnsStatusOfExpiredSubscription(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	service, server := newPushService(t, publicKey, http.StatusGone)
+
+	status, err := newTestSender(t, publicKey, privateKey).Send(context.Background(), service.subscription(server.URL), domain.PushNotification{Title: "x"})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusGone, status)
+}
+
+func TestNewSender_RejectsMismatchedKeys(t *testing.T) {
+	publicKey, _, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	_, otherPrivate, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+
+	_, err = NewSender(SenderOptions{VAPIDPublicKey: publicKey, VAPIDPrivateKey: otherPrivate})
+
+	assert.Error(t, err)
+}
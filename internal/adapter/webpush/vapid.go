@@ -0,0 +1,97 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// vapidTokenTTL is how long a VAPID JWT is valid; push services reject
+// tokens that expire more than 24 hours ahead.
+const vapidTokenTTL = 12 * time.Hour
+
+// GenerateVAPIDKeys returns a new application server key pair, base64url
+// encoded for VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY.
+func GenerateVAPIDKeys() (publicKey string, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+type vapidSigner struct {
+	publicKey  string
+	privateKey *ecdsa.PrivateKey
+	subject    string
+}
+
+func newVAPIDSigner(publicKey, privateKey, subject string) (*vapidSigner, error) {
+	privateBytes, err := decodeKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	publicBytes := key.PublicKey().Bytes()
+	if encoded := base64.RawURLEncoding.EncodeToString(publicBytes); encoded != strings.TrimRight(publicKey, "=") {
+		return nil, fmt.Errorf("VAPID public key does not match the private key")
+	}
+
+	return &vapidSigner{
+		publicKey: base64.RawURLEncoding.EncodeToString(publicBytes),
+		privateKey: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(publicBytes[1:33]),
+				Y:     new(big.Int).SetBytes(publicBytes[33:]),
+			},
+			D: new(big.Int).SetBytes(privateBytes),
+		},
+		subject: subject,
+	}, nil
+}
+
+// authorization returns the "vapid" Authorization header (RFC 8292) for a
+// request to endpoint.
+func (s *vapidSigner) authorization(endpoint string, now time.Time) (string, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]any{
+		"aud": parsed.Scheme + "://" + parsed.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": s.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privateKey, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("unable to sign VAPID token: %w", err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + token + ", k=" + s.publicKey, nil
+}
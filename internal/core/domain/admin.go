@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent       DeliveryStatus = "sent"
+	DeliveryStatusFailed     DeliveryStatus = "failed"
+	DeliveryStatusDeferred   DeliveryStatus = "deferred"
+	DeliveryStatusSuppressed DeliveryStatus = "suppressed"
+)
+
+type Delivery struct {
+	ID             int64
+	SubscriptionID int64
+	Status         DeliveryStatus
+	Error          string
+	CreatedAt      time.Time
+}
+
+type CityStats struct {
+	City          City
+	Subscribers   int
+	Subscriptions int
+}
+
+type AuditEntry struct {
+	ID        int64
+	Actor     string
+	Action    string
+	Target    string
+	Details   string
+	CreatedAt time.Time
+}
+
+// DryRunSummary describes what a send would have delivered without delivering it.
+type DryRunSummary struct {
+	Frequency  Frequency
+	Emails     int
+	Updates    int
+	Failed     int
+	Recipients []string
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	AlertSeverityExtreme = "Extreme"
+	AlertSeveritySevere  = "Severe"
+)
+
+type Alert struct {
+	ID          int64
+	CityID      int64
+	Headline    string
+	Event       string
+	Severity    string
+	Urgency     string
+	Areas       string
+	Description string
+	Instruction string
+	Effective   time.Time
+	Expires     time.Time
+	CreatedAt   time.Time
+}
+
+func (a Alert) IsSevere() bool {
+	return strings.EqualFold(a.Severity, AlertSeveritySevere) || strings.EqualFold(a.Severity, AlertSeverityExtreme)
+}
+
+func (a Alert) Fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		a.Event,
+		a.Headline,
+		a.Areas,
+		a.Effective.UTC().Format(time.RFC3339),
+	}, "|")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+type APIKeyPlan string
+
+const (
+	APIKeyPlanFree      APIKeyPlan = "free"
+	APIKeyPlanStandard  APIKeyPlan = "standard"
+	APIKeyPlanUnlimited APIKeyPlan = "unlimited"
+)
+
+func (p APIKeyPlan) IsValid() bool {
+	switch p {
+	case APIKeyPlanFree, APIKeyPlanStandard, APIKeyPlanUnlimited:
+		return true
+	}
+	return false
+}
+
+// Limits are the rate limit and monthly quota a key on the plan is issued
+// with unless they are overridden. Zero means unlimited.
+func (p APIKeyPlan) Limits() (requestsPerMinute int, monthlyQuota int64) {
+	switch p {
+	case APIKeyPlanFree:
+		return 60, 10_000
+	case APIKeyPlanStandard:
+		return 600, 1_000_000
+	}
+	return 0, 0
+}
+
+// APIKey identifies a partner calling the public API. Only the SHA-256 hash of
+// the key is stored; the key itself is shown once when it is issued and Prefix
+// lets admins tell keys apart.
+type APIKey struct {
+	ID                int64
+	Prefix            string
+	Hash              string
+	Owner             string
+	Plan              APIKeyPlan
+	RequestsPerMinute int
+	MonthlyQuota      int64
+	// MonthlyUsage counts requests in the current calendar month (UTC).
+	MonthlyUsage int64
+	CreatedAt    time.Time
+	LastUsedAt   *time.Time
+	RevokedAt    *time.Time
+}
+
+func (k APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// QuotaExceeded reports whether the key has used up its monthly quota.
+func (k APIKey) QuotaExceeded() bool {
+	return k.MonthlyQuota > 0 && k.MonthlyUsage > k.MonthlyQuota
+}
+
+type APIKeyUsage struct {
+	Month    time.Time
+	Requests int64
+}
@@ -11,7 +11,18 @@ var (
 	ErrInvalidToken                 = errors.New("invalid token")
 	ErrTokenNotFound                = errors.New("token not found")
 	ErrSubscriptionNotFound         = errors.New("subscription not found")
+	ErrSubscriberNotFound           = errors.New("subscriber not found")
 	ErrSubscriptionAlreadyConfirmed = errors.New("subscription already confirmed")
+	ErrLockNotAcquired              = errors.New("lock is held by another instance")
+	ErrLockLost                     = errors.New("lock is no longer held")
+	ErrEmailSuppressed              = errors.New("email address is suppressed")
+	ErrWebhookDisabled              = errors.New("webhook is disabled")
+	ErrChannelUnavailable           = errors.New("no notifier configured for channel")
+	ErrPushSubscriptionExpired      = errors.New("push subscription expired")
+	ErrAPIKeyNotFound               = errors.New("api key not found")
+	ErrInvalidAPIKey                = errors.New("invalid api key")
+	ErrAPIKeyQuotaExceeded          = errors.New("api key monthly quota exceeded")
+	ErrSendAlreadyQueued            = errors.New("a send for this frequency is already queued")
 )
 
 type ValidationError struct {
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type HealthStatus string
+
+const (
+	HealthStatusUp       HealthStatus = "up"
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusDown     HealthStatus = "down"
+)
+
+type ComponentHealth struct {
+	Name      string
+	Status    HealthStatus
+	Critical  bool
+	Latency   time.Duration
+	CheckedAt time.Time
+}
+
+type HealthReport struct {
+	Status     HealthStatus
+	Components []ComponentHealth
+}
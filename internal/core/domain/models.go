@@ -7,10 +7,28 @@ import (
 type Frequency string
 
 const (
-	FrequencyDaily  Frequency = "daily"
-	FrequencyHourly Frequency = "hourly"
+	FrequencyDaily    Frequency = "daily"
+	FrequencyHourly   Frequency = "hourly"
+	FrequencyWeekly   Frequency = "weekly"
+	FrequencyInterval Frequency = "interval"
+	FrequencyWeekdays Frequency = "weekdays"
+	FrequencyCustom   Frequency = "custom"
 )
 
+func (f Frequency) IsValid() bool {
+	switch f {
+	case FrequencyDaily, FrequencyHourly, FrequencyWeekly, FrequencyInterval, FrequencyWeekdays, FrequencyCustom:
+		return true
+	}
+	return false
+}
+
+type Schedule struct {
+	Weekday        time.Weekday
+	IntervalHours  int
+	CronExpression string
+}
+
 type Weather struct {
 	Temperature float64
 	Humidity    int
@@ -22,19 +40,83 @@ type City struct {
 	Name string
 }
 
+type Subscriber struct {
+	ID            int64
+	Email         string
+	Token         string
+	Subscriptions []Subscription
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
 type Subscription struct {
-	ID          int64
-	Email       string
-	CityID      int64
-	City        *City
-	Frequency   Frequency
-	Token       string
-	IsConfirmed bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             int64
+	SubscriberID   int64
+	Subscriber     *Subscriber
+	Email          string
+	CityID         int64
+	City           *City
+	Frequency      Frequency
+	Schedule       Schedule
+	Timezone       string
+	QuietHours     QuietHours
+	Token          string
+	IsConfirmed    bool
+	IsPaused       bool
+	SevereAlerts   bool
+	Webhook        *Webhook
+	TelegramChatID int64
+	Push           *PushSubscription
+	NextRunAt      time.Time
+	LastSentAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Channel returns how updates of the subscription are delivered. A disabled
+// webhook falls back to the next channel.
+func (s Subscription) Channel() Channel {
+	if s.Webhook != nil && !s.Webhook.Disabled() {
+		return ChannelWebhook
+	}
+	if s.Push != nil {
+		return ChannelPush
+	}
+	if s.TelegramChatID != 0 {
+		return ChannelTelegram
+	}
+	return ChannelEmail
+}
+
+func (s Subscription) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (s Subscription) InQuietHours(t time.Time) bool {
+	return s.QuietHours.Contains(t.In(s.Location()))
 }
 
 type WeatherUpdate struct {
 	Subscription Subscription
 	Weather      Weather
+	Overnight    *WeatherSummary
+}
+
+type WeatherDigest struct {
+	Channel         Channel
+	Email           string
+	ManagementToken string
+	Updates         []WeatherUpdate
+}
+
+type DueUpdates struct {
+	Digests  []WeatherDigest
+	Deferred []Subscription
 }
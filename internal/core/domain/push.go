@@ -0,0 +1,16 @@
+package domain
+
+// PushSubscription is a browser's Web Push endpoint with the keys from
+// PushSubscription.getKey, base64url encoded.
+type PushSubscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// PushNotification is what the service worker displays.
+type PushNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url,omitempty"`
+}
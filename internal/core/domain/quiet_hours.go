@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+type QuietHours struct {
+	Start       int
+	End         int
+	SendSummary bool
+}
+
+func (q QuietHours) Enabled() bool {
+	return q.Start != q.End
+}
+
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.Enabled() {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if q.Start < q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// EndAfter returns the first end of the quiet window after t, in t's location.
+func (q QuietHours) EndAfter(t time.Time) time.Time {
+	end := time.Date(t.Year(), t.Month(), t.Day(), q.End/60, q.End%60, 0, 0, t.Location())
+	if !end.After(t) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+func ParseClock(value string) (int, bool) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func FormatClock(minutes int) string {
+	minutes = ((minutes % minutesPerDay) + minutesPerDay) % minutesPerDay
+	return time.Date(0, 1, 1, minutes/60, minutes%60, 0, 0, time.UTC).Format("15:04")
+}
+
+type WeatherObservation struct {
+	Weather    Weather
+	ObservedAt time.Time
+}
+
+type WeatherSummary struct {
+	From           time.Time
+	To             time.Time
+	MinTemperature float64
+	MaxTemperature float64
+	Descriptions   []string
+}
+
+func SummarizeObservations(observations []WeatherObservation) *WeatherSummary {
+	if len(observations) == 0 {
+		return nil
+	}
+
+	first := observations[0]
+	summary := &WeatherSummary{
+		From:           first.ObservedAt,
+		To:             first.ObservedAt,
+		MinTemperature: first.Weather.Temperature,
+		MaxTemperature: first.Weather.Temperature,
+	}
+	seen := make(map[string]bool)
+	for _, obs := range observations {
+		if obs.ObservedAt.Before(summary.From) {
+			summary.From = obs.ObservedAt
+		}
+		if obs.ObservedAt.After(summary.To) {
+			summary.To = obs.ObservedAt
+		}
+		if obs.Weather.Temperature < summary.MinTemperature {
+			summary.MinTemperature = obs.Weather.Temperature
+		}
+		if obs.Weather.Temperature > summary.MaxTemperature {
+			summary.MaxTemperature = obs.Weather.Temperature
+		}
+		if description := obs.Weather.Description; description != "" && !seen[description] {
+			seen[description] = true
+			summary.Descriptions = append(summary.Descriptions, description)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type BounceType string
+
+const (
+	BounceTypeHard      BounceType = "hard_bounce"
+	BounceTypeSoft      BounceType = "soft_bounce"
+	BounceTypeComplaint BounceType = "complaint"
+)
+
+func (t BounceType) IsValid() bool {
+	switch t {
+	case BounceTypeHard, BounceTypeSoft, BounceTypeComplaint:
+		return true
+	}
+	return false
+}
+
+// Suppresses reports whether the address must not be emailed again. Soft
+// bounces are temporary and only logged.
+func (t BounceType) Suppresses() bool {
+	return t == BounceTypeHard || t == BounceTypeComplaint
+}
+
+// BounceEvent is a bounce or complaint reported by the mail provider or parsed
+// from a delivery status notification.
+type BounceEvent struct {
+	Email      string
+	Type       BounceType
+	Reason     string
+	OccurredAt time.Time
+}
+
+type Suppression struct {
+	Email     string
+	Reason    BounceType
+	Details   string
+	CreatedAt time.Time
+}
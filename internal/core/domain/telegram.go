@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const telegramAddressPrefix = "telegram:"
+
+// TelegramAddress is the subscriber address used for subscriptions created
+// from a Telegram chat, which have no email address of their own.
+func TelegramAddress(chatID int64) string {
+	return fmt.Sprintf("%s%d", telegramAddressPrefix, chatID)
+}
+
+// IsTelegramAddress reports whether address was made by TelegramAddress and
+// so cannot receive email.
+func IsTelegramAddress(address string) bool {
+	return strings.HasPrefix(address, telegramAddressPrefix)
+}
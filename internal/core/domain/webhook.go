@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type Channel string
+
+const (
+	ChannelEmail    Channel = "email"
+	ChannelWebhook  Channel = "webhook"
+	ChannelTelegram Channel = "telegram"
+	ChannelPush     Channel = "push"
+)
+
+// Webhook is an HTTP endpoint that receives a subscription's updates instead of email.
+type Webhook struct {
+	URL                 string
+	Secret              string
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+}
+
+func (w *Webhook) Disabled() bool {
+	return w != nil && w.DisabledAt != nil
+}
+
+// WebhookMessage is a single update, or a severe weather alert when Alert is
+// set, delivered to a webhook. ID stays the same across retries so receivers
+// can deduplicate.
+type WebhookMessage struct {
+	ID     string
+	Update WeatherUpdate
+	Alert  *Alert
+	SentAt time.Time
+}
+
+// WebhookDelivery is one attempt to deliver a WebhookMessage.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	MessageID      string
+	Attempt        int
+	StatusCode     int
+	Error          string
+	Duration       time.Duration
+	CreatedAt      time.Time
+}
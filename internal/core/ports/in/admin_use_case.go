@@ -0,0 +1,16 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type AdminUseCase interface {
+	SearchSubscriptions(ctx context.Context, actor string, opts out.SearchSubscriptionsOptions) ([]domain.Subscription, error)
+	GetDeliveryHistory(ctx context.Context, actor string, subscriptionID int64) ([]domain.Delivery, error)
+	ConfirmSubscription(ctx context.Context, actor string, subscriptionID int64) error
+	DeleteSubscription(ctx context.Context, actor string, subscriptionID int64) error
+	ListCities(ctx context.Context, actor string) ([]domain.CityStats, error)
+	TriggerSend(ctx context.Context, actor string, frequency domain.Frequency) error
+}
@@ -0,0 +1,10 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
+type AlertUseCase interface {
+	GetAlerts(ctx context.Context, city string) ([]domain.Alert, error)
+}
@@ -0,0 +1,18 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type APIKeyUseCase interface {
+	// IssueAPIKey returns the stored key and the plaintext key, which is not
+	// kept anywhere.
+	IssueAPIKey(ctx context.Context, actor string, opts out.IssueAPIKeyOptions) (domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, actor string) ([]domain.APIKey, error)
+	GetAPIKeyUsage(ctx context.Context, actor string, id int64) ([]domain.APIKeyUsage, error)
+	RevokeAPIKey(ctx context.Context, actor string, id int64) error
+	Authenticate(ctx context.Context, key string) (domain.APIKey, error)
+	RecordUsage(ctx context.Context, key domain.APIKey) (domain.APIKey, error)
+}
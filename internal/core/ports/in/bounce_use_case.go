@@ -0,0 +1,10 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
+type BounceUseCase interface {
+	HandleBounces(ctx context.Context, events []domain.BounceEvent) (int, error)
+}
@@ -0,0 +1,11 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
+type HealthUseCase interface {
+	Liveness(ctx context.Context) domain.HealthReport
+	Readiness(ctx context.Context) domain.HealthReport
+}
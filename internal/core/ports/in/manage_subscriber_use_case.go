@@ -0,0 +1,11 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
+type ManageSubscriberUseCase interface {
+	GetSubscriber(ctx context.Context, token string) (domain.Subscriber, error)
+	UnsubscribeAll(ctx context.Context, token string) error
+}
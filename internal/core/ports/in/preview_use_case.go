@@ -0,0 +1,11 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/ports/out"
+)
+
+type PreviewUseCase interface {
+	PreviewUpdate(ctx context.Context, actor, token string) (out.SendEmailOptions, error)
+	PreviewConfirmation(ctx context.Context, actor, token string) (out.SendEmailOptions, error)
+}
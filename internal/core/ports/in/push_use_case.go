@@ -0,0 +1,11 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
+type PushUseCase interface {
+	RegisterPush(ctx context.Context, token string, push domain.PushSubscription) error
+	RemovePush(ctx context.Context, token string) error
+}
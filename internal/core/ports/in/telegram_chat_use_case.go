@@ -0,0 +1,11 @@
+package in
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
+type TelegramChatUseCase interface {
+	ListSubscriptions(ctx context.Context, chatID int64) ([]domain.Subscription, error)
+	Unsubscribe(ctx context.Context, chatID int64, city string) (int, error)
+}
@@ -0,0 +1,10 @@
+package in
+
+import (
+	"context"
+)
+
+type WebhookUseCase interface {
+	RegisterWebhook(ctx context.Context, token string, url string, secret string) error
+	RemoveWebhook(ctx context.Context, token string) error
+}
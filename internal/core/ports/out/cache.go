@@ -5,5 +5,6 @@ import "context"
 type Cache interface {
 	Get(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, key string) error
 	Close() error
 }
@@ -1,5 +1,10 @@
 package out
 
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
 type SendEmailOptions struct {
 	To      string
 	Subject string
@@ -7,5 +12,12 @@ type SendEmailOptions struct {
 }
 
 type EmailSender interface {
-	SendEmail(opts SendEmailOptions) error
+	SendEmail(ctx context.Context, opts SendEmailOptions) error
+}
+
+// BounceSource reads bounce and complaint events that arrived out of band,
+// for example as DSN messages in a mailbox. A message is only marked as
+// processed once handle succeeds for its events.
+type BounceSource interface {
+	ProcessBounces(ctx context.Context, handle func(ctx context.Context, events []domain.BounceEvent) error) error
 }
@@ -0,0 +1,8 @@
+package out
+
+import "context"
+
+type HealthCheck interface {
+	Name() string
+	Check(ctx context.Context) error
+}
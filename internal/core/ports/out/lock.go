@@ -0,0 +1,16 @@
+package out
+
+import (
+	"context"
+	"time"
+)
+
+type Locker interface {
+	// TryAcquire returns domain.ErrLockNotAcquired when another holder owns the lock.
+	TryAcquire(ctx context.Context, name string, ttl time.Duration) (Lease, error)
+}
+
+type Lease interface {
+	Renew(ctx context.Context) error
+	Release(ctx context.Context) error
+}
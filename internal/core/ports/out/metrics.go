@@ -0,0 +1,25 @@
+package out
+
+import (
+	"time"
+	"weather-api/internal/core/domain"
+)
+
+// BusinessMetrics records subscription lifecycle and delivery events.
+type BusinessMetrics interface {
+	SubscriptionCreated(frequency domain.Frequency)
+	SubscriptionConfirmed(frequency domain.Frequency)
+	SubscriptionsRemoved(count int)
+	UpdateSent(frequency domain.Frequency, channel domain.Channel)
+	SchedulerRun(job string, duration time.Duration, err error)
+}
+
+// NopBusinessMetrics discards every event. Constructors fall back to it when
+// no metrics are configured.
+type NopBusinessMetrics struct{}
+
+func (NopBusinessMetrics) SubscriptionCreated(domain.Frequency)        {}
+func (NopBusinessMetrics) SubscriptionConfirmed(domain.Frequency)      {}
+func (NopBusinessMetrics) SubscriptionsRemoved(int)                    {}
+func (NopBusinessMetrics) UpdateSent(domain.Frequency, domain.Channel) {}
+func (NopBusinessMetrics) SchedulerRun(string, time.Duration, error)   {}
@@ -0,0 +1,30 @@
+package out
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+)
+
+// Notifier delivers a digest of weather updates over one channel.
+type Notifier interface {
+	Notify(ctx context.Context, digest domain.WeatherDigest) error
+}
+
+// AlertNotifier delivers a severe weather alert to one subscription over the
+// channel of its Notifier.
+type AlertNotifier interface {
+	NotifyAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error
+}
+
+// WebhookSender makes a single signed delivery attempt and returns the HTTP
+// status code of the response, or 0 if no response was received.
+type WebhookSender interface {
+	Send(ctx context.Context, webhook domain.Webhook, message domain.WebhookMessage) (int, error)
+}
+
+// PushSender encrypts a notification for a browser push subscription and
+// delivers it to the push service, returning the HTTP status code of the
+// response, or 0 if no response was received.
+type PushSender interface {
+	Send(ctx context.Context, subscription domain.PushSubscription, notification domain.PushNotification) (int, error)
+}
@@ -2,6 +2,7 @@ package out
 
 import (
 	"context"
+	"time"
 	"weather-api/internal/core/domain"
 )
 
@@ -12,22 +13,147 @@ type IsSubscriptionExistsOptions struct {
 }
 
 type SubscribeOptions struct {
-	Email     string
-	City      string
-	Frequency domain.Frequency
+	Email          string
+	City           string
+	Frequency      domain.Frequency
+	Schedule       domain.Schedule
+	Timezone       string
+	QuietHours     domain.QuietHours
+	SevereAlerts   bool
+	TelegramChatID int64
+}
+
+type CreateSubscriptionOptions struct {
+	SubscriberID   int64
+	Email          string
+	CityID         int64
+	Frequency      domain.Frequency
+	Schedule       domain.Schedule
+	Timezone       string
+	QuietHours     domain.QuietHours
+	SevereAlerts   bool
+	TelegramChatID int64
+}
+
+type MarkSentOptions struct {
+	SubscriptionID int64
+	SentAt         time.Time
+	NextRunAt      time.Time
+}
+
+type SearchSubscriptionsOptions struct {
+	Email  string
+	City   string
+	Limit  int
+	Offset int
 }
 
 type SubscriptionRepository interface {
 	CreateSubscription(ctx context.Context, sub domain.Subscription) error
 	GetSubscriptionByToken(ctx context.Context, token string) (domain.Subscription, error)
+	GetSubscriptionByID(ctx context.Context, id int64) (domain.Subscription, error)
+	SearchSubscriptions(ctx context.Context, opts SearchSubscriptionsOptions) ([]domain.Subscription, error)
 	UpdateSubscription(ctx context.Context, sub domain.Subscription) error
 	DeleteSubscription(ctx context.Context, token string) error
 	GetSubscriptionsByFrequency(ctx context.Context, frequency string) ([]domain.Subscription, error)
+	GetDueSubscriptions(ctx context.Context, now time.Time) ([]domain.Subscription, error)
+	MarkSent(ctx context.Context, opts MarkSentOptions) error
+	UpdateNextRun(ctx context.Context, subscriptionID int64, nextRunAt time.Time) error
 	IsTokenExists(ctx context.Context, token string) (bool, error)
 	IsSubscriptionExists(ctx context.Context, opts IsSubscriptionExistsOptions) (bool, error)
+	GetAlertSubscriptions(ctx context.Context) ([]domain.Subscription, error)
+	GetSubscriptionsBySubscriber(ctx context.Context, subscriberID int64) ([]domain.Subscription, error)
+	DeleteSubscriptionsBySubscriber(ctx context.Context, subscriberID int64) error
+	PauseSubscriptionsByEmail(ctx context.Context, email string) (int64, error)
+}
+
+type SubscriberRepository interface {
+	Create(ctx context.Context, subscriber domain.Subscriber) (domain.Subscriber, error)
+	GetByEmail(ctx context.Context, email string) (domain.Subscriber, error)
+	GetByToken(ctx context.Context, token string) (domain.Subscriber, error)
 }
 
 type CityRepository interface {
 	Create(ctx context.Context, city domain.City) (domain.City, error)
 	GetByName(ctx context.Context, name string) (domain.City, error)
+	ListWithSubscriberCounts(ctx context.Context) ([]domain.CityStats, error)
+}
+
+type AlertRepository interface {
+	// Save stores alert unless it was seen before and returns its ID and
+	// whether it is new.
+	Save(ctx context.Context, alert domain.Alert) (int64, bool, error)
+	GetDeliveredRecipients(ctx context.Context, alertID int64) (map[string]bool, error)
+	MarkDelivered(ctx context.Context, alertID int64, recipient string) error
+}
+
+// SendTriggerRepository queues sends requested through the admin API until
+// the scheduler leader takes them.
+type SendTriggerRepository interface {
+	// Enqueue reports false when a send for frequency is already queued.
+	Enqueue(ctx context.Context, frequency domain.Frequency) (bool, error)
+	TakePending(ctx context.Context) ([]domain.Frequency, error)
+}
+
+type WeatherObservationRepository interface {
+	SaveObservation(ctx context.Context, subscriptionID int64, observation domain.WeatherObservation) error
+	TakeObservations(ctx context.Context, subscriptionID int64) ([]domain.WeatherObservation, error)
+}
+
+type DeliveryRepository interface {
+	RecordDelivery(ctx context.Context, delivery domain.Delivery) error
+	GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.Delivery, error)
+}
+
+type AuditLogRepository interface {
+	Record(ctx context.Context, entry domain.AuditEntry) error
+}
+
+type SuppressionRepository interface {
+	Suppress(ctx context.Context, suppression domain.Suppression) error
+	IsSuppressed(ctx context.Context, email string) (bool, error)
+}
+
+type WebhookRepository interface {
+	SaveWebhook(ctx context.Context, subscriptionID int64, webhook domain.Webhook) error
+	DeleteWebhook(ctx context.Context, subscriptionID int64) error
+	RecordFailure(ctx context.Context, subscriptionID int64) (int, error)
+	ResetFailures(ctx context.Context, subscriptionID int64) error
+	DisableWebhook(ctx context.Context, subscriptionID int64) error
+	RecordDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+}
+
+type PushRepository interface {
+	SavePushSubscription(ctx context.Context, subscriptionID int64, push domain.PushSubscription) error
+	DeletePushSubscription(ctx context.Context, subscriptionID int64) error
+	ExpirePushSubscription(ctx context.Context, subscriptionID int64) error
+}
+
+type ProviderUsageRepository interface {
+	// IncrementUsage counts one call to provider in the month starting at
+	// month and returns the new total.
+	IncrementUsage(ctx context.Context, provider string, month time.Time) (int64, error)
+	GetUsage(ctx context.Context, provider string, month time.Time) (int64, error)
+}
+
+type IssueAPIKeyOptions struct {
+	Owner string
+	Plan  domain.APIKeyPlan
+	// RequestsPerMinute and MonthlyQuota override the plan's limits when set.
+	RequestsPerMinute *int
+	MonthlyQuota      *int64
+}
+
+// APIKeyRepository reads keys together with their usage in the month starting
+// at month.
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id int64, month time.Time) (domain.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string, month time.Time) (domain.APIKey, error)
+	ListAPIKeys(ctx context.Context, month time.Time) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	// IncrementAPIKeyUsage counts one request of the key in the month starting
+	// at month and returns the new total.
+	IncrementAPIKeyUsage(ctx context.Context, id int64, month time.Time) (int64, error)
+	GetAPIKeyUsage(ctx context.Context, id int64) ([]domain.APIKeyUsage, error)
 }
@@ -2,12 +2,14 @@ package out
 
 import (
 	"context"
+	"time"
 	"weather-api/internal/core/domain"
 )
 
 type SubscriptionService interface {
-	CreateSubscription(ctx context.Context, email string, cityID int64, frequency domain.Frequency) (string, error)
+	CreateSubscription(ctx context.Context, opts CreateSubscriptionOptions) (string, error)
 	GetSubscriptionsByFrequency(ctx context.Context, frequency domain.Frequency) ([]domain.Subscription, error)
+	GetDueSubscriptions(ctx context.Context, now time.Time) ([]domain.Subscription, error)
 }
 
 type WeatherService interface {
@@ -17,3 +19,7 @@ type WeatherService interface {
 type CityService interface {
 	EnsureCityExists(ctx context.Context, cityName string) (domain.City, error)
 }
+
+type SubscriberService interface {
+	EnsureSubscriberExists(ctx context.Context, email string) (domain.Subscriber, error)
+}
@@ -10,3 +10,7 @@ type WeatherProvider interface {
 	CheckCityExists(ctx context.Context, city string) error
 	Name() string
 }
+
+type AlertProvider interface {
+	GetAlerts(ctx context.Context, city string) ([]domain.Alert, error)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type AlertServiceImpl struct {
+	subscriptionRepo out.SubscriptionRepository
+	alertRepo        out.AlertRepository
+	alertProvider    out.AlertProvider
+	notifiers        Notifiers
+	logger           *slog.Logger
+}
+
+func NewAlertService(
+	subscriptionRepo out.SubscriptionRepository,
+	alertRepo out.AlertRepository,
+	alertProvider out.AlertProvider,
+	notifiers Notifiers,
+	logger *slog.Logger,
+) *AlertServiceImpl {
+	return &AlertServiceImpl{
+		subscriptionRepo: subscriptionRepo,
+		alertRepo:        alertRepo,
+		alertProvider:    alertProvider,
+		notifiers:        notifiers,
+		logger:           logger,
+	}
+}
+
+// ProcessAlerts fetches the alerts of every city with alert subscriptions and
+// notifies each recipient once per alert over its subscription's channel.
+// Recipients are recorded after a successful send, so failed ones are retried
+// on the next run for as long as the provider reports the alert.
+func (s *AlertServiceImpl) ProcessAlerts(ctx context.Context) error {
+	subs, err := s.subscriptionRepo.GetAlertSubscriptions(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get alert subscriptions: %v", err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	citySubscriptions := make(map[int64][]domain.Subscription)
+	for _, sub := range subs {
+		if !sub.IsConfirmed || !sub.SevereAlerts {
+			continue
+		}
+		citySubscriptions[sub.CityID] = append(citySubscriptions[sub.CityID], sub)
+	}
+
+	for cityID, citySubs := range citySubscriptions {
+		cityName := citySubs[0].City.Name
+		alerts, err := s.alertProvider.GetAlerts(ctx, cityName)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "unable to get alerts", "city", cityName, "error", err)
+			continue
+		}
+
+		for _, alert := range alerts {
+			if !alert.IsSevere() {
+				continue
+			}
+			alert.CityID = cityID
+
+			alertID, isNew, err := s.alertRepo.Save(ctx, alert)
+			if err != nil {
+				s.logger.ErrorContext(ctx, "unable to save alert", "city", cityName, "headline", alert.Headline, "error", err)
+				continue
+			}
+			if isNew {
+				s.logger.InfoContext(ctx, "new severe weather alert", "city", cityName, "event", alert.Event)
+			}
+			s.notify(ctx, alertID, alert, citySubs)
+		}
+	}
+
+	return nil
+}
+
+func (s *AlertServiceImpl) notify(ctx context.Context, alertID int64, alert domain.Alert, subs []domain.Subscription) {
+	delivered, err := s.alertRepo.GetDeliveredRecipients(ctx, alertID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "unable to get alert recipients", "alert_id", alertID, "error", err)
+		return
+	}
+
+	for _, sub := range subs {
+		recipient := s.notifiers.Recipient(sub)
+		if delivered[recipient] {
+			continue
+		}
+
+		err := s.notifiers.NotifyAlert(ctx, sub, alert)
+		if err != nil && !isPermanentDeliveryError(err) && !errors.Is(err, domain.ErrEmailSuppressed) {
+			s.logger.ErrorContext(ctx, "unable to send alert", "alert_id", alertID, "subscription_id", sub.ID, "error", err)
+			continue
+		}
+		if err != nil {
+			// Retrying cannot help, so the recipient is recorded as done.
+			s.logger.WarnContext(ctx, "skipped alert for unreachable recipient", "alert_id", alertID, "subscription_id", sub.ID, "error", err)
+		}
+
+		delivered[recipient] = true
+		if err := s.alertRepo.MarkDelivered(ctx, alertID, recipient); err != nil {
+			s.logger.ErrorContext(ctx, "unable to mark alert delivered", "alert_id", alertID, "subscription_id", sub.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAlertService_ProcessAlerts(t *testing.T) {
+	ctx := context.Background()
+	kyiv := &domain.City{ID: 1, Name: "Kyiv"}
+	sub := domain.Subscription{
+		ID:           10,
+		Email:        "user@example.com",
+		CityID:       1,
+		City:         kyiv,
+		Token:        "token",
+		IsConfirmed:  true,
+		SevereAlerts: true,
+	}
+	severe := domain.Alert{
+		Headline:  "Storm warning",
+		Event:     "Storm",
+		Severity:  "Severe",
+		Effective: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	minor := domain.Alert{Headline: "Fog advisory", Event: "Fog", Severity: "Minor"}
+	stored := severe
+	stored.CityID = 1
+	sameRecipient := sub
+	sameRecipient.ID = 11
+	pushSub := sub
+	pushSub.ID = 12
+	pushSub.Email = "push@example.com"
+	pushSub.Push = &domain.PushSubscription{Endpoint: "https://push.example.com/1"}
+	telegramSub := sub
+	telegramSub.ID = 13
+	telegramSub.Email = domain.TelegramAddress(42)
+	telegramSub.TelegramChatID = 42
+
+	tests := []struct {
+		name       string
+		setupMocks func(repo *mocks.MockSubscriptionRepository, alerts *mocks.MockAlertRepository, provider *mocks.MockAlertProvider, email *MockEmailNotifier, push *mocks.MockPushSender)
+		wantErr    bool
+	}{
+		{
+			name: "sends new severe alerts only",
+			setupMocks: func(repo *mocks.MockSubscriptionRepository, alerts *mocks.MockAlertRepository, provider *mocks.MockAlertProvider, email *MockEmailNotifier, push *mocks.MockPushSender) {
+				repo.On("GetAlertSubscriptions", ctx).Return([]domain.Subscription{sub}, nil)
+				provider.On("GetAlerts", ctx, "Kyiv").Return([]domain.Alert{severe, minor}, nil)
+				alerts.On("Save", ctx, stored).Return(int64(5), true, nil)
+				alerts.On("GetDeliveredRecipients", ctx, int64(5)).Return(map[string]bool{}, nil)
+				email.On("SendAlert", mock.Anything, sub, stored).Return(nil).Once()
+				alerts.On("MarkDelivered", ctx, int64(5), "email:user@example.com").Return(nil).Once()
+			},
+		},
+		{
+			name: "skips recipients that already received the alert",
+			setupMocks: func(repo *mocks.MockSubscriptionRepository, alerts *mocks.MockAlertRepository, provider *mocks.MockAlertProvider, email *MockEmailNotifier, push *mocks.MockPushSender) {
+				repo.On("GetAlertSubscriptions", ctx).Return([]domain.Subscription{sub}, nil)
+				provider.On("GetAlerts", ctx, "Kyiv").Return([]domain.Alert{severe}, nil)
+				alerts.On("Save", ctx, stored).Return(int64(5), false, nil)
+				alerts.On("GetDeliveredRecipients", ctx, int64(5)).Return(map[string]bool{"email:user@example.com": true}, nil)
+			},
+		},
+		{
+			name: "leaves failed sends for the next run",
+			setupMocks: func(repo *mocks.MockSubscriptionRepository, alerts *mocks.MockAlertRepository, provider *mocks.MockAlertProvider, email *MockEmailNotifier, push *mocks.MockPushSender) {
+				repo.On("GetAlertSubscriptions", ctx).Return([]domain.Subscription{sub}, nil)
+				provider.On("GetAlerts", ctx, "Kyiv").Return([]domain.Alert{severe}, nil)
+				alerts.On("Save", ctx, stored).Return(int64(5), false, nil)
+				alerts.On("GetDeliveredRecipients", ctx, int64(5)).Return(map[string]bool{}, nil)
+				email.On("SendAlert", mock.Anything, sub, stored).Return(errors.New("smtp down")).Once()
+			},
+		},
+		{
+			name: "notifies each recipient once over its channel",
+			setupMocks: func(repo *mocks.MockSubscriptionRepository, alerts *mocks.MockAlertRepository, provider *mocks.MockAlertProvider, email *MockEmailNotifier, push *mocks.MockPushSender) {
+				repo.On("GetAlertSubscriptions", ctx).Return([]domain.Subscription{sub, sameRecipient, pushSub, telegramSub}, nil)
+				provider.On("GetAlerts", ctx, "Kyiv").Return([]domain.Alert{severe}, nil)
+				alerts.On("Save", ctx, stored).Return(int64(5), true, nil)
+				alerts.On("GetDeliveredRecipients", ctx, int64(5)).Return(map[string]bool{}, nil)
+				email.On("SendAlert", mock.Anything, sub, stored).Return(nil).Once()
+				alerts.On("MarkDelivered", ctx, int64(5), "email:user@example.com").Return(nil).Once()
+				push.On("Send", ctx, *pushSub.Push, mock.Anything).Return(201, nil).Once()
+				alerts.On("MarkDelivered", ctx, int64(5), "push:https://push.example.com/1").Return(nil).Once()
+				// The bot is switched off, so the chat can't be reached and is not retried.
+				alerts.On("MarkDelivered", ctx, int64(5), "telegram:telegram:42").Return(nil).Once()
+			},
+		},
+		{
+			name: "continues when provider fails",
+			setupMocks: func(repo *mocks.MockSubscriptionRepository, alerts *mocks.MockAlertRepository, provider *mocks.MockAlertProvider, email *MockEmailNotifier, push *mocks.MockPushSender) {
+				repo.On("GetAlertSubscriptions", ctx).Return([]domain.Subscription{sub}, nil)
+				provider.On("GetAlerts", ctx, "Kyiv").Return([]domain.Alert(nil), errors.New("provider down"))
+			},
+		},
+		{
+			name: "returns error when subscriptions cannot be loaded",
+			setupMocks: func(repo *mocks.MockSubscriptionRepository, alerts *mocks.MockAlertRepository, provider *mocks.MockAlertProvider, email *MockEmailNotifier, push *mocks.MockPushSender) {
+				repo.On("GetAlertSubscriptions", ctx).Return([]domain.Subscription(nil), errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockSubscriptionRepository{}
+			alertRepo := &mocks.MockAlertRepository{}
+			provider := &mocks.MockAlertProvider{}
+			email := &MockEmailNotifier{}
+			push := &mocks.MockPushSender{}
+			tt.setupMocks(repo, alertRepo, provider, email, push)
+
+			notifiers := Notifiers{
+				domain.ChannelEmail: NewEmailNotifier(email),
+				domain.ChannelPush:  NewPushNotifier(push, &mocks.MockPushRepository{}, logger.Discard()),
+			}
+			svc := NewAlertService(repo, alertRepo, provider, notifiers, logger.Discard())
+			err := svc.ProcessAlerts(ctx)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			alertRepo.AssertExpectations(t)
+			provider.AssertExpectations(t)
+			email.AssertExpectations(t)
+			push.AssertExpectations(t)
+			alertRepo.AssertNotCalled(t, "Save", mock.Anything, mock.MatchedBy(func(a domain.Alert) bool { return a.Severity == "Minor" }))
+		})
+	}
+}
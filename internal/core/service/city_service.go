@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 )
@@ -12,22 +12,25 @@ import (
 type CityService struct {
 	cityRepo        out.CityRepository
 	weatherProvider out.WeatherProvider
+	logger          *slog.Logger
 }
 
 func NewCityService(
 	cityRepo out.CityRepository,
 	weatherProvider out.WeatherProvider,
+	logger *slog.Logger,
 ) *CityService {
 	return &CityService{
 		cityRepo:        cityRepo,
 		weatherProvider: weatherProvider,
+		logger:          logger,
 	}
 }
 
 func (s *CityService) EnsureCityExists(ctx context.Context, cityName string) (domain.City, error) {
 	city, err := s.cityRepo.GetByName(ctx, cityName)
 	if err == nil {
-		log.Printf("City %s already exists in database", cityName)
+		s.logger.DebugContext(ctx, "city already exists", "city", cityName)
 		return city, nil
 	}
 
@@ -37,7 +40,7 @@ func (s *CityService) EnsureCityExists(ctx context.Context, cityName string) (do
 
 	if err := s.weatherProvider.CheckCityExists(ctx, cityName); err != nil {
 		if errors.Is(err, domain.ErrCityNotFound) {
-			log.Printf("City %s not found in weather service", cityName)
+			s.logger.InfoContext(ctx, "city not found in weather service", "city", cityName)
 			return domain.City{}, domain.ErrCityNotFound
 		}
 		return domain.City{}, fmt.Errorf("unable to check city existence for %s: %w", cityName, err)
@@ -48,6 +51,6 @@ func (s *CityService) EnsureCityExists(ctx context.Context, cityName string) (do
 		return domain.City{}, fmt.Errorf("unable to create city %s: %w", cityName, err)
 	}
 
-	log.Printf("Successfully created city: %s with ID: %d", cityName, city.ID)
+	s.logger.InfoContext(ctx, "created city", "city", cityName, "city_id", city.ID)
 	return city, nil
 }
@@ -1,65 +1,172 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 	"weather-api/internal/util/emailutil"
 )
 
 type EmailService interface {
-	SendUpdates(updates []domain.WeatherUpdate) error
-	SendConfirmationEmail(subscription *domain.Subscription) error
+	SendUpdates(ctx context.Context, digests []domain.WeatherDigest) error
+	SendConfirmationEmail(ctx context.Context, subscription *domain.Subscription) error
+	SendAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error
 }
 
 type EmailServiceImpl struct {
-	emailSvc out.EmailSender
+	emailSvc         out.EmailSender
+	suppressionRepo  out.SuppressionRepository
+	subscriptionRepo out.SubscriptionRepository
+	logger           *slog.Logger
 }
 
-func NewEmailService(emailSvc out.EmailSender) *EmailServiceImpl {
+func NewEmailService(
+	emailSvc out.EmailSender,
+	suppressionRepo out.SuppressionRepository,
+	subscriptionRepo out.SubscriptionRepository,
+	logger *slog.Logger,
+) *EmailServiceImpl {
 	return &EmailServiceImpl{
-		emailSvc: emailSvc,
+		emailSvc:         emailSvc,
+		suppressionRepo:  suppressionRepo,
+		subscriptionRepo: subscriptionRepo,
+		logger:           logger,
 	}
 }
 
-func (s *EmailServiceImpl) SendUpdates(updates []domain.WeatherUpdate) error {
-	for _, update := range updates {
-		subject, htmlBody := emailutil.BuildWeatherUpdateEmail(emailutil.WeatherUpdateEmailOptions{
+func (s *EmailServiceImpl) SendUpdates(ctx context.Context, digests []domain.WeatherDigest) error {
+	for _, digest := range digests {
+		if err := s.checkSuppressed(ctx, digest.Email); err != nil {
+			return err
+		}
+		if err := s.emailSvc.SendEmail(ctx, s.RenderUpdates(digest)); err != nil {
+			msg := fmt.Sprintf("unable to send email to %s: %v", digest.Email, err)
+			s.logger.ErrorContext(ctx, msg)
+			return errors.New(msg)
+		}
+	}
+
+	return nil
+}
+
+// RenderUpdates builds the digest email exactly as SendUpdates would send it.
+func (s *EmailServiceImpl) RenderUpdates(digest domain.WeatherDigest) out.SendEmailOptions {
+	cities := make([]emailutil.WeatherUpdateEmailOptions, 0, len(digest.Updates))
+	for _, update := range digest.Updates {
+		cities = append(cities, emailutil.WeatherUpdateEmailOptions{
 			City:        update.Subscription.City.Name,
 			Temperature: update.Weather.Temperature,
 			Humidity:    update.Weather.Humidity,
 			Description: update.Weather.Description,
 			Token:       update.Subscription.Token,
+			Overnight:   overnightSummary(update),
 		})
+	}
 
-		if err := s.emailSvc.SendEmail(out.SendEmailOptions{
-			To:      update.Subscription.Email,
-			Subject: subject,
-			Body:    htmlBody,
-		}); err != nil {
-			msg := fmt.Sprintf("unable to send email to %s: %v", update.Subscription.Email, err)
-			log.Print(msg)
-			return errors.New(msg)
-		}
+	subject, htmlBody := emailutil.BuildWeatherDigestEmail(emailutil.WeatherDigestEmailOptions{
+		Cities:          cities,
+		ManagementToken: digest.ManagementToken,
+	})
+
+	return out.SendEmailOptions{
+		To:      digest.Email,
+		Subject: subject,
+		Body:    htmlBody,
+	}
+}
+
+func overnightSummary(update domain.WeatherUpdate) *emailutil.OvernightSummaryEmailOptions {
+	if update.Overnight == nil {
+		return nil
+	}
+	location := update.Subscription.Location()
+	return &emailutil.OvernightSummaryEmailOptions{
+		From:           update.Overnight.From.In(location),
+		To:             update.Overnight.To.In(location),
+		MinTemperature: update.Overnight.MinTemperature,
+		MaxTemperature: update.Overnight.MaxTemperature,
+		Descriptions:   update.Overnight.Descriptions,
+	}
+}
+
+func (s *EmailServiceImpl) SendConfirmationEmail(ctx context.Context, subscription *domain.Subscription) error {
+	if err := s.checkSuppressed(ctx, subscription.Email); err != nil {
+		return err
+	}
+	if err := s.emailSvc.SendEmail(ctx, s.RenderConfirmationEmail(subscription)); err != nil {
+		msg := fmt.Sprintf("unable to send confirmation email to %s: %v", subscription.Email, err)
+		s.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
 	}
 
 	return nil
 }
 
-func (s *EmailServiceImpl) SendConfirmationEmail(subscription *domain.Subscription) error {
+// RenderConfirmationEmail builds the confirmation email for a subscription.
+func (s *EmailServiceImpl) RenderConfirmationEmail(subscription *domain.Subscription) out.SendEmailOptions {
 	subject, htmlBody := emailutil.BuildConfirmationEmail(subscription.City.Name, subscription.Token)
 
-	if err := s.emailSvc.SendEmail(out.SendEmailOptions{
+	return out.SendEmailOptions{
+		To:      subscription.Email,
+		Subject: subject,
+		Body:    htmlBody,
+	}
+}
+
+func (s *EmailServiceImpl) SendAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error {
+	if err := s.checkSuppressed(ctx, subscription.Email); err != nil {
+		return err
+	}
+	subject, htmlBody := emailutil.BuildSevereWeatherAlertEmail(emailutil.SevereWeatherAlertEmailOptions{
+		City:        subscription.City.Name,
+		Headline:    alert.Headline,
+		Event:       alert.Event,
+		Severity:    alert.Severity,
+		Areas:       alert.Areas,
+		Description: alert.Description,
+		Instruction: alert.Instruction,
+		Expires:     alert.Expires,
+		Token:       subscription.Token,
+	})
+
+	if err := s.emailSvc.SendEmail(ctx, out.SendEmailOptions{
 		To:      subscription.Email,
 		Subject: subject,
 		Body:    htmlBody,
 	}); err != nil {
-		msg := fmt.Sprintf("unable to send confirmation email to %s: %v", subscription.Email, err)
-		log.Print(msg)
+		msg := fmt.Sprintf("unable to send alert email to %s: %v", subscription.Email, err)
+		s.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
 	return nil
 }
+
+// checkSuppressed returns ErrEmailSuppressed for bounced or complaining
+// addresses and pauses their remaining subscriptions. Lookup failures are
+// logged and do not block sending.
+func (s *EmailServiceImpl) checkSuppressed(ctx context.Context, email string) error {
+	if s.suppressionRepo == nil {
+		return nil
+	}
+
+	suppressed, err := s.suppressionRepo.IsSuppressed(ctx, email)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "unable to check suppression list", "email", email, "error", err)
+		return nil
+	}
+	if !suppressed {
+		return nil
+	}
+
+	paused, err := s.subscriptionRepo.PauseSubscriptionsByEmail(ctx, email)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "unable to pause subscriptions of suppressed address", "email", email, "error", err)
+	} else if paused > 0 {
+		s.logger.InfoContext(ctx, "paused subscriptions of suppressed address", "email", email, "paused", paused)
+	}
+	return fmt.Errorf("%w: %s", domain.ErrEmailSuppressed, email)
+}
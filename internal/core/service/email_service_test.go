@@ -4,6 +4,7 @@
 package service_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"weather-api/internal/core/ports/out"
@@ -11,64 +12,91 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/service"
 	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
 )
 
 func TestEmailService_SendUpdates(t *testing.T) {
+	kyivUpdate := emailutil.WeatherUpdateEmailOptions{
+		City:        "Kyiv",
+		Temperature: 20.5,
+		Humidity:    60,
+		Description: "Sunny",
+		Token:       "token1",
+	}
+	lvivUpdate := emailutil.WeatherUpdateEmailOptions{
+		City:        "Lviv",
+		Temperature: 18.0,
+		Humidity:    65,
+		Description: "Cloudy",
+		Token:       "token2",
+	}
+
 	tests := []struct {
 		name        string
-		updates     []domain.WeatherUpdate
+		digests     []domain.WeatherDigest
 		setupMocks  func(emailSvc *mocks.MockEmailService)
 		verifyMocks func(t *testing.T, emailSvc *mocks.MockEmailService)
 	}{
 		{
-			name: "all emails sent successfully",
-			updates: []domain.WeatherUpdate{
+			name: "one email per subscriber combining all cities",
+			digests: []domain.WeatherDigest{
 				{
-					Subscription: domain.Subscription{
-						Email: "user1@example.com",
-						City:  &domain.City{Name: "Kyiv"},
-						Token: "token1",
+					Email:           "user1@example.com",
+					ManagementToken: "manage1",
+					Updates: []domain.WeatherUpdate{
+						{
+							Subscription: domain.Subscription{
+								Email: "user1@example.com",
+								City:  &domain.City{Name: "Kyiv"},
+								Token: "token1",
+							},
+							Weather: domain.Weather{Temperature: 20.5, Humidity: 60, Description: "Sunny"},
+						},
+						{
+							Subscription: domain.Subscription{
+								Email: "user1@example.com",
+								City:  &domain.City{Name: "Lviv"},
+								Token: "token2",
+							},
+							Weather: domain.Weather{Temperature: 18.0, Humidity: 65, Description: "Cloudy"},
+						},
 					},
-					Weather: domain.Weather{Temperature: 20.5, Humidity: 60, Description: "Sunny"},
 				},
 				{
-					Subscription: domain.Subscription{
-						Email: "user2@example.com",
-						City:  &domain.City{Name: "Lviv"},
-						Token: "token2",
+					Email: "user2@example.com",
+					Updates: []domain.WeatherUpdate{
+						{
+							Subscription: domain.Subscription{
+								Email: "user2@example.com",
+								City:  &domain.City{Name: "Lviv"},
+								Token: "token2",
+							},
+							Weather: domain.Weather{Temperature: 18.0, Humidity: 65, Description: "Cloudy"},
+						},
 					},
-					Weather: domain.Weather{Temperature: 18.0, Humidity: 65, Description: "Cloudy"},
 				},
 			},
 			setupMocks: func(es *mocks.MockEmailService) {
-				subKyiv, bodyKyiv := emailutil.BuildWeatherUpdateEmail(emailutil.WeatherUpdateEmailOptions{
-					City:        "Kyiv",
-					Temperature: 20.5,
-					Humidity:    60,
-					Description: "Sunny",
-					Token:       "token1",
+				subUser1, bodyUser1 := emailutil.BuildWeatherDigestEmail(emailutil.WeatherDigestEmailOptions{
+					Cities:          []emailutil.WeatherUpdateEmailOptions{kyivUpdate, lvivUpdate},
+					ManagementToken: "manage1",
 				})
-				subLviv, bodyLviv := emailutil.BuildWeatherUpdateEmail(emailutil.WeatherUpdateEmailOptions{
-					City:        "Lviv",
-					Temperature: 18.0,
-					Humidity:    65,
-					Description: "Cloudy",
-					Token:       "token2",
+				subUser2, bodyUser2 := emailutil.BuildWeatherDigestEmail(emailutil.WeatherDigestEmailOptions{
+					Cities: []emailutil.WeatherUpdateEmailOptions{lvivUpdate},
 				})
 
-				es.On("SendEmail", out.SendEmailOptions{
+				es.On("SendEmail", mock.Anything, out.SendEmailOptions{
 					To:      "user1@example.com",
-					Subject: subKyiv,
-					Body:    bodyKyiv,
+					Subject: subUser1,
+					Body:    bodyUser1,
 				}).Return(nil).Once()
-				es.On("SendEmail", out.SendEmailOptions{
+				es.On("SendEmail", mock.Anything, out.SendEmailOptions{
 					To:      "user2@example.com",
-					Subject: subLviv,
-					Body:    bodyLviv,
+					Subject: subUser2,
+					Body:    bodyUser2,
 				}).Return(nil).Once()
 			},
 			verifyMocks: func(t *testing.T, es *mocks.MockEmailService) {
@@ -77,10 +105,10 @@ func TestEmailService_SendUpdates(t *testing.T) {
 		},
 		{
 			name:       "no updates",
-			updates:    []domain.WeatherUpdate{},
+			digests:    []domain.WeatherDigest{},
 			setupMocks: func(*mocks.MockEmailService) {},
 			verifyMocks: func(t *testing.T, es *mocks.MockEmailService) {
-				es.AssertNotCalled(t, "SendEmail", mock.Anything)
+				es.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
 			},
 		},
 	}
@@ -90,9 +118,9 @@ func TestEmailService_SendUpdates(t *testing.T) {
 			emailMock := &mocks.MockEmailService{}
 			tt.setupMocks(emailMock)
 
-			s := service.NewEmailService(emailMock)
+			s := service.NewEmailService(emailMock, nil, nil, logger.Discard())
 
-			err := s.SendUpdates(tt.updates)
+			err := s.SendUpdates(context.Background(), tt.digests)
 			assert.NoError(t, err)
 
 			tt.verifyMocks(t, emailMock)
@@ -116,7 +144,7 @@ func TestEmailService_SendConfirmationEmail(t *testing.T) {
 			},
 			setupMocks: func(es *mocks.MockEmailService) {
 				subject, body := emailutil.BuildConfirmationEmail("Kyiv", "token123")
-				es.On("SendEmail", out.SendEmailOptions{
+				es.On("SendEmail", mock.Anything, out.SendEmailOptions{
 					To:      "user@example.com",
 					Subject: subject,
 					Body:    body,
@@ -133,7 +161,7 @@ func TestEmailService_SendConfirmationEmail(t *testing.T) {
 			},
 			setupMocks: func(es *mocks.MockEmailService) {
 				subject, body := emailutil.BuildConfirmationEmail("Kyiv", "token123")
-				es.On("SendEmail", out.SendEmailOptions{
+				es.On("SendEmail", mock.Anything, out.SendEmailOptions{
 					To:      "user@example.com",
 					Subject: subject,
 					Body:    body,
@@ -148,12 +176,46 @@ func TestEmailService_SendConfirmationEmail(t *testing.T) {
 			emailMock := &mocks.MockEmailService{}
 			tt.setupMocks(emailMock)
 
-			s := service.NewEmailService(emailMock)
+			s := service.NewEmailService(emailMock, nil, nil, logger.Discard())
 
-			err := s.SendConfirmationEmail(tt.subscription)
+			err := s.SendConfirmationEmail(context.Background(), tt.subscription)
 			assert.Equal(t, tt.expectErr, err)
 
 			emailMock.AssertExpectations(t)
 		})
 	}
 }
+
+func TestEmailService_SkipsSuppressedAddresses(t *testing.T) {
+	emailMock := &mocks.MockEmailService{}
+	suppressions := &mocks.MockSuppressionRepository{}
+	subscriptions := &mocks.MockSubscriptionRepository{}
+	suppressions.On("IsSuppressed", mock.Anything, "bounced@example.com").Return(true, nil)
+	subscriptions.On("PauseSubscriptionsByEmail", mock.Anything, "bounced@example.com").Return(int64(2), nil).Times(3)
+
+	s := service.NewEmailService(emailMock, suppressions, subscriptions, logger.Discard())
+	sub := &domain.Subscription{Email: "bounced@example.com", City: &domain.City{Name: "Kyiv"}, Token: "token"}
+
+	err := s.SendUpdates(context.Background(), []domain.WeatherDigest{{Email: "bounced@example.com"}})
+	assert.ErrorIs(t, err, domain.ErrEmailSuppressed)
+	assert.ErrorIs(t, s.SendConfirmationEmail(context.Background(), sub), domain.ErrEmailSuppressed)
+	assert.ErrorIs(t, s.SendAlert(context.Background(), *sub, domain.Alert{}), domain.ErrEmailSuppressed)
+
+	emailMock.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+	subscriptions.AssertExpectations(t)
+}
+
+func TestEmailService_SendsWhenSuppressionLookupFails(t *testing.T) {
+	emailMock := &mocks.MockEmailService{}
+	suppressions := &mocks.MockSuppressionRepository{}
+	subscriptions := &mocks.MockSubscriptionRepository{}
+	suppressions.On("IsSuppressed", mock.Anything, "user@example.com").Return(false, errors.New("db down"))
+	emailMock.On("SendEmail", mock.Anything, mock.Anything).Return(nil).Once()
+
+	s := service.NewEmailService(emailMock, suppressions, subscriptions, logger.Discard())
+	err := s.SendConfirmationEmail(context.Background(), &domain.Subscription{Email: "user@example.com", City: &domain.City{Name: "Kyiv"}, Token: "token"})
+
+	assert.NoError(t, err)
+	emailMock.AssertExpectations(t)
+	subscriptions.AssertNotCalled(t, "PauseSubscriptionsByEmail", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type HealthCheckOptions struct {
+	// Critical components make the service unready when they fail;
+	// failures of the others only degrade it.
+	Critical bool
+	// CacheTTL reuses the last result so frequent probes don't hit the dependency.
+	CacheTTL time.Duration
+}
+
+type registeredCheck struct {
+	check out.HealthCheck
+	opts  HealthCheckOptions
+
+	mu   sync.Mutex
+	last *domain.ComponentHealth
+}
+
+type HealthService struct {
+	checks  []*registeredCheck
+	timeout time.Duration
+	now     func() time.Time
+	logger  *slog.Logger
+}
+
+func NewHealthService(timeout time.Duration, logger *slog.Logger) *HealthService {
+	return &HealthService{
+		timeout: timeout,
+		now:     time.Now,
+		logger:  logger,
+	}
+}
+
+func (s *HealthService) Register(check out.HealthCheck, opts HealthCheckOptions) {
+	s.checks = append(s.checks, &registeredCheck{check: check, opts: opts})
+}
+
+func (s *HealthService) Liveness(context.Context) domain.HealthReport {
+	return domain.HealthReport{Status: domain.HealthStatusUp}
+}
+
+func (s *HealthService) Readiness(ctx context.Context) domain.HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	components := make([]domain.ComponentHealth, len(s.checks))
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func(i int, check *registeredCheck) {
+			defer wg.Done()
+			components[i] = s.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := domain.HealthReport{Status: domain.HealthStatusUp, Components: components}
+	for _, component := range components {
+		if component.Status == domain.HealthStatusUp {
+			continue
+		}
+		if component.Critical {
+			report.Status = domain.HealthStatusDown
+			break
+		}
+		report.Status = domain.HealthStatusDegraded
+	}
+	return report
+}
+
+func (s *HealthService) run(ctx context.Context, rc *registeredCheck) domain.ComponentHealth {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := s.now()
+	if rc.last != nil && now.Sub(rc.last.CheckedAt) < rc.opts.CacheTTL {
+		return *rc.last
+	}
+
+	err := rc.check.Check(ctx)
+	component := domain.ComponentHealth{
+		Name:      rc.check.Name(),
+		Status:    domain.HealthStatusUp,
+		Critical:  rc.opts.Critical,
+		Latency:   s.now().Sub(now),
+		CheckedAt: now,
+	}
+	if err != nil {
+		// Errors can carry hostnames and credentials, so they stay in the logs
+		// and probes only see the status.
+		component.Status = domain.HealthStatusDown
+		s.logger.WarnContext(ctx, "health check failed", "component", component.Name, "critical", component.Critical, "error", err)
+	}
+
+	rc.last = &component
+	return component
+}
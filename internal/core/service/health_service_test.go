@@ -0,0 +1,72 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockHealthCheck(name string, err error) *mocks.MockHealthCheck {
+	check := &mocks.MockHealthCheck{}
+	check.On("Name").Return(name)
+	check.On("Check", mock.Anything).Return(err)
+	return check
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		postgresErr error
+		redisErr    error
+		expected    domain.HealthStatus
+	}{
+		{name: "all components up", expected: domain.HealthStatusUp},
+		{name: "optional component down", redisErr: errors.New("connection refused"), expected: domain.HealthStatusDegraded},
+		{name: "critical component down", postgresErr: errors.New("connection refused"), expected: domain.HealthStatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewHealthService(time.Second, logger.Discard())
+			svc.Register(newMockHealthCheck("postgres", tt.postgresErr), HealthCheckOptions{Critical: true})
+			svc.Register(newMockHealthCheck("redis", tt.redisErr), HealthCheckOptions{})
+
+			report := svc.Readiness(context.Background())
+
+			assert.Equal(t, tt.expected, report.Status)
+			require.Len(t, report.Components, 2)
+			assert.Equal(t, "postgres", report.Components[0].Name)
+			assert.True(t, report.Components[0].Critical)
+		})
+	}
+}
+
+func TestHealthService_Readiness_CachesResults(t *testing.T) {
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	provider := newMockHealthCheck("provider:weatherapi", errors.New("quota exceeded"))
+
+	svc := NewHealthService(time.Second, logger.Discard())
+	svc.now = func() time.Time { return now }
+	svc.Register(provider, HealthCheckOptions{CacheTTL: 5 * time.Minute})
+
+	first := svc.Readiness(context.Background())
+	now = now.Add(time.Minute)
+	second := svc.Readiness(context.Background())
+	now = now.Add(5 * time.Minute)
+	svc.Readiness(context.Background())
+
+	assert.Equal(t, domain.HealthStatusDegraded, first.Status)
+	assert.Equal(t, first, second)
+	provider.AssertNumberOfCalls(t, "Check", 2)
+}
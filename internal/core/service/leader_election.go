@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type LeaderMetrics interface {
+	SetLeader(lock string, leader bool)
+	LeadershipLost(lock string)
+}
+
+type LeaderElectionOptions struct {
+	LockName   string
+	InstanceID string
+	TTL        time.Duration
+	Metrics    LeaderMetrics
+	Logger     *slog.Logger
+}
+
+// LeaderElector keeps a lease on a named lock and runs jobs only while it holds it.
+// Jobs receive a context that is cancelled as soon as the lease cannot be renewed,
+// so a former leader stops working before another instance takes over.
+type LeaderElector struct {
+	locker out.Locker
+	opts   LeaderElectionOptions
+
+	mu     sync.Mutex
+	lease  out.Lease
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewLeaderElector(locker out.Locker, opts LeaderElectionOptions) *LeaderElector {
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &LeaderElector{
+		locker: locker,
+		opts:   opts,
+	}
+}
+
+// Run campaigns for leadership until ctx is done, renewing the lease every third of its ttl.
+func (e *LeaderElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.opts.TTL / 3)
+	defer ticker.Stop()
+
+	for {
+		e.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *LeaderElector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lease != nil
+}
+
+// RunIfLeader runs job with the leadership context, or skips it on followers.
+func (e *LeaderElector) RunIfLeader(job func(ctx context.Context)) bool {
+	e.mu.Lock()
+	if e.lease == nil {
+		e.mu.Unlock()
+		return false
+	}
+	ctx := e.ctx
+	e.mu.Unlock()
+
+	e.opts.Logger.DebugContext(ctx, "running leader job", "instance", e.opts.InstanceID, "lock", e.opts.LockName)
+	job(ctx)
+	return true
+}
+
+func (e *LeaderElector) tick(ctx context.Context) {
+	e.mu.Lock()
+	lease := e.lease
+	e.mu.Unlock()
+
+	if lease != nil {
+		if err := lease.Renew(ctx); err != nil {
+			e.opts.Logger.WarnContext(ctx, "lost leadership", "instance", e.opts.InstanceID, "lock", e.opts.LockName, "error", err)
+			e.demote(lease)
+		}
+		return
+	}
+
+	lease, err := e.locker.TryAcquire(ctx, e.opts.LockName, e.opts.TTL)
+	if err != nil {
+		if !errors.Is(err, domain.ErrLockNotAcquired) {
+			e.opts.Logger.ErrorContext(ctx, "unable to acquire lock", "lock", e.opts.LockName, "error", err)
+		}
+		return
+	}
+
+	e.mu.Lock()
+	e.lease = lease
+	e.ctx, e.cancel = context.WithCancel(ctx)
+	e.mu.Unlock()
+
+	e.setLeader(true)
+	e.opts.Logger.InfoContext(ctx, "became leader", "instance", e.opts.InstanceID, "lock", e.opts.LockName)
+}
+
+// demote stops leader jobs after a failed renewal. The lease is still
+// released: a renewal can fail on a transient error while the lock is held,
+// and keeping it would block every instance, this one included, from leading.
+func (e *LeaderElector) demote(lease out.Lease) {
+	e.mu.Lock()
+	cancel := e.cancel
+	e.lease, e.ctx, e.cancel = nil, nil, nil
+	e.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	e.setLeader(false)
+	if e.opts.Metrics != nil {
+		e.opts.Metrics.LeadershipLost(e.opts.LockName)
+	}
+	e.release(lease, e.opts.TTL/3)
+}
+
+func (e *LeaderElector) resign() {
+	e.mu.Lock()
+	lease, cancel := e.lease, e.cancel
+	e.lease, e.ctx, e.cancel = nil, nil, nil
+	e.mu.Unlock()
+
+	if lease == nil {
+		return
+	}
+	cancel()
+	e.setLeader(false)
+	e.release(lease, e.opts.TTL)
+}
+
+// release gives up the lease on a fresh context, since the caller's may
+// already be cancelled.
+func (e *LeaderElector) release(lease out.Lease, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := lease.Release(ctx); err != nil {
+		e.opts.Logger.ErrorContext(ctx, "unable to release lock", "lock", e.opts.LockName, "error", err)
+	}
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	if e.opts.Metrics != nil {
+		e.opts.Metrics.SetLeader(e.opts.LockName, leader)
+	}
+}
@@ -0,0 +1,65 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type recordingLeaderMetrics struct {
+	leader bool
+	lost   int
+}
+
+func (m *recordingLeaderMetrics) SetLeader(_ string, leader bool) { m.leader = leader }
+func (m *recordingLeaderMetrics) LeadershipLost(string)           { m.lost++ }
+
+func TestLeaderElector_FollowerSkipsJobs(t *testing.T) {
+	ctx := context.Background()
+	locker := &mocks.MockLocker{}
+	locker.On("TryAcquire", ctx, "scheduler", 30*time.Second).Return(nil, domain.ErrLockNotAcquired)
+
+	elector := NewLeaderElector(locker, LeaderElectionOptions{LockName: "scheduler", TTL: 30 * time.Second})
+	elector.tick(ctx)
+
+	ran := elector.RunIfLeader(func(context.Context) { t.Fatal("follower must not run jobs") })
+
+	assert.False(t, ran)
+	assert.False(t, elector.IsLeader())
+}
+
+func TestLeaderElector_LosingLeaseCancelsRunningJob(t *testing.T) {
+	ctx := context.Background()
+	lease := &mocks.MockLease{}
+	lease.On("Renew", mock.Anything).Return(domain.ErrLockLost)
+	lease.On("Release", mock.Anything).Return(nil).Once()
+	locker := &mocks.MockLocker{}
+	locker.On("TryAcquire", ctx, "scheduler", 30*time.Second).Return(lease, nil).Once()
+	metrics := &recordingLeaderMetrics{}
+
+	elector := NewLeaderElector(locker, LeaderElectionOptions{LockName: "scheduler", TTL: 30 * time.Second, Metrics: metrics})
+	elector.tick(ctx)
+	assert.True(t, elector.IsLeader())
+	assert.True(t, metrics.leader)
+
+	var jobCtx context.Context
+	ran := elector.RunIfLeader(func(ctx context.Context) { jobCtx = ctx })
+	assert.True(t, ran)
+	assert.NoError(t, jobCtx.Err())
+
+	elector.tick(ctx)
+
+	assert.False(t, elector.IsLeader())
+	assert.ErrorIs(t, jobCtx.Err(), context.Canceled)
+	assert.False(t, metrics.leader)
+	assert.Equal(t, 1, metrics.lost)
+	lease.AssertExpectations(t)
+}
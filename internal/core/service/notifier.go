@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+// Notifiers routes each digest to the notifier of its delivery channel.
+// Digests of a channel that is switched off, such as push without VAPID keys,
+// are emailed instead so their subscriptions keep receiving updates. Telegram
+// subscriptions have no email address and fail with ErrChannelUnavailable.
+type Notifiers map[domain.Channel]out.Notifier
+
+func (n Notifiers) Notify(ctx context.Context, digest domain.WeatherDigest) error {
+	channel := digest.Channel
+	if channel == "" {
+		channel = domain.ChannelEmail
+	}
+	notifier, routed, ok := n.route(channel, digest.Email)
+	if !ok {
+		return fmt.Errorf("%w %s", domain.ErrChannelUnavailable, channel)
+	}
+	if routed != channel {
+		digest.Channel = routed
+	}
+	return notifier.Notify(ctx, digest)
+}
+
+// NotifyAlert routes a severe weather alert for subscription the same way
+// digests are routed.
+func (n Notifiers) NotifyAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error {
+	notifier, channel, ok := n.route(subscription.Channel(), subscription.Email)
+	if !ok {
+		return fmt.Errorf("%w %s", domain.ErrChannelUnavailable, channel)
+	}
+	alertNotifier, ok := notifier.(out.AlertNotifier)
+	if !ok {
+		return fmt.Errorf("%w %s", domain.ErrChannelUnavailable, channel)
+	}
+	return alertNotifier.NotifyAlert(ctx, subscription, alert)
+}
+
+// Recipient identifies where a message for subscription ends up, so that a
+// subscriber with several subscriptions in one city is notified once.
+func (n Notifiers) Recipient(subscription domain.Subscription) string {
+	_, channel, _ := n.route(subscription.Channel(), subscription.Email)
+	switch channel {
+	case domain.ChannelWebhook:
+		return "webhook:" + subscription.Webhook.URL
+	case domain.ChannelPush:
+		return "push:" + subscription.Push.Endpoint
+	default:
+		return string(channel) + ":" + strings.ToLower(subscription.Email)
+	}
+}
+
+// route returns the notifier for channel, or the email notifier when channel
+// is switched off and email is a real address.
+func (n Notifiers) route(channel domain.Channel, email string) (out.Notifier, domain.Channel, bool) {
+	if notifier, ok := n[channel]; ok {
+		return notifier, channel, true
+	}
+	if email != "" && !domain.IsTelegramAddress(email) {
+		if notifier, ok := n[domain.ChannelEmail]; ok {
+			return notifier, domain.ChannelEmail, true
+		}
+	}
+	return nil, channel, false
+}
+
+// EmailNotifier delivers digests as emails through the EmailService.
+type EmailNotifier struct {
+	emailService EmailService
+}
+
+func NewEmailNotifier(emailService EmailService) *EmailNotifier {
+	return &EmailNotifier{emailService: emailService}
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, digest domain.WeatherDigest) error {
+	return n.emailService.SendUpdates(ctx, []domain.WeatherDigest{digest})
+}
+
+func (n *EmailNotifier) NotifyAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error {
+	return n.emailService.SendAlert(ctx, subscription, alert)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+// PushNotifier delivers weather updates and alerts as browser push notifications.
+type PushNotifier struct {
+	sender   out.PushSender
+	pushRepo out.PushRepository
+	logger   *slog.Logger
+}
+
+func NewPushNotifier(sender out.PushSender, pushRepo out.PushRepository, logger *slog.Logger) *PushNotifier {
+	return &PushNotifier{sender: sender, pushRepo: pushRepo, logger: logger}
+}
+
+func (n *PushNotifier) Notify(ctx context.Context, digest domain.WeatherDigest) error {
+	for _, update := range digest.Updates {
+		if err := n.deliver(ctx, update.Subscription, pushNotification(update)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *PushNotifier) NotifyAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error {
+	return n.deliver(ctx, subscription, domain.PushNotification{
+		Title: fmt.Sprintf("%s in %s", alert.Event, subscription.City.Name),
+		Body:  alert.Headline,
+	})
+}
+
+func (n *PushNotifier) deliver(ctx context.Context, sub domain.Subscription, notification domain.PushNotification) error {
+	if sub.Push == nil {
+		return fmt.Errorf("subscription %d has no push subscription", sub.ID)
+	}
+
+	status, err := n.sender.Send(ctx, *sub.Push, notification)
+	if status == http.StatusNotFound || status == http.StatusGone {
+		// The browser unsubscribed or the endpoint expired; it will never work again.
+		if expireErr := n.pushRepo.ExpirePushSubscription(ctx, sub.ID); expireErr != nil {
+			n.logger.ErrorContext(ctx, "unable to expire push subscription", "subscription_id", sub.ID, "error", expireErr)
+		}
+		return fmt.Errorf("%w: subscription %d", domain.ErrPushSubscriptionExpired, sub.ID)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("unable to send push notification for subscription %d: %v", sub.ID, err)
+		n.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func pushNotification(update domain.WeatherUpdate) domain.PushNotification {
+	body := fmt.Sprintf("%.1f°C, humidity %d%%, %s",
+		update.Weather.Temperature, update.Weather.Humidity, update.Weather.Description)
+	if summary := update.Overnight; summary != nil {
+		body += fmt.Sprintf(". Overnight %.1f°C to %.1f°C", summary.MinTemperature, summary.MaxTemperature)
+	}
+	return domain.PushNotification{
+		Title: "Weather in " + update.Subscription.City.Name,
+		Body:  body,
+	}
+}
@@ -0,0 +1,72 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func pushDigest() domain.WeatherDigest {
+	return domain.WeatherDigest{Channel: domain.ChannelPush, Updates: []domain.WeatherUpdate{{
+		Subscription: domain.Subscription{
+			ID:   9,
+			City: &domain.City{Name: "Kyiv"},
+			Push: &domain.PushSubscription{Endpoint: "https://push.example.com/abc", P256dh: "key", Auth: "auth"},
+		},
+		Weather: domain.Weather{Temperature: 21.5, Humidity: 40, Description: "Sunny"},
+	}}}
+}
+
+func TestPushNotifier_SendsNotification(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockPushSender{}
+	repo := &mocks.MockPushRepository{}
+	digest := pushDigest()
+
+	sender.On("Send", ctx, *digest.Updates[0].Subscription.Push, domain.PushNotification{
+		Title: "Weather in Kyiv",
+		Body:  "21.5°C, humidity 40%, Sunny",
+	}).Return(201, nil).Once()
+
+	err := NewPushNotifier(sender, repo, logger.Discard()).Notify(ctx, digest)
+
+	assert.NoError(t, err)
+	sender.AssertExpectations(t)
+}
+
+func TestPushNotifier_ExpiresGoneSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockPushSender{}
+	repo := &mocks.MockPushRepository{}
+
+	sender.On("Send", ctx, mock.Anything, mock.Anything).Return(410, errors.New("gone")).Once()
+	repo.On("ExpirePushSubscription", ctx, int64(9)).Return(nil).Once()
+
+	err := NewPushNotifier(sender, repo, logger.Discard()).Notify(ctx, pushDigest())
+
+	assert.ErrorIs(t, err, domain.ErrPushSubscriptionExpired)
+	repo.AssertExpectations(t)
+}
+
+func TestPushNotifier_KeepsSubscriptionOnTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockPushSender{}
+	repo := &mocks.MockPushRepository{}
+
+	sender.On("Send", ctx, mock.Anything, mock.Anything).Return(503, errors.New("unavailable")).Once()
+
+	err := NewPushNotifier(sender, repo, logger.Discard()).Notify(ctx, pushDigest())
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrPushSubscriptionExpired)
+	repo.AssertNotCalled(t, "ExpirePushSubscription", mock.Anything, mock.Anything)
+}
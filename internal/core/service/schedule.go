@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/util/cronutil"
+)
+
+// Schedules holds the cron expressions used for the fixed frequencies.
+// Weekly must leave the day of week as "*"; it is filled in per subscription.
+type Schedules struct {
+	Hourly   string
+	Daily    string
+	Weekly   string
+	Weekdays string
+}
+
+func DefaultSchedules() Schedules {
+	return Schedules{
+		Hourly:   "0 * * * *",
+		Daily:    "0 0 * * *",
+		Weekly:   "0 0 * * *",
+		Weekdays: "0 0 * * 1-5",
+	}
+}
+
+func (s Schedules) CronSpec(frequency domain.Frequency, schedule domain.Schedule) (string, error) {
+	switch frequency {
+	case domain.FrequencyHourly:
+		return s.Hourly, nil
+	case domain.FrequencyDaily:
+		return s.Daily, nil
+	case domain.FrequencyWeekly:
+		return cronutil.WithWeekday(s.Weekly, schedule.Weekday)
+	case domain.FrequencyWeekdays:
+		return s.Weekdays, nil
+	case domain.FrequencyCustom:
+		return schedule.CronExpression, nil
+	}
+	return "", fmt.Errorf("unsupported frequency %q", frequency)
+}
+
+func (s Schedules) NextRunAt(frequency domain.Frequency, schedule domain.Schedule, after time.Time) (time.Time, error) {
+	if frequency == domain.FrequencyInterval {
+		return nextInterval(schedule.IntervalHours, after)
+	}
+	spec, err := s.CronSpec(frequency, schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cronutil.Next(spec, after)
+}
+
+// nextInterval counts N hours from the start of the hour of after. A cron
+// step such as "*/5" restarts at midnight, so it cannot express intervals
+// that do not divide 24.
+func nextInterval(hours int, after time.Time) (time.Time, error) {
+	if hours < 1 || hours > 23 {
+		return time.Time{}, fmt.Errorf("invalid interval of %d hours", hours)
+	}
+	hour := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), 0, 0, 0, after.Location())
+	return hour.Add(time.Duration(hours) * time.Hour), nil
+}
@@ -0,0 +1,112 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRunAt(t *testing.T) {
+	// Wednesday
+	after := time.Date(2025, 6, 11, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency domain.Frequency
+		schedule  domain.Schedule
+		expected  time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "hourly runs at the top of the next hour",
+			frequency: domain.FrequencyHourly,
+			expected:  time.Date(2025, 6, 11, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "daily runs at midnight",
+			frequency: domain.FrequencyDaily,
+			expected:  time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "weekly runs on the chosen weekday",
+			frequency: domain.FrequencyWeekly,
+			schedule:  domain.Schedule{Weekday: time.Monday},
+			expected:  time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "weekdays skip the weekend",
+			frequency: domain.FrequencyWeekdays,
+			expected:  time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "interval runs every N hours",
+			frequency: domain.FrequencyInterval,
+			schedule:  domain.Schedule{IntervalHours: 6},
+			expected:  time.Date(2025, 6, 11, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "interval carries over midnight",
+			frequency: domain.FrequencyInterval,
+			schedule:  domain.Schedule{IntervalHours: 17},
+			expected:  time.Date(2025, 6, 12, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "custom uses the cron expression",
+			frequency: domain.FrequencyCustom,
+			schedule:  domain.Schedule{CronExpression: "15 7 * * 6"},
+			expected:  time.Date(2025, 6, 14, 7, 15, 0, 0, time.UTC),
+		},
+		{
+			name:      "invalid interval",
+			frequency: domain.FrequencyInterval,
+			schedule:  domain.Schedule{IntervalHours: 0},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid custom expression",
+			frequency: domain.FrequencyCustom,
+			schedule:  domain.Schedule{CronExpression: "not a cron"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := DefaultSchedules().NextRunAt(tt.frequency, tt.schedule, after)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, next)
+		})
+	}
+}
+
+func TestSchedules_CustomConfiguration(t *testing.T) {
+	after := time.Date(2025, 6, 11, 10, 30, 0, 0, time.UTC)
+	schedules := Schedules{
+		Hourly:   "45 * * * *",
+		Daily:    "0 7 * * *",
+		Weekly:   "30 8 * * *",
+		Weekdays: "0 6 * * 1-5",
+	}
+
+	next, err := schedules.NextRunAt(domain.FrequencyHourly, domain.Schedule{}, after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 11, 10, 45, 0, 0, time.UTC), next)
+
+	next, err = schedules.NextRunAt(domain.FrequencyWeekly, domain.Schedule{Weekday: time.Friday}, after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 13, 8, 30, 0, 0, time.UTC), next)
+
+	schedules.Weekly = "0 0 * * 1"
+	_, err = schedules.NextRunAt(domain.FrequencyWeekly, domain.Schedule{Weekday: time.Friday}, after)
+	assert.Error(t, err)
+}
@@ -4,39 +4,265 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
 )
 
 type WeatherUpdateService interface {
-	PrepareUpdates(ctx context.Context, frequency domain.Frequency) ([]domain.WeatherUpdate, error)
+	PrepareUpdates(ctx context.Context, frequency domain.Frequency) ([]domain.WeatherDigest, error)
+	PrepareDueUpdates(ctx context.Context, now time.Time) (domain.DueUpdates, error)
 }
 
 type SchedulerService struct {
 	weatherUpdateService WeatherUpdateService
-	emailService         EmailService
+	notifiers            Notifiers
+	subscriptionRepo     out.SubscriptionRepository
+	deliveryRepo         out.DeliveryRepository
+	schedules            Schedules
+	metrics              out.BusinessMetrics
+	logger               *slog.Logger
 }
 
-func NewSchedulerService(weatherUpdateService WeatherUpdateService, emailService EmailService) *SchedulerService {
+func NewSchedulerService(
+	weatherUpdateService WeatherUpdateService,
+	notifiers Notifiers,
+	subscriptionRepo out.SubscriptionRepository,
+	deliveryRepo out.DeliveryRepository,
+	schedules Schedules,
+	metrics out.BusinessMetrics,
+	logger *slog.Logger,
+) *SchedulerService {
+	if metrics == nil {
+		metrics = out.NopBusinessMetrics{}
+	}
 	return &SchedulerService{
 		weatherUpdateService: weatherUpdateService,
-		emailService:         emailService,
+		notifiers:            notifiers,
+		subscriptionRepo:     subscriptionRepo,
+		deliveryRepo:         deliveryRepo,
+		schedules:            schedules,
+		metrics:              metrics,
+		logger:               logger,
 	}
 }
 
-func (s *SchedulerService) SendWeatherUpdates(ctx context.Context, frequency domain.Frequency) error {
+func (s *SchedulerService) SendWeatherUpdates(ctx context.Context, frequency domain.Frequency) (err error) {
+	now := time.Now()
+	defer s.timeRun("updates_"+string(frequency), now, &err)
+
 	updates, err := s.weatherUpdateService.PrepareUpdates(ctx, frequency)
 	if err != nil {
 		msg := fmt.Sprintf("unable to prepare updates for frequency %s: %v", frequency, err)
-		log.Print(msg)
+		s.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
-	if err := s.emailService.SendUpdates(updates); err != nil {
-		msg := fmt.Sprintf("unable to send updates for frequency %s: %v", frequency, err)
-		log.Print(msg)
+	for _, digest := range updates {
+		if err := ctx.Err(); err != nil {
+			msg := fmt.Sprintf("stopped sending updates for frequency %s: %v", frequency, err)
+			s.logger.ErrorContext(ctx, msg)
+			return errors.New(msg)
+		}
+		err := s.notifiers.Notify(ctx, digest)
+		if errors.Is(err, domain.ErrEmailSuppressed) {
+			s.recordDeliveries(ctx, digest.Updates, domain.DeliveryStatusSuppressed, err)
+			continue
+		}
+		if err != nil {
+			s.recordDeliveries(ctx, digest.Updates, domain.DeliveryStatusFailed, err)
+			// A failing webhook or chat only affects its own subscription, while
+			// an email failure usually means the sender itself is down.
+			if !isEmailDigest(digest) {
+				s.logger.ErrorContext(ctx, "unable to deliver update", "channel", digest.Channel, "frequency", frequency, "error", err)
+				continue
+			}
+			msg := fmt.Sprintf("unable to send updates for frequency %s: %v", frequency, err)
+			s.logger.ErrorContext(ctx, msg)
+			return errors.New(msg)
+		}
+		s.recordDeliveries(ctx, digest.Updates, domain.DeliveryStatusSent, nil)
+		s.countSent(digest)
+		for _, update := range digest.Updates {
+			s.reschedule(ctx, update.Subscription, now)
+		}
+	}
+
+	return nil
+}
+
+// DryRunWeatherUpdates prepares and renders the updates SendWeatherUpdates would
+// send by email, but writes them to sink instead of the email sender. Webhook
+// and Telegram digests are skipped. Deliveries are not recorded and subscriptions are not
+// rescheduled.
+func (s *SchedulerService) DryRunWeatherUpdates(ctx context.Context, frequency domain.Frequency, sink out.EmailSender) (domain.DryRunSummary, error) {
+	summary := domain.DryRunSummary{Frequency: frequency}
+
+	updates, err := s.weatherUpdateService.PrepareUpdates(ctx, frequency)
+	if err != nil {
+		msg := fmt.Sprintf("unable to prepare updates for frequency %s: %v", frequency, err)
+		s.logger.ErrorContext(ctx, msg)
+		return summary, errors.New(msg)
+	}
+
+	// Rendering only: the suppression list is not consulted so nothing is paused.
+	renderer := &EmailServiceImpl{emailSvc: sink, logger: s.logger}
+	for _, digest := range updates {
+		if !isEmailDigest(digest) {
+			continue
+		}
+		if err := renderer.SendUpdates(ctx, []domain.WeatherDigest{digest}); err != nil {
+			summary.Failed++
+			continue
+		}
+		summary.Emails++
+		summary.Updates += len(digest.Updates)
+		summary.Recipients = append(summary.Recipients, digest.Email)
+	}
+
+	s.logger.InfoContext(ctx, "dry run rendered",
+		"frequency", frequency, "emails", summary.Emails, "updates", summary.Updates, "failed", summary.Failed)
+	return summary, nil
+}
+
+func (s *SchedulerService) SendDueUpdates(ctx context.Context, now time.Time) (err error) {
+	defer s.timeRun("due_updates", time.Now(), &err)
+
+	due, err := s.weatherUpdateService.PrepareDueUpdates(ctx, now)
+	if err != nil {
+		msg := fmt.Sprintf("unable to prepare updates due at %s: %v", now.Format(time.RFC3339), err)
+		s.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
+	for _, sub := range due.Deferred {
+		s.postpone(ctx, sub, now)
+		s.recordDelivery(ctx, sub.ID, domain.DeliveryStatusDeferred, nil)
+	}
+
+	for _, digest := range due.Digests {
+		if err := ctx.Err(); err != nil {
+			msg := fmt.Sprintf("stopped sending due updates: %v", err)
+			s.logger.ErrorContext(ctx, msg)
+			return errors.New(msg)
+		}
+		err := s.notifiers.Notify(ctx, digest)
+		if errors.Is(err, domain.ErrEmailSuppressed) {
+			s.recordDeliveries(ctx, digest.Updates, domain.DeliveryStatusSuppressed, err)
+			continue
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "unable to send due updates", "channel", digest.Channel, "error", err)
+			s.recordDeliveries(ctx, digest.Updates, domain.DeliveryStatusFailed, err)
+			// Transient failures are retried on the next dispatch; errors that
+			// will never succeed skip to the next scheduled run instead.
+			if isPermanentDeliveryError(err) {
+				for _, update := range digest.Updates {
+					s.postpone(ctx, update.Subscription, now)
+				}
+			}
+			continue
+		}
+
+		s.recordDeliveries(ctx, digest.Updates, domain.DeliveryStatusSent, nil)
+		s.countSent(digest)
+		for _, update := range digest.Updates {
+			s.reschedule(ctx, update.Subscription, now)
+		}
+	}
+
 	return nil
 }
+
+func (s *SchedulerService) postpone(ctx context.Context, sub domain.Subscription, now time.Time) {
+	nextRunAt, err := s.nextRunOutsideQuietHours(sub, now.In(sub.Location()))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "unable to compute next run", "subscription_id", sub.ID, "error", err)
+		return
+	}
+
+	if err := s.subscriptionRepo.UpdateNextRun(ctx, sub.ID, nextRunAt); err != nil {
+		s.logger.ErrorContext(ctx, "unable to postpone subscription", "subscription_id", sub.ID, "error", err)
+	}
+}
+
+// nextRunOutsideQuietHours returns the next scheduled run. A schedule that
+// has no run between the end of the quiet window and its next start, such as
+// daily at 00:00 with quiet hours 22:00-07:00, would be deferred forever, so
+// it runs when the quiet window ends instead. Schedules that do leave the
+// window keep their runs inside it, where summary observations are recorded.
+func (s *SchedulerService) nextRunOutsideQuietHours(sub domain.Subscription, after time.Time) (time.Time, error) {
+	nextRunAt, err := s.schedules.NextRunAt(sub.Frequency, sub.Schedule, after)
+	if err != nil || !sub.InQuietHours(nextRunAt) {
+		return nextRunAt, err
+	}
+
+	quietEnd := sub.QuietHours.EndAfter(after)
+	afterQuiet, err := s.schedules.NextRunAt(sub.Frequency, sub.Schedule, quietEnd.Add(-time.Second))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if sub.InQuietHours(afterQuiet) {
+		return quietEnd, nil
+	}
+	return nextRunAt, nil
+}
+
+func (s *SchedulerService) reschedule(ctx context.Context, sub domain.Subscription, now time.Time) {
+	nextRunAt, err := s.schedules.NextRunAt(sub.Frequency, sub.Schedule, now.In(sub.Location()))
+	if err != nil {
+		// A zero next run would clear next_run_at and drop the subscription
+		// from dispatch for good; leave it as is so the problem stays visible.
+		s.logger.ErrorContext(ctx, "unable to compute next run", "subscription_id", sub.ID, "error", err)
+		return
+	}
+
+	if err := s.subscriptionRepo.MarkSent(ctx, out.MarkSentOptions{
+		SubscriptionID: sub.ID,
+		SentAt:         now,
+		NextRunAt:      nextRunAt,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "unable to reschedule subscription", "subscription_id", sub.ID, "error", err)
+	}
+}
+
+func (s *SchedulerService) recordDeliveries(ctx context.Context, updates []domain.WeatherUpdate, status domain.DeliveryStatus, sendErr error) {
+	for _, update := range updates {
+		s.recordDelivery(ctx, update.Subscription.ID, status, sendErr)
+	}
+}
+
+func (s *SchedulerService) recordDelivery(ctx context.Context, subscriptionID int64, status domain.DeliveryStatus, sendErr error) {
+	delivery := domain.Delivery{SubscriptionID: subscriptionID, Status: status}
+	if sendErr != nil {
+		delivery.Error = sendErr.Error()
+	}
+	if err := s.deliveryRepo.RecordDelivery(ctx, delivery); err != nil {
+		s.logger.ErrorContext(ctx, "unable to record delivery", "status", status, "subscription_id", subscriptionID, "error", err)
+	}
+}
+
+func (s *SchedulerService) timeRun(job string, start time.Time, err *error) {
+	s.metrics.SchedulerRun(job, time.Since(start), *err)
+}
+
+func (s *SchedulerService) countSent(digest domain.WeatherDigest) {
+	channel := digest.Channel
+	if channel == "" {
+		channel = domain.ChannelEmail
+	}
+	for _, update := range digest.Updates {
+		s.metrics.UpdateSent(update.Subscription.Frequency, channel)
+	}
+}
+
+func isPermanentDeliveryError(err error) bool {
+	return errors.Is(err, domain.ErrWebhookDisabled) ||
+		errors.Is(err, domain.ErrPushSubscriptionExpired) ||
+		errors.Is(err, domain.ErrChannelUnavailable)
+}
+
+func isEmailDigest(digest domain.WeatherDigest) bool {
+	return digest.Channel == "" || digest.Channel == domain.ChannelEmail
+}
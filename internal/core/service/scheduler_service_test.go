@@ -0,0 +1,316 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWeatherUpdateService struct{ mock.Mock }
+
+func (m *MockWeatherUpdateService) PrepareUpdates(ctx context.Context, frequency domain.Frequency) ([]domain.WeatherDigest, error) {
+	args := m.Called(ctx, frequency)
+	return args.Get(0).([]domain.WeatherDigest), args.Error(1)
+}
+
+func (m *MockWeatherUpdateService) PrepareDueUpdates(ctx context.Context, now time.Time) (domain.DueUpdates, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(domain.DueUpdates), args.Error(1)
+}
+
+type recordingBusinessMetrics struct {
+	out.NopBusinessMetrics
+	sent []string
+	runs []string
+}
+
+func (m *recordingBusinessMetrics) UpdateSent(frequency domain.Frequency, channel domain.Channel) {
+	m.sent = append(m.sent, string(frequency)+"/"+string(channel))
+}
+
+func (m *recordingBusinessMetrics) SchedulerRun(job string, _ time.Duration, _ error) {
+	m.runs = append(m.runs, job)
+}
+
+func TestSchedulerService_SendDueUpdates(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	hourly := domain.Subscription{ID: 1, Email: "a@example.com", City: &domain.City{Name: "Kyiv"}, Frequency: domain.FrequencyHourly}
+	weekly := domain.Subscription{ID: 2, Email: "b@example.com", City: &domain.City{Name: "Lviv"}, Frequency: domain.FrequencyWeekly, Schedule: domain.Schedule{Weekday: time.Friday}}
+	digestA := domain.WeatherDigest{Email: "a@example.com", Updates: []domain.WeatherUpdate{{Subscription: hourly}}}
+	digestB := domain.WeatherDigest{Email: "b@example.com", Updates: []domain.WeatherUpdate{{Subscription: weekly}}}
+
+	updates := &MockWeatherUpdateService{}
+	email := &MockEmailNotifier{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	deliveries.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+
+	updates.On("PrepareDueUpdates", ctx, now).Return(domain.DueUpdates{Digests: []domain.WeatherDigest{digestA, digestB}}, nil)
+	email.On("SendUpdates", mock.Anything, []domain.WeatherDigest{digestA}).Return(nil)
+	email.On("SendUpdates", mock.Anything, []domain.WeatherDigest{digestB}).Return(errors.New("smtp down"))
+	repo.On("MarkSent", ctx, out.MarkSentOptions{
+		SubscriptionID: 1,
+		SentAt:         now,
+		NextRunAt:      time.Date(2025, 6, 11, 11, 0, 0, 0, time.UTC),
+	}).Return(nil).Once()
+
+	metrics := &recordingBusinessMetrics{}
+	svc := NewSchedulerService(updates, Notifiers{domain.ChannelEmail: NewEmailNotifier(email)}, repo, deliveries, DefaultSchedules(), metrics, logger.Discard())
+	err := svc.SendDueUpdates(ctx, now)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "MarkSent", ctx, mock.MatchedBy(func(opts out.MarkSentOptions) bool { return opts.SubscriptionID == 2 }))
+	deliveries.AssertCalled(t, "RecordDelivery", ctx, domain.Delivery{SubscriptionID: 1, Status: domain.DeliveryStatusSent})
+	deliveries.AssertCalled(t, "RecordDelivery", ctx, domain.Delivery{SubscriptionID: 2, Status: domain.DeliveryStatusFailed, Error: "smtp down"})
+	assert.Equal(t, []string{"hourly/email"}, metrics.sent)
+	assert.Equal(t, []string{"due_updates"}, metrics.runs)
+}
+
+func TestSchedulerService_SendDueUpdates_PostponesQuietSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 11, 23, 0, 0, 0, time.UTC)
+	quiet := domain.Subscription{ID: 3, Email: "c@example.com", Frequency: domain.FrequencyHourly, QuietHours: domain.QuietHours{Start: 22 * 60, End: 7 * 60}}
+
+	updates := &MockWeatherUpdateService{}
+	email := &MockEmailNotifier{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	deliveries.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+
+	updates.On("PrepareDueUpdates", ctx, now).Return(domain.DueUpdates{Deferred: []domain.Subscription{quiet}}, nil)
+	repo.On("UpdateNextRun", ctx, int64(3), time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)).Return(nil).Once()
+
+	svc := NewSchedulerService(updates, Notifiers{domain.ChannelEmail: NewEmailNotifier(email)}, repo, deliveries, DefaultSchedules(), nil, logger.Discard())
+	err := svc.SendDueUpdates(ctx, now)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything)
+	email.AssertNotCalled(t, "SendUpdates", mock.Anything, mock.Anything)
+}
+
+func TestSchedulerService_SendDueUpdates_MovesDailyRunsOutOfQuietHours(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	quiet := domain.Subscription{ID: 5, Email: "e@example.com", Frequency: domain.FrequencyDaily, QuietHours: domain.QuietHours{Start: 22 * 60, End: 7 * 60}}
+
+	updates := &MockWeatherUpdateService{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	deliveries.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+
+	updates.On("PrepareDueUpdates", ctx, now).Return(domain.DueUpdates{Deferred: []domain.Subscription{quiet}}, nil)
+	// The next midnight is quiet as well, so the update goes out when the window ends.
+	repo.On("UpdateNextRun", ctx, int64(5), time.Date(2025, 6, 12, 7, 0, 0, 0, time.UTC)).Return(nil).Once()
+
+	svc := NewSchedulerService(updates, Notifiers{}, repo, deliveries, DefaultSchedules(), nil, logger.Discard())
+	err := svc.SendDueUpdates(ctx, now)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestSchedulerService_SendWeatherUpdates_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	digestA := domain.WeatherDigest{Email: "a@example.com"}
+	digestB := domain.WeatherDigest{Email: "b@example.com"}
+
+	updates := &MockWeatherUpdateService{}
+	email := &MockEmailNotifier{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	deliveries.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+
+	updates.On("PrepareUpdates", ctx, domain.FrequencyDaily).Return([]domain.WeatherDigest{digestA, digestB}, nil)
+	email.On("SendUpdates", mock.Anything, []domain.WeatherDigest{digestA}).Run(func(mock.Arguments) { cancel() }).Return(nil).Once()
+
+	svc := NewSchedulerService(updates, Notifiers{domain.ChannelEmail: NewEmailNotifier(email)}, repo, deliveries, DefaultSchedules(), nil, logger.Discard())
+	err := svc.SendWeatherUpdates(ctx, domain.FrequencyDaily)
+
+	assert.Error(t, err)
+	email.AssertExpectations(t)
+	email.AssertNotCalled(t, "SendUpdates", mock.Anything, []domain.WeatherDigest{digestB})
+}
+
+func TestSchedulerService_DryRunWeatherUpdates(t *testing.T) {
+	ctx := context.Background()
+	city := &domain.City{Name: "Kyiv"}
+	digestA := domain.WeatherDigest{Email: "a@example.com", Updates: []domain.WeatherUpdate{
+		{Subscription: domain.Subscription{ID: 1, City: city}, Weather: domain.Weather{Temperature: 20}},
+		{Subscription: domain.Subscription{ID: 2, City: &domain.City{Name: "Lviv"}}},
+	}}
+	digestB := domain.WeatherDigest{Email: "b@example.com", Updates: []domain.WeatherUpdate{
+		{Subscription: domain.Subscription{ID: 3, City: city}},
+	}}
+
+	updates := &MockWeatherUpdateService{}
+	email := &MockEmailNotifier{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	sink := &mocks.MockEmailService{}
+
+	updates.On("PrepareUpdates", ctx, domain.FrequencyDaily).Return([]domain.WeatherDigest{digestA, digestB}, nil)
+	sink.On("SendEmail", mock.Anything, mock.MatchedBy(func(opts out.SendEmailOptions) bool { return opts.To == "a@example.com" })).Return(nil).Once()
+	sink.On("SendEmail", mock.Anything, mock.MatchedBy(func(opts out.SendEmailOptions) bool { return opts.To == "b@example.com" })).Return(errors.New("disk full")).Once()
+
+	svc := NewSchedulerService(updates, Notifiers{domain.ChannelEmail: NewEmailNotifier(email)}, repo, deliveries, DefaultSchedules(), nil, logger.Discard())
+	summary, err := svc.DryRunWeatherUpdates(ctx, domain.FrequencyDaily, sink)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DryRunSummary{
+		Frequency:  domain.FrequencyDaily,
+		Emails:     1,
+		Updates:    2,
+		Failed:     1,
+		Recipients: []string{"a@example.com"},
+	}, summary)
+	sink.AssertExpectations(t)
+	email.AssertNotCalled(t, "SendUpdates", mock.Anything, mock.Anything)
+	deliveries.AssertNotCalled(t, "RecordDelivery", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything)
+}
+
+func TestSchedulerService_SendDueUpdates_RecordsSuppressedAddresses(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	suppressed := domain.WeatherDigest{Email: "bounced@example.com", Updates: []domain.WeatherUpdate{
+		{Subscription: domain.Subscription{ID: 1, Frequency: domain.FrequencyHourly}},
+	}}
+	delivered := domain.WeatherDigest{Email: "user@example.com", Updates: []domain.WeatherUpdate{
+		{Subscription: domain.Subscription{ID: 2, Frequency: domain.FrequencyHourly}},
+	}}
+	suppressedErr := fmt.Errorf("%w: bounced@example.com", domain.ErrEmailSuppressed)
+
+	updates := &MockWeatherUpdateService{}
+	email := &MockEmailNotifier{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	deliveries.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+
+	updates.On("PrepareDueUpdates", ctx, now).Return(domain.DueUpdates{Digests: []domain.WeatherDigest{suppressed, delivered}}, nil)
+	email.On("SendUpdates", mock.Anything, []domain.WeatherDigest{suppressed}).Return(suppressedErr)
+	email.On("SendUpdates", mock.Anything, []domain.WeatherDigest{delivered}).Return(nil)
+	repo.On("MarkSent", ctx, mock.MatchedBy(func(opts out.MarkSentOptions) bool { return opts.SubscriptionID == 2 })).Return(nil).Once()
+
+	svc := NewSchedulerService(updates, Notifiers{domain.ChannelEmail: NewEmailNotifier(email)}, repo, deliveries, DefaultSchedules(), nil, logger.Discard())
+	err := svc.SendDueUpdates(ctx, now)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	deliveries.AssertCalled(t, "RecordDelivery", ctx, domain.Delivery{
+		SubscriptionID: 1,
+		Status:         domain.DeliveryStatusSuppressed,
+		Error:          suppressedErr.Error(),
+	})
+	deliveries.AssertCalled(t, "RecordDelivery", ctx, domain.Delivery{SubscriptionID: 2, Status: domain.DeliveryStatusSent})
+}
+
+type notifierFunc func(ctx context.Context, digest domain.WeatherDigest) error
+
+func (f notifierFunc) Notify(ctx context.Context, digest domain.WeatherDigest) error {
+	return f(ctx, digest)
+}
+
+func TestSchedulerService_SendDueUpdates_PostponesPermanentFailures(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	sub := domain.Subscription{ID: 3, Frequency: domain.FrequencyHourly, Webhook: &domain.Webhook{URL: "https://example.com/hook"}}
+	digest := domain.WeatherDigest{Channel: domain.ChannelWebhook, Updates: []domain.WeatherUpdate{{Subscription: sub}}}
+	disabledErr := fmt.Errorf("%w: subscription 3", domain.ErrWebhookDisabled)
+
+	updates := &MockWeatherUpdateService{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	deliveries.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+	updates.On("PrepareDueUpdates", ctx, now).Return(domain.DueUpdates{Digests: []domain.WeatherDigest{digest}}, nil)
+	repo.On("UpdateNextRun", ctx, int64(3), time.Date(2025, 6, 11, 11, 0, 0, 0, time.UTC)).Return(nil).Once()
+
+	notifiers := Notifiers{domain.ChannelWebhook: notifierFunc(func(context.Context, domain.WeatherDigest) error { return disabledErr })}
+	svc := NewSchedulerService(updates, notifiers, repo, deliveries, DefaultSchedules(), nil, logger.Discard())
+	err := svc.SendDueUpdates(ctx, now)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything)
+	deliveries.AssertCalled(t, "RecordDelivery", ctx, domain.Delivery{SubscriptionID: 3, Status: domain.DeliveryStatusFailed, Error: disabledErr.Error()})
+}
+
+func TestSchedulerService_SendDueUpdates_KeepsNextRunWhenScheduleIsInvalid(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	sub := domain.Subscription{ID: 4, Email: "d@example.com", Frequency: domain.FrequencyCustom, Schedule: domain.Schedule{CronExpression: "not a cron"}}
+	digest := domain.WeatherDigest{Email: "d@example.com", Updates: []domain.WeatherUpdate{{Subscription: sub}}}
+
+	updates := &MockWeatherUpdateService{}
+	email := &MockEmailNotifier{}
+	repo := &mocks.MockSubscriptionRepository{}
+	deliveries := &mocks.MockDeliveryRepository{}
+	deliveries.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+	updates.On("PrepareDueUpdates", ctx, now).Return(domain.DueUpdates{Digests: []domain.WeatherDigest{digest}}, nil)
+	email.On("SendUpdates", mock.Anything, []domain.WeatherDigest{digest}).Return(nil)
+
+	svc := NewSchedulerService(updates, Notifiers{domain.ChannelEmail: NewEmailNotifier(email)}, repo, deliveries, DefaultSchedules(), nil, logger.Discard())
+	err := svc.SendDueUpdates(ctx, now)
+
+	assert.NoError(t, err)
+	repo.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything)
+	deliveries.AssertCalled(t, "RecordDelivery", ctx, domain.Delivery{SubscriptionID: 4, Status: domain.DeliveryStatusSent})
+}
+
+func TestSubscription_ChannelSkipsDisabledWebhook(t *testing.T) {
+	disabledAt := time.Now()
+	sub := domain.Subscription{Webhook: &domain.Webhook{URL: "https://example.com/hook", DisabledAt: &disabledAt}}
+
+	assert.Equal(t, domain.ChannelEmail, sub.Channel())
+}
+
+func TestNotifiers_FallsBackToEmailForDisabledChannels(t *testing.T) {
+	ctx := context.Background()
+	push := domain.WeatherDigest{Channel: domain.ChannelPush, Email: "user@example.com", Updates: []domain.WeatherUpdate{
+		{Subscription: domain.Subscription{ID: 4, Email: "user@example.com"}},
+	}}
+
+	var emailed domain.WeatherDigest
+	notifiers := Notifiers{domain.ChannelEmail: notifierFunc(func(_ context.Context, digest domain.WeatherDigest) error {
+		emailed = digest
+		return nil
+	})}
+
+	assert.NoError(t, notifiers.Notify(ctx, push))
+	assert.Equal(t, domain.ChannelEmail, emailed.Channel)
+	assert.Equal(t, "user@example.com", emailed.Email)
+
+	err := Notifiers{}.Notify(ctx, push)
+	assert.ErrorIs(t, err, domain.ErrChannelUnavailable)
+}
+
+func TestNotifiers_DoesNotEmailTelegramAddresses(t *testing.T) {
+	ctx := context.Background()
+	telegram := domain.WeatherDigest{Channel: domain.ChannelTelegram, Email: domain.TelegramAddress(42), Updates: []domain.WeatherUpdate{
+		{Subscription: domain.Subscription{ID: 5, Email: domain.TelegramAddress(42), TelegramChatID: 42}},
+	}}
+
+	emailed := false
+	notifiers := Notifiers{domain.ChannelEmail: notifierFunc(func(context.Context, domain.WeatherDigest) error {
+		emailed = true
+		return nil
+	})}
+
+	err := notifiers.Notify(ctx, telegram)
+	assert.ErrorIs(t, err, domain.ErrChannelUnavailable)
+	assert.False(t, emailed)
+}
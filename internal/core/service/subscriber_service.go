@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type SubscriberServiceImpl struct {
+	subscriberRepo out.SubscriberRepository
+	tokenSvc       TokenService
+	logger         *slog.Logger
+}
+
+func NewSubscriberService(
+	subscriberRepo out.SubscriberRepository,
+	tokenSvc TokenService,
+	logger *slog.Logger,
+) *SubscriberServiceImpl {
+	return &SubscriberServiceImpl{
+		subscriberRepo: subscriberRepo,
+		tokenSvc:       tokenSvc,
+		logger:         logger,
+	}
+}
+
+func (s *SubscriberServiceImpl) EnsureSubscriberExists(ctx context.Context, email string) (domain.Subscriber, error) {
+	subscriber, err := s.subscriberRepo.GetByEmail(ctx, email)
+	if err == nil {
+		return subscriber, nil
+	}
+
+	if !errors.Is(err, domain.ErrSubscriberNotFound) {
+		return domain.Subscriber{}, fmt.Errorf("unable to get subscriber %s: %w", email, err)
+	}
+
+	token, err := s.tokenSvc.GenerateToken()
+	if err != nil {
+		msg := fmt.Sprintf("unable to generate management token: %v", err)
+		s.logger.ErrorContext(ctx, msg)
+		return domain.Subscriber{}, errors.New(msg)
+	}
+
+	subscriber, err = s.subscriberRepo.Create(ctx, domain.Subscriber{
+		Email: email,
+		Token: token,
+	})
+	if err != nil {
+		return domain.Subscriber{}, fmt.Errorf("unable to create subscriber %s: %w", email, err)
+	}
+
+	s.logger.InfoContext(ctx, "created subscriber", "subscriber_id", subscriber.ID)
+	return subscriber, nil
+}
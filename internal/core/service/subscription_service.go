@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 )
@@ -19,6 +20,7 @@ type SubscriptionServiceImpl struct {
 	tokenSvc      TokenService
 	cityRepo      out.CityRepository
 	emailService  EmailService
+	logger        *slog.Logger
 }
 
 func NewSubscriptionService(
@@ -27,6 +29,7 @@ func NewSubscriptionService(
 	weatherClient out.WeatherProvider,
 	tokenSvc TokenService,
 	emailService EmailService,
+	logger *slog.Logger,
 ) *SubscriptionServiceImpl {
 	return &SubscriptionServiceImpl{
 		repo:          repo,
@@ -34,28 +37,35 @@ func NewSubscriptionService(
 		weatherClient: weatherClient,
 		tokenSvc:      tokenSvc,
 		emailService:  emailService,
+		logger:        logger,
 	}
 }
 
-func (s *SubscriptionServiceImpl) CreateSubscription(ctx context.Context, email string, cityID int64, frequency domain.Frequency) (string, error) {
+func (s *SubscriptionServiceImpl) CreateSubscription(ctx context.Context, opts out.CreateSubscriptionOptions) (string, error) {
 	token, err := s.tokenSvc.GenerateToken()
 	if err != nil {
 		msg := fmt.Sprintf("unable to generate token: %v", err)
-		log.Print(msg)
+		s.logger.ErrorContext(ctx, msg)
 		return "", errors.New(msg)
 	}
 
 	subscription := domain.Subscription{
-		Email:       email,
-		CityID:      cityID,
-		Frequency:   frequency,
-		Token:       token,
-		IsConfirmed: false,
+		SubscriberID:   opts.SubscriberID,
+		Email:          opts.Email,
+		CityID:         opts.CityID,
+		Frequency:      opts.Frequency,
+		Schedule:       opts.Schedule,
+		Timezone:       opts.Timezone,
+		QuietHours:     opts.QuietHours,
+		Token:          token,
+		IsConfirmed:    false,
+		SevereAlerts:   opts.SevereAlerts,
+		TelegramChatID: opts.TelegramChatID,
 	}
 
 	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
 		msg := fmt.Sprintf("unable to create subscription in repository: %v", err)
-		log.Print(msg)
+		s.logger.ErrorContext(ctx, msg)
 		return "", errors.New(msg)
 	}
 
@@ -66,9 +76,20 @@ func (s *SubscriptionServiceImpl) GetSubscriptionsByFrequency(ctx context.Contex
 	subscriptions, err := s.repo.GetSubscriptionsByFrequency(ctx, string(frequency))
 	if err != nil {
 		msg := fmt.Sprintf("unable to get subscriptions by frequency %s: %v", frequency, err)
-		log.Print(msg)
+		s.logger.ErrorContext(ctx, msg)
 		return nil, errors.New(msg)
 	}
-	log.Printf("Successfully retrieved %d subscriptions for frequency %s", len(subscriptions), frequency)
+	s.logger.DebugContext(ctx, "retrieved subscriptions", "frequency", frequency, "count", len(subscriptions))
+	return subscriptions, nil
+}
+
+func (s *SubscriptionServiceImpl) GetDueSubscriptions(ctx context.Context, now time.Time) ([]domain.Subscription, error) {
+	subscriptions, err := s.repo.GetDueSubscriptions(ctx, now)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get subscriptions due at %s: %v", now.Format(time.RFC3339), err)
+		s.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	s.logger.DebugContext(ctx, "retrieved due subscriptions", "due_at", now, "count", len(subscriptions))
 	return subscriptions, nil
 }
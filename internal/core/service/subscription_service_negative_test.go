@@ -8,7 +8,9 @@ import (
 	"errors"
 	"testing"
 	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
 	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,6 +30,7 @@ func TestSubscriptionServiceImpl_CreateSubscription_TokenGenerationFailed(t *tes
 		mockWeatherProvider,
 		mockTokenSvc,
 		mockEmailService,
+		logger.Discard(),
 	)
 
 	email := "test@example.com"
@@ -37,7 +40,11 @@ func TestSubscriptionServiceImpl_CreateSubscription_TokenGenerationFailed(t *tes
 	mockTokenSvc.On("GenerateToken").Return("", errors.New("token generation failed"))
 
 	// Act
-	token, err := service.CreateSubscription(context.Background(), email, cityID, frequency)
+	token, err := service.CreateSubscription(context.Background(), out.CreateSubscriptionOptions{
+		Email:     email,
+		CityID:    cityID,
+		Frequency: frequency,
+	})
 
 	// Assert
 	assert.Error(t, err)
@@ -61,6 +68,7 @@ func TestSubscriptionServiceImpl_CreateSubscription_RepositoryCreationFailed(t *
 		mockWeatherProvider,
 		mockTokenSvc,
 		mockEmailService,
+		logger.Discard(),
 	)
 
 	expectedToken := "test-token-123"
@@ -78,7 +86,11 @@ func TestSubscriptionServiceImpl_CreateSubscription_RepositoryCreationFailed(t *
 	}).Return(errors.New("database error"))
 
 	// Act
-	token, err := service.CreateSubscription(context.Background(), email, cityID, frequency)
+	token, err := service.CreateSubscription(context.Background(), out.CreateSubscriptionOptions{
+		Email:     email,
+		CityID:    cityID,
+		Frequency: frequency,
+	})
 
 	// Assert
 	assert.Error(t, err)
@@ -7,7 +7,9 @@ import (
 	"context"
 	"testing"
 	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
 	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,13 +19,18 @@ type MockEmailNotifier struct {
 	mock.Mock
 }
 
-func (m *MockEmailNotifier) SendConfirmationEmail(subscription *domain.Subscription) error {
-	args := m.Called(subscription)
+func (m *MockEmailNotifier) SendConfirmationEmail(ctx context.Context, subscription *domain.Subscription) error {
+	args := m.Called(ctx, subscription)
 	return args.Error(0)
 }
 
-func (m *MockEmailNotifier) SendUpdates(updates []domain.WeatherUpdate) error {
-	args := m.Called(updates)
+func (m *MockEmailNotifier) SendUpdates(ctx context.Context, digests []domain.WeatherDigest) error {
+	args := m.Called(ctx, digests)
+	return args.Error(0)
+}
+
+func (m *MockEmailNotifier) SendAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error {
+	args := m.Called(ctx, subscription, alert)
 	return args.Error(0)
 }
 
@@ -41,6 +48,7 @@ func TestSubscriptionServiceImpl_CreateSubscription_Success(t *testing.T) {
 		mockWeatherProvider,
 		mockTokenSvc,
 		mockEmailService,
+		logger.Discard(),
 	)
 
 	expectedToken := "test-token-123"
@@ -58,7 +66,11 @@ func TestSubscriptionServiceImpl_CreateSubscription_Success(t *testing.T) {
 	}).Return(nil)
 
 	// Act
-	token, err := service.CreateSubscription(context.Background(), email, cityID, frequency)
+	token, err := service.CreateSubscription(context.Background(), out.CreateSubscriptionOptions{
+		Email:     email,
+		CityID:    cityID,
+		Frequency: frequency,
+	})
 
 	// Assert
 	assert.NoError(t, err)
@@ -6,18 +6,20 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 )
 
 type TokenServiceImpl struct {
-	repo out.SubscriptionRepository
+	repo   out.SubscriptionRepository
+	logger *slog.Logger
 }
 
-func NewTokenService(repo out.SubscriptionRepository) *TokenServiceImpl {
+func NewTokenService(repo out.SubscriptionRepository, logger *slog.Logger) *TokenServiceImpl {
 	return &TokenServiceImpl{
-		repo: repo,
+		repo:   repo,
+		logger: logger,
 	}
 }
 
@@ -31,18 +33,18 @@ func (s *TokenServiceImpl) GenerateToken() (string, error) {
 
 func (s *TokenServiceImpl) CheckTokenExists(ctx context.Context, token string) error {
 	if token == "" {
-		log.Printf("Invalid token provided: empty token")
+		s.logger.DebugContext(ctx, "empty token provided")
 		return domain.ErrInvalidToken
 	}
 
 	exists, err := s.repo.IsTokenExists(ctx, token)
 	if err != nil {
 		msg := fmt.Sprintf("unable to check token existence: %v", err)
-		log.Print(msg)
+		s.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 	if !exists {
-		log.Printf("Token not found: %s", token)
+		s.logger.DebugContext(ctx, "token not found", "token", token)
 		return domain.ErrTokenNotFound
 	}
 
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
 )
 
 func TestTokenService_GenerateToken(t *testing.T) {
@@ -24,7 +25,7 @@ func TestTokenService_GenerateToken(t *testing.T) {
 			name: "success",
 			setup: func() TokenService {
 				repo := &mocks.MockSubscriptionRepository{}
-				return NewTokenService(repo)
+				return NewTokenService(repo, logger.Discard())
 			},
 			verify: func(t *testing.T, token string, err error) {
 				assert.NoError(t, err, "GenerateToken should not return an error")
@@ -100,7 +101,7 @@ func TestTokenService_CheckTokenExists(t *testing.T) {
 			repo := &mocks.MockSubscriptionRepository{}
 			tt.setupMocks(repo)
 
-			svc := NewTokenService(repo)
+			svc := NewTokenService(repo, logger.Discard())
 
 			err := svc.CheckTokenExists(ctx, tt.token)
 			assert.Equal(t, tt.expectErr, err)
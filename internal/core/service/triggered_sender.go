@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+// TriggeredSender queues sends requested through the admin API so that any
+// replica can accept them. The scheduler leader runs the queue from a cron
+// job, so triggered sends never overlap across replicas or with each other.
+type TriggeredSender struct {
+	scheduler *SchedulerService
+	triggers  out.SendTriggerRepository
+	logger    *slog.Logger
+}
+
+func NewTriggeredSender(scheduler *SchedulerService, triggers out.SendTriggerRepository, logger *slog.Logger) *TriggeredSender {
+	return &TriggeredSender{
+		scheduler: scheduler,
+		triggers:  triggers,
+		logger:    logger,
+	}
+}
+
+// SendWeatherUpdates queues a send for frequency and returns without waiting
+// for it. It fails with domain.ErrSendAlreadyQueued while an earlier request
+// for the same frequency has not been picked up yet.
+func (s *TriggeredSender) SendWeatherUpdates(ctx context.Context, frequency domain.Frequency) error {
+	queued, err := s.triggers.Enqueue(ctx, frequency)
+	if err != nil {
+		return err
+	}
+	if !queued {
+		return domain.ErrSendAlreadyQueued
+	}
+	return nil
+}
+
+// RunQueued sends every queued frequency. It must only run on the leader.
+// Sends are taken off the queue before they start, so one interrupted by a
+// crash or a lost leadership is not repeated.
+func (s *TriggeredSender) RunQueued(ctx context.Context) error {
+	frequencies, err := s.triggers.TakePending(ctx)
+	if err != nil {
+		return err
+	}
+	for _, frequency := range frequencies {
+		if err := s.scheduler.SendWeatherUpdates(ctx, frequency); err != nil {
+			s.logger.ErrorContext(ctx, "unable to send triggered weather updates", "frequency", frequency, "error", err)
+		}
+	}
+	return nil
+}
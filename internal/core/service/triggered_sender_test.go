@@ -0,0 +1,48 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggeredSender_QueuesSend(t *testing.T) {
+	ctx := context.Background()
+	updates := &MockWeatherUpdateService{}
+	triggers := &mocks.MockSendTriggerRepository{}
+	triggers.On("Enqueue", ctx, domain.FrequencyDaily).Return(true, nil).Once()
+	triggers.On("Enqueue", ctx, domain.FrequencyDaily).Return(false, nil).Once()
+
+	scheduler := NewSchedulerService(updates, Notifiers{}, &mocks.MockSubscriptionRepository{}, &mocks.MockDeliveryRepository{}, DefaultSchedules(), nil, logger.Discard())
+	sender := NewTriggeredSender(scheduler, triggers, logger.Discard())
+
+	require.NoError(t, sender.SendWeatherUpdates(ctx, domain.FrequencyDaily))
+	assert.ErrorIs(t, sender.SendWeatherUpdates(ctx, domain.FrequencyDaily), domain.ErrSendAlreadyQueued)
+	triggers.AssertExpectations(t)
+	updates.AssertNotCalled(t, "PrepareUpdates", mock.Anything, mock.Anything)
+}
+
+func TestTriggeredSender_RunQueuedSendsEveryFrequency(t *testing.T) {
+	ctx := context.Background()
+	updates := &MockWeatherUpdateService{}
+	updates.On("PrepareUpdates", ctx, domain.FrequencyDaily).Return([]domain.WeatherDigest{}, nil).Once()
+	updates.On("PrepareUpdates", ctx, domain.FrequencyHourly).Return([]domain.WeatherDigest{}, nil).Once()
+	triggers := &mocks.MockSendTriggerRepository{}
+	triggers.On("TakePending", ctx).Return([]domain.Frequency{domain.FrequencyDaily, domain.FrequencyHourly}, nil).Once()
+
+	scheduler := NewSchedulerService(updates, Notifiers{}, &mocks.MockSubscriptionRepository{}, &mocks.MockDeliveryRepository{}, DefaultSchedules(), nil, logger.Discard())
+	err := NewTriggeredSender(scheduler, triggers, logger.Discard()).RunQueued(ctx)
+
+	require.NoError(t, err)
+	updates.AssertExpectations(t)
+	triggers.AssertExpectations(t)
+}
@@ -3,7 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 )
@@ -11,48 +12,163 @@ import (
 type WeatherUpdateServiceImpl struct {
 	subscriptionService out.SubscriptionService
 	weatherService      out.WeatherService
+	observationRepo     out.WeatherObservationRepository
+	logger              *slog.Logger
 }
 
 func NewWeatherUpdateService(
 	subscriptionService out.SubscriptionService,
 	weatherService out.WeatherService,
+	observationRepo out.WeatherObservationRepository,
+	logger *slog.Logger,
 ) *WeatherUpdateServiceImpl {
 	return &WeatherUpdateServiceImpl{
 		subscriptionService: subscriptionService,
 		weatherService:      weatherService,
+		observationRepo:     observationRepo,
+		logger:              logger,
 	}
 }
 
-func (s *WeatherUpdateServiceImpl) PrepareUpdates(ctx context.Context, frequency domain.Frequency) ([]domain.WeatherUpdate, error) {
+func (s *WeatherUpdateServiceImpl) PrepareUpdates(ctx context.Context, frequency domain.Frequency) ([]domain.WeatherDigest, error) {
 	subs, err := s.subscriptionService.GetSubscriptionsByFrequency(ctx, frequency)
 	if err != nil {
 		return nil, err
 	}
 
-	citySubscriptions := make(map[string][]domain.Subscription)
+	return s.buildDigests(ctx, subs, time.Now()).Digests, nil
+}
+
+func (s *WeatherUpdateServiceImpl) PrepareDueUpdates(ctx context.Context, now time.Time) (domain.DueUpdates, error) {
+	subs, err := s.subscriptionService.GetDueSubscriptions(ctx, now)
+	if err != nil {
+		return domain.DueUpdates{}, err
+	}
+
+	return s.buildDigests(ctx, subs, now), nil
+}
+
+// PrepareDigest builds a single-subscription digest with the current weather,
+// ignoring quiet hours and leaving stored overnight observations untouched.
+func (s *WeatherUpdateServiceImpl) PrepareDigest(ctx context.Context, sub domain.Subscription) (domain.WeatherDigest, error) {
+	weather, err := s.weatherService.GetWeather(ctx, sub.City.Name)
+	if err != nil {
+		return domain.WeatherDigest{}, err
+	}
+
+	digest := domain.WeatherDigest{
+		Channel: sub.Channel(),
+		Email:   sub.Email,
+		Updates: []domain.WeatherUpdate{{Subscription: sub, Weather: weather}},
+	}
+	if sub.Subscriber != nil {
+		digest.ManagementToken = sub.Subscriber.Token
+	}
+	return digest, nil
+}
+
+func (s *WeatherUpdateServiceImpl) buildDigests(ctx context.Context, subs []domain.Subscription, now time.Time) domain.DueUpdates {
+	// Email and Telegram subscriptions are grouped into one digest per address,
+	// while every webhook and push subscription gets a digest of its own.
+	type digestKey struct {
+		channel        domain.Channel
+		email          string
+		subscriptionID int64
+	}
+
+	var result domain.DueUpdates
+	var keys []digestKey
+	grouped := make(map[digestKey][]domain.Subscription)
 	for _, sub := range subs {
 		if !sub.IsConfirmed {
 			continue
 		}
-		citySubscriptions[sub.City.Name] = append(citySubscriptions[sub.City.Name], sub)
+		key := digestKey{channel: sub.Channel(), email: sub.Email}
+		if key.channel == domain.ChannelWebhook || key.channel == domain.ChannelPush {
+			key.subscriptionID = sub.ID
+		}
+		if _, exists := grouped[key]; !exists {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], sub)
 	}
 
-	var updates []domain.WeatherUpdate
-	for cityName, citySubs := range citySubscriptions {
-		weather, err := s.weatherService.GetWeather(ctx, cityName)
-		if err != nil {
-			msg := fmt.Sprintf("unable to get weather for city %s: %v", cityName, err)
-			log.Print(msg)
-			continue
+	cityWeather := make(map[string]*domain.Weather)
+	getWeather := func(cityName string) *domain.Weather {
+		weather, fetched := cityWeather[cityName]
+		if !fetched {
+			weather = s.fetchWeather(ctx, cityName)
+			cityWeather[cityName] = weather
 		}
+		return weather
+	}
+
+	for _, key := range keys {
+		digest := domain.WeatherDigest{Channel: key.channel, Email: key.email}
+
+		for _, sub := range grouped[key] {
+			if sub.InQuietHours(now) {
+				result.Deferred = append(result.Deferred, sub)
+				if sub.QuietHours.SendSummary {
+					s.recordObservation(ctx, sub, getWeather(sub.City.Name), now)
+				}
+				continue
+			}
+
+			if digest.ManagementToken == "" && sub.Subscriber != nil {
+				digest.ManagementToken = sub.Subscriber.Token
+			}
+
+			weather := getWeather(sub.City.Name)
+			if weather == nil {
+				continue
+			}
 
-		for _, sub := range citySubs {
-			updates = append(updates, domain.WeatherUpdate{
+			update := domain.WeatherUpdate{
 				Subscription: sub,
-				Weather:      weather,
-			})
+				Weather:      *weather,
+			}
+			if sub.QuietHours.SendSummary {
+				update.Overnight = s.takeSummary(ctx, sub)
+			}
+			digest.Updates = append(digest.Updates, update)
+		}
+
+		if len(digest.Updates) > 0 {
+			result.Digests = append(result.Digests, digest)
 		}
 	}
 
-	return updates, nil
+	return result
+}
+
+func (s *WeatherUpdateServiceImpl) fetchWeather(ctx context.Context, cityName string) *domain.Weather {
+	weather, err := s.weatherService.GetWeather(ctx, cityName)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get weather for city %s: %v", cityName, err)
+		s.logger.ErrorContext(ctx, msg)
+		return nil
+	}
+	return &weather
+}
+
+func (s *WeatherUpdateServiceImpl) recordObservation(ctx context.Context, sub domain.Subscription, weather *domain.Weather, now time.Time) {
+	if weather == nil {
+		return
+	}
+	if err := s.observationRepo.SaveObservation(ctx, sub.ID, domain.WeatherObservation{
+		Weather:    *weather,
+		ObservedAt: now,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "unable to record quiet hours observation", "subscription_id", sub.ID, "error", err)
+	}
+}
+
+func (s *WeatherUpdateServiceImpl) takeSummary(ctx context.Context, sub domain.Subscription) *domain.WeatherSummary {
+	observations, err := s.observationRepo.TakeObservations(ctx, sub.ID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "unable to load quiet hours observations", "subscription_id", sub.ID, "error", err)
+		return nil
+	}
+	return domain.SummarizeObservations(observations)
 }
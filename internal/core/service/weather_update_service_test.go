@@ -0,0 +1,202 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeatherUpdateService_PrepareUpdates_GroupsByEmail(t *testing.T) {
+	ctx := context.Background()
+	subscriber := &domain.Subscriber{ID: 1, Email: "user@example.com", Token: "manage"}
+	kyiv := domain.Subscription{Email: "user@example.com", City: &domain.City{Name: "Kyiv"}, Subscriber: subscriber, Token: "t1", IsConfirmed: true}
+	lviv := domain.Subscription{Email: "user@example.com", City: &domain.City{Name: "Lviv"}, Subscriber: subscriber, Token: "t2", IsConfirmed: true}
+	other := domain.Subscription{Email: "other@example.com", City: &domain.City{Name: "Kyiv"}, Token: "t3", IsConfirmed: true}
+	unconfirmed := domain.Subscription{Email: "new@example.com", City: &domain.City{Name: "Kyiv"}, Token: "t4"}
+
+	subscriptionSvc := &mocks.MockSubscriptionService{}
+	weatherSvc := &mocks.MockWeatherService{}
+	subscriptionSvc.On("GetSubscriptionsByFrequency", ctx, domain.FrequencyDaily).
+		Return([]domain.Subscription{kyiv, lviv, other, unconfirmed}, nil)
+	kyivWeather := domain.Weather{Temperature: 20, Humidity: 50, Description: "Sunny"}
+	lvivWeather := domain.Weather{Temperature: 15, Humidity: 70, Description: "Rain"}
+	weatherSvc.On("GetWeather", ctx, "Kyiv").Return(kyivWeather, nil).Once()
+	weatherSvc.On("GetWeather", ctx, "Lviv").Return(lvivWeather, nil).Once()
+
+	svc := NewWeatherUpdateService(subscriptionSvc, weatherSvc, &mocks.MockWeatherObservationRepository{}, logger.Discard())
+	digests, err := svc.PrepareUpdates(ctx, domain.FrequencyDaily)
+
+	require.NoError(t, err)
+	require.Len(t, digests, 2)
+	assert.Equal(t, "user@example.com", digests[0].Email)
+	assert.Equal(t, "manage", digests[0].ManagementToken)
+	assert.Equal(t, []domain.WeatherUpdate{
+		{Subscription: kyiv, Weather: kyivWeather},
+		{Subscription: lviv, Weather: lvivWeather},
+	}, digests[0].Updates)
+	assert.Equal(t, "other@example.com", digests[1].Email)
+	assert.Len(t, digests[1].Updates, 1)
+	weatherSvc.AssertExpectations(t)
+}
+
+func TestWeatherUpdateService_PrepareUpdates_SeparatesWebhookSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	webhook := &domain.Webhook{URL: "https://example.com/hook", Secret: "0123456789abcdef"}
+	kyiv := domain.Subscription{ID: 1, Email: "user@example.com", City: &domain.City{Name: "Kyiv"}, IsConfirmed: true}
+	lviv := domain.Subscription{ID: 2, Email: "user@example.com", City: &domain.City{Name: "Kyiv"}, Webhook: webhook, IsConfirmed: true}
+	odesa := domain.Subscription{ID: 3, Email: "user@example.com", City: &domain.City{Name: "Kyiv"}, Webhook: webhook, IsConfirmed: true}
+
+	subscriptionSvc := &mocks.MockSubscriptionService{}
+	weatherSvc := &mocks.MockWeatherService{}
+	subscriptionSvc.On("GetSubscriptionsByFrequency", ctx, domain.FrequencyDaily).
+		Return([]domain.Subscription{kyiv, lviv, odesa}, nil)
+	weatherSvc.On("GetWeather", ctx, "Kyiv").Return(domain.Weather{Temperature: 20}, nil).Once()
+
+	svc := NewWeatherUpdateService(subscriptionSvc, weatherSvc, &mocks.MockWeatherObservationRepository{}, logger.Discard())
+	digests, err := svc.PrepareUpdates(ctx, domain.FrequencyDaily)
+
+	require.NoError(t, err)
+	require.Len(t, digests, 3)
+	assert.Equal(t, domain.ChannelEmail, digests[0].Channel)
+	assert.Equal(t, domain.ChannelWebhook, digests[1].Channel)
+	assert.Equal(t, int64(2), digests[1].Updates[0].Subscription.ID)
+	assert.Equal(t, domain.ChannelWebhook, digests[2].Channel)
+	assert.Equal(t, int64(3), digests[2].Updates[0].Subscription.ID)
+}
+
+func TestWeatherUpdateService_PrepareUpdates_SkipsCitiesWithoutWeather(t *testing.T) {
+	ctx := context.Background()
+	kyiv := domain.Subscription{Email: "user@example.com", City: &domain.City{Name: "Kyiv"}, IsConfirmed: true}
+	lviv := domain.Subscription{Email: "user@example.com", City: &domain.City{Name: "Lviv"}, IsConfirmed: true}
+
+	subscriptionSvc := &mocks.MockSubscriptionService{}
+	weatherSvc := &mocks.MockWeatherService{}
+	subscriptionSvc.On("GetSubscriptionsByFrequency", ctx, domain.FrequencyHourly).
+		Return([]domain.Subscription{kyiv, lviv}, nil)
+	weatherSvc.On("GetWeather", ctx, "Kyiv").Return(domain.Weather{}, errors.New("provider down"))
+	weatherSvc.On("GetWeather", ctx, "Lviv").Return(domain.Weather{Temperature: 10}, nil)
+
+	svc := NewWeatherUpdateService(subscriptionSvc, weatherSvc, &mocks.MockWeatherObservationRepository{}, logger.Discard())
+	digests, err := svc.PrepareUpdates(ctx, domain.FrequencyHourly)
+
+	require.NoError(t, err)
+	require.Len(t, digests, 1)
+	require.Len(t, digests[0].Updates, 1)
+	assert.Equal(t, "Lviv", digests[0].Updates[0].Subscription.City.Name)
+}
+
+func TestWeatherUpdateService_PrepareDueUpdates_DefersQuietHours(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 11, 23, 0, 0, 0, time.UTC)
+	quiet := domain.QuietHours{Start: 22 * 60, End: 7 * 60, SendSummary: true}
+	kyiv := domain.Subscription{ID: 1, Email: "user@example.com", City: &domain.City{Name: "Kyiv"}, Timezone: "Europe/Kyiv", QuietHours: quiet, IsConfirmed: true}
+	lviv := domain.Subscription{ID: 2, Email: "user@example.com", City: &domain.City{Name: "Lviv"}, IsConfirmed: true}
+	kyivWeather := domain.Weather{Temperature: 12, Description: "Clear"}
+
+	subscriptionSvc := &mocks.MockSubscriptionService{}
+	weatherSvc := &mocks.MockWeatherService{}
+	observationRepo := &mocks.MockWeatherObservationRepository{}
+	subscriptionSvc.On("GetDueSubscriptions", ctx, now).Return([]domain.Subscription{kyiv, lviv}, nil)
+	weatherSvc.On("GetWeather", ctx, "Kyiv").Return(kyivWeather, nil)
+	weatherSvc.On("GetWeather", ctx, "Lviv").Return(domain.Weather{Temperature: 15}, nil)
+	observationRepo.On("SaveObservation", ctx, int64(1), domain.WeatherObservation{Weather: kyivWeather, ObservedAt: now}).Return(nil).Once()
+
+	svc := NewWeatherUpdateService(subscriptionSvc, weatherSvc, observationRepo, logger.Discard())
+	due, err := svc.PrepareDueUpdates(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Subscription{kyiv}, due.Deferred)
+	require.Len(t, due.Digests, 1)
+	require.Len(t, due.Digests[0].Updates, 1)
+	assert.Equal(t, "Lviv", due.Digests[0].Updates[0].Subscription.City.Name)
+	observationRepo.AssertExpectations(t)
+}
+
+func TestWeatherUpdateService_PrepareDueUpdates_AttachesOvernightSummary(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 12, 7, 0, 0, 0, time.UTC)
+	sub := domain.Subscription{
+		ID:          1,
+		Email:       "user@example.com",
+		City:        &domain.City{Name: "Kyiv"},
+		QuietHours:  domain.QuietHours{Start: 22 * 60, End: 7 * 60, SendSummary: true},
+		IsConfirmed: true,
+	}
+	observations := []domain.WeatherObservation{
+		{Weather: domain.Weather{Temperature: 14, Description: "Clear"}, ObservedAt: now.Add(-8 * time.Hour)},
+		{Weather: domain.Weather{Temperature: 9, Description: "Fog"}, ObservedAt: now.Add(-3 * time.Hour)},
+		{Weather: domain.Weather{Temperature: 11, Description: "Clear"}, ObservedAt: now.Add(-time.Hour)},
+	}
+
+	subscriptionSvc := &mocks.MockSubscriptionService{}
+	weatherSvc := &mocks.MockWeatherService{}
+	observationRepo := &mocks.MockWeatherObservationRepository{}
+	subscriptionSvc.On("GetDueSubscriptions", ctx, now).Return([]domain.Subscription{sub}, nil)
+	weatherSvc.On("GetWeather", ctx, "Kyiv").Return(domain.Weather{Temperature: 13}, nil)
+	observationRepo.On("TakeObservations", ctx, int64(1)).Return(observations, nil).Once()
+
+	svc := NewWeatherUpdateService(subscriptionSvc, weatherSvc, observationRepo, logger.Discard())
+	due, err := svc.PrepareDueUpdates(ctx, now)
+
+	require.NoError(t, err)
+	assert.Empty(t, due.Deferred)
+	require.Len(t, due.Digests, 1)
+	assert.Equal(t, &domain.WeatherSummary{
+		From:           now.Add(-8 * time.Hour),
+		To:             now.Add(-time.Hour),
+		MinTemperature: 9,
+		MaxTemperature: 14,
+		Descriptions:   []string{"Clear", "Fog"},
+	}, due.Digests[0].Updates[0].Overnight)
+}
+
+func TestWeatherUpdateService_PrepareDigest(t *testing.T) {
+	ctx := context.Background()
+	sub := domain.Subscription{
+		ID:         4,
+		Email:      "user@example.com",
+		City:       &domain.City{Name: "Kyiv"},
+		Subscriber: &domain.Subscriber{Token: "manage"},
+		QuietHours: domain.QuietHours{Start: 0, End: 24*60 - 1, SendSummary: true},
+	}
+	weather := domain.Weather{Temperature: 18, Humidity: 60, Description: "Cloudy"}
+
+	weatherSvc := &mocks.MockWeatherService{}
+	observations := &mocks.MockWeatherObservationRepository{}
+	weatherSvc.On("GetWeather", ctx, "Kyiv").Return(weather, nil).Once()
+
+	svc := NewWeatherUpdateService(&mocks.MockSubscriptionService{}, weatherSvc, observations, logger.Discard())
+	digest, err := svc.PrepareDigest(ctx, sub)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WeatherDigest{
+		Channel:         domain.ChannelEmail,
+		Email:           "user@example.com",
+		ManagementToken: "manage",
+		Updates:         []domain.WeatherUpdate{{Subscription: sub, Weather: weather}},
+	}, digest)
+	observations.AssertNotCalled(t, "TakeObservations", ctx, int64(4))
+}
+
+func TestWeatherUpdateService_PrepareDigest_WeatherUnavailable(t *testing.T) {
+	ctx := context.Background()
+	sub := domain.Subscription{Email: "user@example.com", City: &domain.City{Name: "Atlantis"}}
+
+	weatherSvc := &mocks.MockWeatherService{}
+	weatherSvc.On("GetWeather", ctx, "Atlantis").Return(domain.Weather{}, domain.ErrCityNotFound)
+
+	svc := NewWeatherUpdateService(&mocks.MockSubscriptionService{}, weatherSvc, &mocks.MockWeatherObservationRepository{}, logger.Discard())
+	_, err := svc.PrepareDigest(ctx, sub)
+
+	assert.ErrorIs(t, err, domain.ErrCityNotFound)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type WebhookRetryOptions struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryBudget bounds the time spent on one message, attempts and backoff
+	// included, so a dead endpoint can't hold up the rest of a dispatch run.
+	// Zero leaves it unbounded.
+	RetryBudget time.Duration
+	// DisableAfter is the number of consecutive failed messages after which
+	// the webhook is disabled. Zero keeps failing webhooks enabled.
+	DisableAfter int
+}
+
+// WebhookNotifier delivers digests to the webhooks of their subscriptions,
+// retrying failed attempts with exponential backoff within a retry budget.
+type WebhookNotifier struct {
+	sender      out.WebhookSender
+	webhookRepo out.WebhookRepository
+	opts        WebhookRetryOptions
+	now         func() time.Time
+	sleep       func(ctx context.Context, d time.Duration) error
+	logger      *slog.Logger
+}
+
+func NewWebhookNotifier(sender out.WebhookSender, webhookRepo out.WebhookRepository, opts WebhookRetryOptions, logger *slog.Logger) *WebhookNotifier {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	return &WebhookNotifier{
+		sender:      sender,
+		webhookRepo: webhookRepo,
+		opts:        opts,
+		now:         time.Now,
+		sleep:       sleepContext,
+		logger:      logger,
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, digest domain.WeatherDigest) error {
+	for _, update := range digest.Updates {
+		if err := n.deliver(ctx, update.Subscription, domain.WebhookMessage{Update: update}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *WebhookNotifier) NotifyAlert(ctx context.Context, subscription domain.Subscription, alert domain.Alert) error {
+	return n.deliver(ctx, subscription, domain.WebhookMessage{
+		Update: domain.WeatherUpdate{Subscription: subscription},
+		Alert:  &alert,
+	})
+}
+
+func (n *WebhookNotifier) deliver(ctx context.Context, sub domain.Subscription, message domain.WebhookMessage) error {
+	if sub.Webhook == nil || sub.Webhook.Disabled() {
+		return fmt.Errorf("%w: subscription %d", domain.ErrWebhookDisabled, sub.ID)
+	}
+
+	now := n.now()
+	message.ID = fmt.Sprintf("%d-%d", sub.ID, now.UnixNano())
+	message.SentAt = now
+
+	retryCtx := ctx
+	if n.opts.RetryBudget > 0 {
+		var cancel context.CancelFunc
+		retryCtx, cancel = context.WithTimeout(ctx, n.opts.RetryBudget)
+		defer cancel()
+	}
+
+	var lastErr error
+	backoff := n.opts.InitialBackoff
+	for attempt := 1; attempt <= n.opts.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if deadline, ok := retryCtx.Deadline(); ok && time.Now().Add(backoff).After(deadline) {
+				break
+			}
+			if err := n.sleep(retryCtx, backoff); err != nil {
+				lastErr = err
+				break
+			}
+			backoff = min(backoff*2, n.opts.MaxBackoff)
+		}
+
+		started := time.Now()
+		status, err := n.sender.Send(retryCtx, *sub.Webhook, message)
+		n.recordDelivery(ctx, domain.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			MessageID:      message.ID,
+			Attempt:        attempt,
+			StatusCode:     status,
+			Error:          errorString(err),
+			Duration:       time.Since(started),
+		})
+		if err == nil {
+			if sub.Webhook.ConsecutiveFailures > 0 {
+				if err := n.webhookRepo.ResetFailures(ctx, sub.ID); err != nil {
+					n.logger.ErrorContext(ctx, "unable to reset webhook failures", "subscription_id", sub.ID, "error", err)
+				}
+			}
+			return nil
+		}
+
+		lastErr = err
+		if permanentWebhookFailure(status) {
+			break
+		}
+	}
+
+	n.recordFailure(ctx, sub.ID)
+	msg := fmt.Sprintf("unable to deliver webhook message %s for subscription %d: %v", message.ID, sub.ID, lastErr)
+	n.logger.ErrorContext(ctx, msg)
+	return errors.New(msg)
+}
+
+func (n *WebhookNotifier) recordFailure(ctx context.Context, subscriptionID int64) {
+	failures, err := n.webhookRepo.RecordFailure(ctx, subscriptionID)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "unable to record webhook failure", "subscription_id", subscriptionID, "error", err)
+		return
+	}
+	if n.opts.DisableAfter <= 0 || failures < n.opts.DisableAfter {
+		return
+	}
+	if err := n.webhookRepo.DisableWebhook(ctx, subscriptionID); err != nil {
+		n.logger.ErrorContext(ctx, "unable to disable webhook", "subscription_id", subscriptionID, "error", err)
+		return
+	}
+	n.logger.WarnContext(ctx, "disabled webhook after consecutive failures", "subscription_id", subscriptionID, "failures", failures)
+}
+
+func (n *WebhookNotifier) recordDelivery(ctx context.Context, delivery domain.WebhookDelivery) {
+	if err := n.webhookRepo.RecordDelivery(ctx, delivery); err != nil {
+		n.logger.ErrorContext(ctx, "unable to record webhook delivery", "subscription_id", delivery.SubscriptionID, "error", err)
+	}
+}
+
+// permanentWebhookFailure reports whether retrying cannot help: the receiver
+// rejected the request itself rather than being unavailable.
+func permanentWebhookFailure(status int) bool {
+	if status == http.StatusRequestTimeout || status == http.StatusTooManyRequests {
+		return false
+	}
+	return status >= 400 && status < 500
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+//go:build unit
+// +build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestWebhookNotifier(sender *mocks.MockWebhookSender, repo *mocks.MockWebhookRepository, sleeps *[]time.Duration) *WebhookNotifier {
+	notifier := NewWebhookNotifier(sender, repo, WebhookRetryOptions{
+		MaxAttempts:    4,
+		InitialBackoff: time.Second,
+		MaxBackoff:     3 * time.Second,
+		DisableAfter:   3,
+	}, logger.Discard())
+	notifier.now = func() time.Time { return time.Unix(1700000000, 0) }
+	notifier.sleep = func(_ context.Context, d time.Duration) error {
+		*sleeps = append(*sleeps, d)
+		return nil
+	}
+	return notifier
+}
+
+func webhookDigest(failures int) domain.WeatherDigest {
+	sub := domain.Subscription{
+		ID:      7,
+		City:    &domain.City{Name: "Kyiv"},
+		Webhook: &domain.Webhook{URL: "https://example.com/hook", Secret: "0123456789abcdef", ConsecutiveFailures: failures},
+	}
+	return domain.WeatherDigest{Channel: domain.ChannelWebhook, Updates: []domain.WeatherUpdate{{Subscription: sub}}}
+}
+
+func TestWebhookNotifier_RetriesWithBackoffUntilDelivered(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockWebhookSender{}
+	repo := &mocks.MockWebhookRepository{}
+	var sleeps []time.Duration
+
+	repo.On("RecordDelivery", ctx, mock.Anything).Return(nil)
+	repo.On("ResetFailures", ctx, int64(7)).Return(nil).Once()
+	sender.On("Send", ctx, mock.Anything, mock.Anything).Return(503, errors.New("unavailable")).Times(3)
+	sender.On("Send", ctx, mock.Anything, mock.Anything).Return(200, nil).Once()
+
+	err := newTestWebhookNotifier(sender, repo, &sleeps).Notify(ctx, webhookDigest(2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, sleeps)
+	sender.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "RecordDelivery", 4)
+	repo.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything)
+
+	ids := map[string]bool{}
+	for _, call := range sender.Calls {
+		ids[call.Arguments.Get(2).(domain.WebhookMessage).ID] = true
+	}
+	assert.Len(t, ids, 1, "retries must reuse the message ID")
+}
+
+func TestWebhookNotifier_DoesNotRetryClientErrors(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockWebhookSender{}
+	repo := &mocks.MockWebhookRepository{}
+	var sleeps []time.Duration
+
+	repo.On("RecordDelivery", ctx, mock.Anything).Return(nil)
+	repo.On("RecordFailure", ctx, int64(7)).Return(1, nil).Once()
+	sender.On("Send", ctx, mock.Anything, mock.Anything).Return(410, errors.New("gone")).Once()
+
+	err := newTestWebhookNotifier(sender, repo, &sleeps).Notify(ctx, webhookDigest(0))
+
+	assert.Error(t, err)
+	assert.Empty(t, sleeps)
+	sender.AssertExpectations(t)
+	repo.AssertNotCalled(t, "DisableWebhook", mock.Anything, mock.Anything)
+}
+
+func TestWebhookNotifier_DisablesAfterConsecutiveFailures(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockWebhookSender{}
+	repo := &mocks.MockWebhookRepository{}
+	var sleeps []time.Duration
+
+	repo.On("RecordDelivery", ctx, mock.Anything).Return(nil)
+	repo.On("RecordFailure", ctx, int64(7)).Return(3, nil).Once()
+	repo.On("DisableWebhook", ctx, int64(7)).Return(nil).Once()
+	sender.On("Send", ctx, mock.Anything, mock.Anything).Return(429, errors.New("slow down"))
+
+	err := newTestWebhookNotifier(sender, repo, &sleeps).Notify(ctx, webhookDigest(2))
+
+	assert.Error(t, err)
+	sender.AssertNumberOfCalls(t, "Send", 4)
+	repo.AssertExpectations(t)
+}
+
+func TestWebhookNotifier_SkipsDisabledWebhook(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockWebhookSender{}
+	repo := &mocks.MockWebhookRepository{}
+	var sleeps []time.Duration
+	digest := webhookDigest(10)
+	disabledAt := time.Now()
+	digest.Updates[0].Subscription.Webhook.DisabledAt = &disabledAt
+
+	err := newTestWebhookNotifier(sender, repo, &sleeps).Notify(ctx, digest)
+
+	assert.ErrorIs(t, err, domain.ErrWebhookDisabled)
+	sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookNotifier_StopsRetryingWhenBudgetIsSpent(t *testing.T) {
+	ctx := context.Background()
+	sender := &mocks.MockWebhookSender{}
+	repo := &mocks.MockWebhookRepository{}
+	var sleeps []time.Duration
+
+	repo.On("RecordDelivery", ctx, mock.Anything).Return(nil)
+	repo.On("RecordFailure", ctx, int64(7)).Return(1, nil).Once()
+	sender.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(503, errors.New("unavailable"))
+
+	notifier := newTestWebhookNotifier(sender, repo, &sleeps)
+	notifier.opts.RetryBudget = 500 * time.Millisecond
+	err := notifier.Notify(ctx, webhookDigest(0))
+
+	assert.Error(t, err)
+	assert.Empty(t, sleeps)
+	sender.AssertNumberOfCalls(t, "Send", 1)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/core/service"
+)
+
+const deliveryHistoryLimit = 100
+
+type WeatherUpdateSender interface {
+	SendWeatherUpdates(ctx context.Context, frequency domain.Frequency) error
+}
+
+type AdminUseCase struct {
+	subscriptionRepo out.SubscriptionRepository
+	cityRepo         out.CityRepository
+	deliveryRepo     out.DeliveryRepository
+	auditRepo        out.AuditLogRepository
+	updateSender     WeatherUpdateSender
+	schedules        service.Schedules
+	metrics          out.BusinessMetrics
+	logger           *slog.Logger
+}
+
+func NewAdminUseCase(
+	subscriptionRepo out.SubscriptionRepository,
+	cityRepo out.CityRepository,
+	deliveryRepo out.DeliveryRepository,
+	auditRepo out.AuditLogRepository,
+	updateSender WeatherUpdateSender,
+	schedules service.Schedules,
+	metrics out.BusinessMetrics,
+	logger *slog.Logger,
+) *AdminUseCase {
+	if metrics == nil {
+		metrics = out.NopBusinessMetrics{}
+	}
+	return &AdminUseCase{
+		subscriptionRepo: subscriptionRepo,
+		cityRepo:         cityRepo,
+		deliveryRepo:     deliveryRepo,
+		auditRepo:        auditRepo,
+		updateSender:     updateSender,
+		schedules:        schedules,
+		metrics:          metrics,
+		logger:           logger,
+	}
+}
+
+func (uc *AdminUseCase) SearchSubscriptions(ctx context.Context, actor string, opts out.SearchSubscriptionsOptions) ([]domain.Subscription, error) {
+	uc.audit(ctx, actor, "search_subscriptions", "", fmt.Sprintf("email=%q city=%q", opts.Email, opts.City))
+	return uc.subscriptionRepo.SearchSubscriptions(ctx, opts)
+}
+
+func (uc *AdminUseCase) GetDeliveryHistory(ctx context.Context, actor string, subscriptionID int64) ([]domain.Delivery, error) {
+	uc.audit(ctx, actor, "view_delivery_history", subscriptionTarget(subscriptionID), "")
+	if _, err := uc.subscriptionRepo.GetSubscriptionByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return uc.deliveryRepo.GetDeliveries(ctx, subscriptionID, deliveryHistoryLimit)
+}
+
+func (uc *AdminUseCase) ConfirmSubscription(ctx context.Context, actor string, subscriptionID int64) error {
+	subscription, err := uc.subscriptionRepo.GetSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if subscription.IsConfirmed {
+		return domain.ErrSubscriptionAlreadyConfirmed
+	}
+
+	nextRunAt, err := uc.schedules.NextRunAt(subscription.Frequency, subscription.Schedule, time.Now().In(subscription.Location()))
+	if err != nil {
+		msg := fmt.Sprintf("unable to schedule subscription %d: %v", subscriptionID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	subscription.IsConfirmed = true
+	subscription.NextRunAt = nextRunAt
+
+	if err := uc.subscriptionRepo.UpdateSubscription(ctx, subscription); err != nil {
+		return err
+	}
+	uc.metrics.SubscriptionConfirmed(subscription.Frequency)
+	uc.audit(ctx, actor, "confirm_subscription", subscriptionTarget(subscriptionID), subscription.Email)
+	return nil
+}
+
+func (uc *AdminUseCase) DeleteSubscription(ctx context.Context, actor string, subscriptionID int64) error {
+	subscription, err := uc.subscriptionRepo.GetSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if err := uc.subscriptionRepo.DeleteSubscription(ctx, subscription.Token); err != nil {
+		return err
+	}
+	uc.metrics.SubscriptionsRemoved(1)
+	uc.audit(ctx, actor, "delete_subscription", subscriptionTarget(subscriptionID), subscription.Email)
+	return nil
+}
+
+func (uc *AdminUseCase) ListCities(ctx context.Context, actor string) ([]domain.CityStats, error) {
+	uc.audit(ctx, actor, "list_cities", "", "")
+	return uc.cityRepo.ListWithSubscriberCounts(ctx)
+}
+
+func (uc *AdminUseCase) TriggerSend(ctx context.Context, actor string, frequency domain.Frequency) error {
+	uc.audit(ctx, actor, "trigger_send", string(frequency), "")
+	return uc.updateSender.SendWeatherUpdates(ctx, frequency)
+}
+
+func (uc *AdminUseCase) audit(ctx context.Context, actor, action, target, details string) {
+	recordAudit(ctx, uc.auditRepo, uc.logger, domain.AuditEntry{
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		Details: details,
+	})
+}
+
+func recordAudit(ctx context.Context, auditRepo out.AuditLogRepository, logger *slog.Logger, entry domain.AuditEntry) {
+	if err := auditRepo.Record(ctx, entry); err != nil {
+		logger.ErrorContext(ctx, "unable to record admin action", "action", entry.Action, "actor", entry.Actor, "error", err)
+	}
+}
+
+func subscriptionTarget(id int64) string {
+	return "subscription:" + strconv.FormatInt(id, 10)
+}
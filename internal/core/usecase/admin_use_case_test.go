@@ -0,0 +1,83 @@
+//go:build unit
+// +build unit
+
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/service"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockUpdateSender struct{ mock.Mock }
+
+func (m *mockUpdateSender) SendWeatherUpdates(ctx context.Context, frequency domain.Frequency) error {
+	args := m.Called(ctx, frequency)
+	return args.Error(0)
+}
+
+func newAdminUseCase() (*AdminUseCase, *mocks.MockSubscriptionRepository, *mocks.MockAuditLogRepository, *mockUpdateSender) {
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+	auditRepo := &mocks.MockAuditLogRepository{}
+	sender := &mockUpdateSender{}
+	uc := NewAdminUseCase(subscriptionRepo, &mocks.MockCityRepo{}, &mocks.MockDeliveryRepository{}, auditRepo, sender, service.DefaultSchedules(), nil, logger.Discard())
+	return uc, subscriptionRepo, auditRepo, sender
+}
+
+func TestAdminUseCase_ConfirmSubscription(t *testing.T) {
+	ctx := context.Background()
+	uc, subscriptionRepo, auditRepo, _ := newAdminUseCase()
+	sub := domain.Subscription{ID: 5, Email: "user@example.com", Token: "token", Frequency: domain.FrequencyHourly}
+
+	subscriptionRepo.On("GetSubscriptionByID", ctx, int64(5)).Return(sub, nil)
+	subscriptionRepo.On("UpdateSubscription", ctx, mock.MatchedBy(func(s domain.Subscription) bool {
+		return s.ID == 5 && s.IsConfirmed && s.NextRunAt.After(time.Now())
+	})).Return(nil).Once()
+	auditRepo.On("Record", ctx, domain.AuditEntry{
+		Actor:   "support",
+		Action:  "confirm_subscription",
+		Target:  "subscription:5",
+		Details: "user@example.com",
+	}).Return(nil).Once()
+
+	err := uc.ConfirmSubscription(ctx, "support", 5)
+
+	require.NoError(t, err)
+	subscriptionRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAdminUseCase_ConfirmSubscription_AlreadyConfirmed(t *testing.T) {
+	ctx := context.Background()
+	uc, subscriptionRepo, auditRepo, _ := newAdminUseCase()
+
+	subscriptionRepo.On("GetSubscriptionByID", ctx, int64(5)).Return(domain.Subscription{ID: 5, IsConfirmed: true}, nil)
+
+	err := uc.ConfirmSubscription(ctx, "support", 5)
+
+	assert.ErrorIs(t, err, domain.ErrSubscriptionAlreadyConfirmed)
+	subscriptionRepo.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything)
+	auditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestAdminUseCase_TriggerSend_IsAudited(t *testing.T) {
+	ctx := context.Background()
+	uc, _, auditRepo, sender := newAdminUseCase()
+
+	auditRepo.On("Record", ctx, domain.AuditEntry{Actor: "api-key", Action: "trigger_send", Target: "daily"}).Return(nil).Once()
+	sender.On("SendWeatherUpdates", ctx, domain.FrequencyDaily).Return(nil).Once()
+
+	err := uc.TriggerSend(ctx, "api-key", domain.FrequencyDaily)
+
+	require.NoError(t, err)
+	auditRepo.AssertExpectations(t)
+	sender.AssertExpectations(t)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type AlertUseCase struct {
+	alertProvider out.AlertProvider
+}
+
+func NewAlertUseCase(
+	alertProvider out.AlertProvider,
+) *AlertUseCase {
+	return &AlertUseCase{
+		alertProvider: alertProvider,
+	}
+}
+
+func (uc *AlertUseCase) GetAlerts(ctx context.Context, city string) ([]domain.Alert, error) {
+	return uc.alertProvider.GetAlerts(ctx, city)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+const (
+	apiKeyPrefix = "wk_"
+	// apiKeyPrefixLen characters of a key are stored in clear so admins can
+	// tell keys apart.
+	apiKeyPrefixLen = 11
+)
+
+type APIKeyUseCase struct {
+	apiKeyRepo out.APIKeyRepository
+	auditRepo  out.AuditLogRepository
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+func NewAPIKeyUseCase(apiKeyRepo out.APIKeyRepository, auditRepo out.AuditLogRepository, logger *slog.Logger) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		auditRepo:  auditRepo,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+func (uc *APIKeyUseCase) IssueAPIKey(ctx context.Context, actor string, opts out.IssueAPIKeyOptions) (domain.APIKey, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		msg := fmt.Sprintf("unable to generate api key for %s: %v", opts.Owner, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return domain.APIKey{}, "", errors.New(msg)
+	}
+	plaintext := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	requestsPerMinute, monthlyQuota := opts.Plan.Limits()
+	if opts.RequestsPerMinute != nil {
+		requestsPerMinute = *opts.RequestsPerMinute
+	}
+	if opts.MonthlyQuota != nil {
+		monthlyQuota = *opts.MonthlyQuota
+	}
+
+	key, err := uc.apiKeyRepo.CreateAPIKey(ctx, domain.APIKey{
+		Prefix:            plaintext[:apiKeyPrefixLen],
+		Hash:              hashAPIKey(plaintext),
+		Owner:             opts.Owner,
+		Plan:              opts.Plan,
+		RequestsPerMinute: requestsPerMinute,
+		MonthlyQuota:      monthlyQuota,
+	})
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	uc.audit(ctx, actor, "issue_api_key", apiKeyTarget(key.ID), fmt.Sprintf("owner=%q plan=%s", key.Owner, key.Plan))
+	return key, plaintext, nil
+}
+
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context, actor string) ([]domain.APIKey, error) {
+	uc.audit(ctx, actor, "list_api_keys", "", "")
+	return uc.apiKeyRepo.ListAPIKeys(ctx, uc.month())
+}
+
+func (uc *APIKeyUseCase) GetAPIKeyUsage(ctx context.Context, actor string, id int64) ([]domain.APIKeyUsage, error) {
+	uc.audit(ctx, actor, "view_api_key_usage", apiKeyTarget(id), "")
+	if _, err := uc.apiKeyRepo.GetAPIKeyByID(ctx, id, uc.month()); err != nil {
+		return nil, err
+	}
+	return uc.apiKeyRepo.GetAPIKeyUsage(ctx, id)
+}
+
+// RevokeAPIKey rejects the key from now on. Its usage is kept.
+func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, actor string, id int64) error {
+	key, err := uc.apiKeyRepo.GetAPIKeyByID(ctx, id, uc.month())
+	if err != nil {
+		return err
+	}
+	if err := uc.apiKeyRepo.RevokeAPIKey(ctx, id); err != nil {
+		return err
+	}
+	uc.audit(ctx, actor, "revoke_api_key", apiKeyTarget(id), key.Owner)
+	return nil
+}
+
+// Authenticate resolves a key presented by a client. Unknown and revoked keys
+// are both reported as ErrInvalidAPIKey.
+func (uc *APIKeyUseCase) Authenticate(ctx context.Context, plaintext string) (domain.APIKey, error) {
+	key, err := uc.apiKeyRepo.GetAPIKeyByHash(ctx, hashAPIKey(plaintext), uc.month())
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		uc.logger.DebugContext(ctx, "unknown api key")
+		return domain.APIKey{}, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	if key.Revoked() {
+		uc.logger.DebugContext(ctx, "revoked api key", "api_key_id", key.ID)
+		return domain.APIKey{}, domain.ErrInvalidAPIKey
+	}
+	return key, nil
+}
+
+// RecordUsage counts a request of the key against its monthly quota and
+// returns the key with the updated usage, or ErrAPIKeyQuotaExceeded once the
+// quota is used up.
+func (uc *APIKeyUseCase) RecordUsage(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	usage, err := uc.apiKeyRepo.IncrementAPIKeyUsage(ctx, key.ID, uc.month())
+	if err != nil {
+		return key, err
+	}
+	key.MonthlyUsage = usage
+	if key.QuotaExceeded() {
+		uc.logger.InfoContext(ctx, "api key monthly quota exceeded", "api_key_id", key.ID, "quota", key.MonthlyQuota)
+		return key, domain.ErrAPIKeyQuotaExceeded
+	}
+	return key, nil
+}
+
+func (uc *APIKeyUseCase) month() time.Time {
+	now := uc.now().UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (uc *APIKeyUseCase) audit(ctx context.Context, actor, action, target, details string) {
+	recordAudit(ctx, uc.auditRepo, uc.logger, domain.AuditEntry{
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		Details: details,
+	})
+}
+
+// hashAPIKey uses a fast unsalted hash so keys can be looked up by it; keys
+// are random, so they cannot be guessed from the hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func apiKeyTarget(id int64) string {
+	return "api_key:" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,121 @@
+//go:build unit
+// +build unit
+
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var apiKeyMonth = time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+func newAPIKeyUseCase() (*APIKeyUseCase, *mocks.MockAPIKeyRepository, *mocks.MockAuditLogRepository) {
+	apiKeyRepo := &mocks.MockAPIKeyRepository{}
+	auditRepo := &mocks.MockAuditLogRepository{}
+	uc := NewAPIKeyUseCase(apiKeyRepo, auditRepo, logger.Discard())
+	uc.now = func() time.Time { return time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC) }
+	return uc, apiKeyRepo, auditRepo
+}
+
+func TestAPIKeyUseCase_IssueAPIKey_StoresOnlyTheHash(t *testing.T) {
+	ctx := context.Background()
+	uc, apiKeyRepo, auditRepo := newAPIKeyUseCase()
+
+	var stored domain.APIKey
+	apiKeyRepo.On("CreateAPIKey", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(domain.APIKey)
+	}).Return(domain.APIKey{ID: 7, Owner: "partner", Plan: domain.APIKeyPlanFree}, nil).Once()
+	auditRepo.On("Record", ctx, domain.AuditEntry{
+		Actor:   "support",
+		Action:  "issue_api_key",
+		Target:  "api_key:7",
+		Details: `owner="partner" plan=free`,
+	}).Return(nil).Once()
+
+	quota := int64(500)
+	key, plaintext, err := uc.IssueAPIKey(ctx, "support", out.IssueAPIKeyOptions{
+		Owner:        "partner",
+		Plan:         domain.APIKeyPlanFree,
+		MonthlyQuota: &quota,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), key.ID)
+	assert.True(t, strings.HasPrefix(plaintext, "wk_"))
+	assert.Equal(t, plaintext[:11], stored.Prefix)
+	assert.Equal(t, hashAPIKey(plaintext), stored.Hash)
+	assert.NotContains(t, stored.Hash, plaintext)
+	assert.Equal(t, 60, stored.RequestsPerMinute, "the plan's rate limit")
+	assert.Equal(t, int64(500), stored.MonthlyQuota, "the overridden quota")
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyUseCase_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Now()
+	tests := []struct {
+		name    string
+		key     domain.APIKey
+		repoErr error
+		wantErr error
+	}{
+		{name: "active", key: domain.APIKey{ID: 1, Owner: "partner"}},
+		{name: "unknown", repoErr: domain.ErrAPIKeyNotFound, wantErr: domain.ErrInvalidAPIKey},
+		{name: "revoked", key: domain.APIKey{ID: 1, RevokedAt: &revokedAt}, wantErr: domain.ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, apiKeyRepo, _ := newAPIKeyUseCase()
+			apiKeyRepo.On("GetAPIKeyByHash", ctx, hashAPIKey("wk_secret"), apiKeyMonth).Return(tt.key, tt.repoErr).Once()
+
+			key, err := uc.Authenticate(ctx, "wk_secret")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+}
+
+func TestAPIKeyUseCase_RecordUsage_RejectsOverQuota(t *testing.T) {
+	ctx := context.Background()
+	uc, apiKeyRepo, _ := newAPIKeyUseCase()
+	key := domain.APIKey{ID: 3, MonthlyQuota: 100}
+
+	apiKeyRepo.On("IncrementAPIKeyUsage", ctx, int64(3), apiKeyMonth).Return(int64(100), nil).Once()
+	apiKeyRepo.On("IncrementAPIKeyUsage", ctx, int64(3), apiKeyMonth).Return(int64(101), nil).Once()
+
+	key, err := uc.RecordUsage(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), key.MonthlyUsage)
+
+	key, err = uc.RecordUsage(ctx, key)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyQuotaExceeded)
+	assert.Equal(t, int64(101), key.MonthlyUsage)
+}
+
+func TestAPIKeyUseCase_RevokeAPIKey_NotFound(t *testing.T) {
+	ctx := context.Background()
+	uc, apiKeyRepo, _ := newAPIKeyUseCase()
+	apiKeyRepo.On("GetAPIKeyByID", ctx, int64(9), apiKeyMonth).Return(domain.APIKey{}, domain.ErrAPIKeyNotFound).Once()
+
+	err := uc.RevokeAPIKey(ctx, "support", 9)
+
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	apiKeyRepo.AssertNotCalled(t, "RevokeAPIKey", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type BounceUseCase struct {
+	suppressionRepo  out.SuppressionRepository
+	subscriptionRepo out.SubscriptionRepository
+	bounceSource     out.BounceSource
+	logger           *slog.Logger
+}
+
+func NewBounceUseCase(
+	suppressionRepo out.SuppressionRepository,
+	subscriptionRepo out.SubscriptionRepository,
+	bounceSource out.BounceSource,
+	logger *slog.Logger,
+) *BounceUseCase {
+	return &BounceUseCase{
+		suppressionRepo:  suppressionRepo,
+		subscriptionRepo: subscriptionRepo,
+		bounceSource:     bounceSource,
+		logger:           logger,
+	}
+}
+
+// HandleBounces suppresses addresses that hard-bounced or complained and
+// pauses their subscriptions. It returns the number of suppressed addresses.
+func (uc *BounceUseCase) HandleBounces(ctx context.Context, events []domain.BounceEvent) (int, error) {
+	suppressed := 0
+	for _, event := range events {
+		if !event.Type.Suppresses() {
+			uc.logger.InfoContext(ctx, "ignoring bounce event", "type", event.Type, "email", event.Email, "reason", event.Reason)
+			continue
+		}
+
+		if err := uc.suppressionRepo.Suppress(ctx, domain.Suppression{
+			Email:   event.Email,
+			Reason:  event.Type,
+			Details: event.Reason,
+		}); err != nil {
+			msg := fmt.Sprintf("unable to suppress %s: %v", event.Email, err)
+			uc.logger.ErrorContext(ctx, msg)
+			return suppressed, errors.New(msg)
+		}
+		suppressed++
+
+		paused, err := uc.subscriptionRepo.PauseSubscriptionsByEmail(ctx, event.Email)
+		if err != nil {
+			msg := fmt.Sprintf("unable to pause subscriptions of %s: %v", event.Email, err)
+			uc.logger.ErrorContext(ctx, msg)
+			return suppressed, errors.New(msg)
+		}
+		uc.logger.InfoContext(ctx, "suppressed address", "email", event.Email, "type", event.Type, "paused", paused)
+	}
+	return suppressed, nil
+}
+
+// ProcessMailbox handles pending bounce messages from the configured source.
+func (uc *BounceUseCase) ProcessMailbox(ctx context.Context) error {
+	if uc.bounceSource == nil {
+		return nil
+	}
+
+	err := uc.bounceSource.ProcessBounces(ctx, func(ctx context.Context, events []domain.BounceEvent) error {
+		_, err := uc.HandleBounces(ctx, events)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("unable to process bounce mailbox: %v", err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+//go:build unit
+// +build unit
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBounceSource struct {
+	batches [][]domain.BounceEvent
+	handled int
+}
+
+func (s *stubBounceSource) ProcessBounces(ctx context.Context, handle func(ctx context.Context, events []domain.BounceEvent) error) error {
+	for _, events := range s.batches {
+		if err := handle(ctx, events); err != nil {
+			return err
+		}
+		s.handled++
+	}
+	return nil
+}
+
+func TestBounceUseCase_HandleBounces(t *testing.T) {
+	ctx := context.Background()
+	suppressionRepo := &mocks.MockSuppressionRepository{}
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+
+	suppressionRepo.On("Suppress", ctx, domain.Suppression{Email: "gone@example.com", Reason: domain.BounceTypeHard, Details: "5.1.1 user unknown"}).Return(nil).Once()
+	suppressionRepo.On("Suppress", ctx, domain.Suppression{Email: "angry@example.com", Reason: domain.BounceTypeComplaint}).Return(nil).Once()
+	subscriptionRepo.On("PauseSubscriptionsByEmail", ctx, "gone@example.com").Return(int64(2), nil).Once()
+	subscriptionRepo.On("PauseSubscriptionsByEmail", ctx, "angry@example.com").Return(int64(1), nil).Once()
+
+	uc := NewBounceUseCase(suppressionRepo, subscriptionRepo, nil, logger.Discard())
+	suppressed, err := uc.HandleBounces(ctx, []domain.BounceEvent{
+		{Email: "gone@example.com", Type: domain.BounceTypeHard, Reason: "5.1.1 user unknown"},
+		{Email: "full@example.com", Type: domain.BounceTypeSoft, Reason: "4.2.2 mailbox full"},
+		{Email: "angry@example.com", Type: domain.BounceTypeComplaint},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, suppressed)
+	suppressionRepo.AssertExpectations(t)
+	subscriptionRepo.AssertExpectations(t)
+	subscriptionRepo.AssertNotCalled(t, "PauseSubscriptionsByEmail", ctx, "full@example.com")
+}
+
+func TestBounceUseCase_HandleBounces_StopsOnRepositoryError(t *testing.T) {
+	ctx := context.Background()
+	suppressionRepo := &mocks.MockSuppressionRepository{}
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+	suppressionRepo.On("Suppress", ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+	uc := NewBounceUseCase(suppressionRepo, subscriptionRepo, nil, logger.Discard())
+	suppressed, err := uc.HandleBounces(ctx, []domain.BounceEvent{
+		{Email: "gone@example.com", Type: domain.BounceTypeHard},
+		{Email: "other@example.com", Type: domain.BounceTypeHard},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, suppressed)
+	suppressionRepo.AssertExpectations(t)
+	subscriptionRepo.AssertNotCalled(t, "PauseSubscriptionsByEmail", mock.Anything, mock.Anything)
+}
+
+func TestBounceUseCase_ProcessMailbox(t *testing.T) {
+	ctx := context.Background()
+	suppressionRepo := &mocks.MockSuppressionRepository{}
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+	suppressionRepo.On("Suppress", ctx, mock.Anything).Return(nil)
+	subscriptionRepo.On("PauseSubscriptionsByEmail", ctx, mock.Anything).Return(int64(0), nil)
+
+	source := &stubBounceSource{batches: [][]domain.BounceEvent{
+		{{Email: "a@example.com", Type: domain.BounceTypeHard}},
+		{{Email: "b@example.com", Type: domain.BounceTypeComplaint}},
+	}}
+	uc := NewBounceUseCase(suppressionRepo, subscriptionRepo, source, logger.Discard())
+
+	require.NoError(t, uc.ProcessMailbox(ctx))
+	assert.Equal(t, 2, source.handled)
+	suppressionRepo.AssertNumberOfCalls(t, "Suppress", 2)
+}
+
+func TestBounceUseCase_ProcessMailbox_WithoutSource(t *testing.T) {
+	uc := NewBounceUseCase(&mocks.MockSuppressionRepository{}, &mocks.MockSubscriptionRepository{}, nil, logger.Discard())
+
+	assert.NoError(t, uc.ProcessMailbox(context.Background()))
+}
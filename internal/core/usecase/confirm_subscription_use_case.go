@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 	"weather-api/internal/core/service"
@@ -14,22 +15,34 @@ type ConfirmSubscriptionUseCase struct {
 	subscriptionRepo out.SubscriptionRepository
 	tokenService     service.TokenService
 	emailService     service.EmailService
+	schedules        service.Schedules
+	metrics          out.BusinessMetrics
+	logger           *slog.Logger
 }
 
 func NewConfirmSubscriptionUseCase(
 	subscriptionRepo out.SubscriptionRepository,
 	tokenService service.TokenService,
 	emailService service.EmailService,
+	schedules service.Schedules,
+	metrics out.BusinessMetrics,
+	logger *slog.Logger,
 ) *ConfirmSubscriptionUseCase {
+	if metrics == nil {
+		metrics = out.NopBusinessMetrics{}
+	}
 	return &ConfirmSubscriptionUseCase{
 		subscriptionRepo: subscriptionRepo,
 		tokenService:     tokenService,
 		emailService:     emailService,
+		schedules:        schedules,
+		metrics:          metrics,
+		logger:           logger,
 	}
 }
 
 func (uc *ConfirmSubscriptionUseCase) ConfirmSubscription(ctx context.Context, token string) error {
-	log.Printf("Starting subscription confirmation for token: %s", token)
+	uc.logger.DebugContext(ctx, "confirming subscription", "token", token)
 
 	if err := uc.tokenService.CheckTokenExists(ctx, token); err != nil {
 		return err
@@ -38,17 +51,18 @@ func (uc *ConfirmSubscriptionUseCase) ConfirmSubscription(ctx context.Context, t
 	subscription, err := uc.getSubscription(ctx, token)
 	if err != nil {
 		msg := fmt.Sprintf("unable to get subscription for token %s: %v", token, err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
 	if err := uc.confirmSubscription(ctx, subscription); err != nil {
 		msg := fmt.Sprintf("unable to confirm subscription for token %s: %v", token, err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
-	log.Printf("Successfully confirmed subscription for token: %s", token)
+	uc.metrics.SubscriptionConfirmed(subscription.Frequency)
+	uc.logger.InfoContext(ctx, "confirmed subscription", "token", token)
 	return nil
 }
 
@@ -56,7 +70,7 @@ func (uc *ConfirmSubscriptionUseCase) getSubscription(ctx context.Context, token
 	subscription, err := uc.subscriptionRepo.GetSubscriptionByToken(ctx, token)
 	if err != nil {
 		msg := fmt.Sprintf("unable to get subscription: %v", err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return domain.Subscription{}, errors.New(msg)
 	}
 	return subscription, nil
@@ -67,11 +81,19 @@ func (uc *ConfirmSubscriptionUseCase) confirmSubscription(ctx context.Context, s
 		return domain.ErrSubscriptionAlreadyConfirmed
 	}
 
+	nextRunAt, err := uc.schedules.NextRunAt(subscription.Frequency, subscription.Schedule, time.Now().In(subscription.Location()))
+	if err != nil {
+		msg := fmt.Sprintf("unable to schedule subscription: %v", err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
 	subscription.IsConfirmed = true
+	subscription.NextRunAt = nextRunAt
 
 	if err := uc.subscriptionRepo.UpdateSubscription(ctx, subscription); err != nil {
 		msg := fmt.Sprintf("unable to update subscription: %v", err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type ManageSubscriberUseCase struct {
+	subscriberRepo   out.SubscriberRepository
+	subscriptionRepo out.SubscriptionRepository
+	metrics          out.BusinessMetrics
+	logger           *slog.Logger
+}
+
+func NewManageSubscriberUseCase(
+	subscriberRepo out.SubscriberRepository,
+	subscriptionRepo out.SubscriptionRepository,
+	metrics out.BusinessMetrics,
+	logger *slog.Logger,
+) *ManageSubscriberUseCase {
+	if metrics == nil {
+		metrics = out.NopBusinessMetrics{}
+	}
+	return &ManageSubscriberUseCase{
+		subscriberRepo:   subscriberRepo,
+		subscriptionRepo: subscriptionRepo,
+		metrics:          metrics,
+		logger:           logger,
+	}
+}
+
+func (uc *ManageSubscriberUseCase) GetSubscriber(ctx context.Context, token string) (domain.Subscriber, error) {
+	if token == "" {
+		return domain.Subscriber{}, domain.ErrInvalidToken
+	}
+
+	subscriber, err := uc.subscriberRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, domain.ErrSubscriberNotFound) {
+			return domain.Subscriber{}, domain.ErrTokenNotFound
+		}
+		msg := fmt.Sprintf("unable to get subscriber: %v", err)
+		uc.logger.ErrorContext(ctx, msg)
+		return domain.Subscriber{}, errors.New(msg)
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetSubscriptionsBySubscriber(ctx, subscriber.ID)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get subscriptions for subscriber %d: %v", subscriber.ID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return domain.Subscriber{}, errors.New(msg)
+	}
+	subscriber.Subscriptions = subscriptions
+
+	return subscriber, nil
+}
+
+func (uc *ManageSubscriberUseCase) UnsubscribeAll(ctx context.Context, token string) error {
+	subscriber, err := uc.GetSubscriber(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.subscriptionRepo.DeleteSubscriptionsBySubscriber(ctx, subscriber.ID); err != nil {
+		msg := fmt.Sprintf("unable to delete subscriptions for subscriber %d: %v", subscriber.ID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	uc.metrics.SubscriptionsRemoved(len(subscriber.Subscriptions))
+	uc.logger.InfoContext(ctx, "removed all subscriptions of subscriber", "subscriber_id", subscriber.ID)
+	return nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+type DigestPreparer interface {
+	PrepareDigest(ctx context.Context, sub domain.Subscription) (domain.WeatherDigest, error)
+}
+
+type EmailRenderer interface {
+	RenderUpdates(digest domain.WeatherDigest) out.SendEmailOptions
+	RenderConfirmationEmail(subscription *domain.Subscription) out.SendEmailOptions
+}
+
+type PreviewUseCase struct {
+	subscriptionRepo out.SubscriptionRepository
+	digestPreparer   DigestPreparer
+	emailRenderer    EmailRenderer
+	auditRepo        out.AuditLogRepository
+	logger           *slog.Logger
+}
+
+func NewPreviewUseCase(
+	subscriptionRepo out.SubscriptionRepository,
+	digestPreparer DigestPreparer,
+	emailRenderer EmailRenderer,
+	auditRepo out.AuditLogRepository,
+	logger *slog.Logger,
+) *PreviewUseCase {
+	return &PreviewUseCase{
+		subscriptionRepo: subscriptionRepo,
+		digestPreparer:   digestPreparer,
+		emailRenderer:    emailRenderer,
+		auditRepo:        auditRepo,
+		logger:           logger,
+	}
+}
+
+func (uc *PreviewUseCase) PreviewUpdate(ctx context.Context, actor, token string) (out.SendEmailOptions, error) {
+	subscription, err := uc.subscriptionRepo.GetSubscriptionByToken(ctx, token)
+	if err != nil {
+		return out.SendEmailOptions{}, err
+	}
+	uc.audit(ctx, actor, "preview_update", subscription)
+
+	digest, err := uc.digestPreparer.PrepareDigest(ctx, subscription)
+	if err != nil {
+		return out.SendEmailOptions{}, err
+	}
+	return uc.emailRenderer.RenderUpdates(digest), nil
+}
+
+func (uc *PreviewUseCase) PreviewConfirmation(ctx context.Context, actor, token string) (out.SendEmailOptions, error) {
+	subscription, err := uc.subscriptionRepo.GetSubscriptionByToken(ctx, token)
+	if err != nil {
+		return out.SendEmailOptions{}, err
+	}
+	uc.audit(ctx, actor, "preview_confirmation", subscription)
+
+	return uc.emailRenderer.RenderConfirmationEmail(&subscription), nil
+}
+
+func (uc *PreviewUseCase) audit(ctx context.Context, actor, action string, subscription domain.Subscription) {
+	recordAudit(ctx, uc.auditRepo, uc.logger, domain.AuditEntry{
+		Actor:   actor,
+		Action:  action,
+		Target:  subscriptionTarget(subscription.ID),
+		Details: subscription.Email,
+	})
+}
@@ -0,0 +1,108 @@
+//go:build unit
+// +build unit
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/service"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDigestPreparer struct{ mock.Mock }
+
+func (m *mockDigestPreparer) PrepareDigest(ctx context.Context, sub domain.Subscription) (domain.WeatherDigest, error) {
+	args := m.Called(ctx, sub)
+	return args.Get(0).(domain.WeatherDigest), args.Error(1)
+}
+
+func newPreviewUseCase() (*PreviewUseCase, *mocks.MockSubscriptionRepository, *mocks.MockAuditLogRepository, *mockDigestPreparer, *mocks.MockEmailService) {
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+	auditRepo := &mocks.MockAuditLogRepository{}
+	preparer := &mockDigestPreparer{}
+	sender := &mocks.MockEmailService{}
+	uc := NewPreviewUseCase(subscriptionRepo, preparer, service.NewEmailService(sender, nil, nil, logger.Discard()), auditRepo, logger.Discard())
+	return uc, subscriptionRepo, auditRepo, preparer, sender
+}
+
+func TestPreviewUseCase_PreviewUpdate(t *testing.T) {
+	ctx := context.Background()
+	uc, subscriptionRepo, auditRepo, preparer, sender := newPreviewUseCase()
+	sub := domain.Subscription{ID: 7, Email: "user@example.com", Token: "token", City: &domain.City{Name: "Kyiv"}}
+	digest := domain.WeatherDigest{Email: sub.Email, Updates: []domain.WeatherUpdate{
+		{Subscription: sub, Weather: domain.Weather{Temperature: 21.5, Humidity: 40, Description: "Clear sky"}},
+	}}
+
+	subscriptionRepo.On("GetSubscriptionByToken", ctx, "token").Return(sub, nil)
+	preparer.On("PrepareDigest", ctx, sub).Return(digest, nil)
+	auditRepo.On("Record", ctx, domain.AuditEntry{
+		Actor:   "support",
+		Action:  "preview_update",
+		Target:  "subscription:7",
+		Details: "user@example.com",
+	}).Return(nil).Once()
+
+	email, err := uc.PreviewUpdate(ctx, "support", "token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", email.To)
+	assert.NotEmpty(t, email.Subject)
+	assert.Contains(t, email.Body, "Kyiv")
+	assert.Contains(t, email.Body, "Clear sky")
+	auditRepo.AssertExpectations(t)
+	sender.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+}
+
+func TestPreviewUseCase_PreviewUpdate_WeatherUnavailable(t *testing.T) {
+	ctx := context.Background()
+	uc, subscriptionRepo, auditRepo, preparer, _ := newPreviewUseCase()
+	sub := domain.Subscription{ID: 7, Email: "user@example.com", Token: "token", City: &domain.City{Name: "Kyiv"}}
+
+	subscriptionRepo.On("GetSubscriptionByToken", ctx, "token").Return(sub, nil)
+	preparer.On("PrepareDigest", ctx, sub).Return(domain.WeatherDigest{}, errors.New("provider down"))
+	auditRepo.On("Record", ctx, mock.Anything).Return(nil)
+
+	_, err := uc.PreviewUpdate(ctx, "support", "token")
+
+	assert.EqualError(t, err, "provider down")
+}
+
+func TestPreviewUseCase_PreviewConfirmation(t *testing.T) {
+	ctx := context.Background()
+	uc, subscriptionRepo, auditRepo, _, sender := newPreviewUseCase()
+	sub := domain.Subscription{ID: 8, Email: "user@example.com", Token: "confirm-token", City: &domain.City{Name: "Lviv"}}
+
+	subscriptionRepo.On("GetSubscriptionByToken", ctx, "confirm-token").Return(sub, nil)
+	auditRepo.On("Record", ctx, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+		return entry.Action == "preview_confirmation" && entry.Target == "subscription:8"
+	})).Return(nil).Once()
+
+	email, err := uc.PreviewConfirmation(ctx, "support", "confirm-token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", email.To)
+	assert.Contains(t, email.Body, "confirm-token")
+	auditRepo.AssertExpectations(t)
+	sender.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+}
+
+func TestPreviewUseCase_UnknownToken(t *testing.T) {
+	ctx := context.Background()
+	uc, subscriptionRepo, auditRepo, preparer, _ := newPreviewUseCase()
+
+	subscriptionRepo.On("GetSubscriptionByToken", ctx, "missing").Return(domain.Subscription{}, domain.ErrSubscriptionNotFound)
+
+	_, err := uc.PreviewUpdate(ctx, "support", "missing")
+
+	assert.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+	preparer.AssertNotCalled(t, "PrepareDigest", mock.Anything, mock.Anything)
+	auditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
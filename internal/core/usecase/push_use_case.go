@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/core/service"
+)
+
+type PushUseCase struct {
+	subscriptionRepo out.SubscriptionRepository
+	pushRepo         out.PushRepository
+	tokenService     service.TokenService
+	logger           *slog.Logger
+}
+
+func NewPushUseCase(
+	subscriptionRepo out.SubscriptionRepository,
+	pushRepo out.PushRepository,
+	tokenService service.TokenService,
+	logger *slog.Logger,
+) *PushUseCase {
+	return &PushUseCase{
+		subscriptionRepo: subscriptionRepo,
+		pushRepo:         pushRepo,
+		tokenService:     tokenService,
+		logger:           logger,
+	}
+}
+
+// RegisterPush delivers the subscription's updates to a browser instead of email.
+func (uc *PushUseCase) RegisterPush(ctx context.Context, token string, push domain.PushSubscription) error {
+	sub, err := subscriptionByToken(ctx, uc.logger, uc.tokenService, uc.subscriptionRepo, token)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.pushRepo.SavePushSubscription(ctx, sub.ID, push); err != nil {
+		msg := fmt.Sprintf("unable to register push subscription for subscription %d: %v", sub.ID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	uc.logger.InfoContext(ctx, "registered push subscription", "subscription_id", sub.ID)
+	return nil
+}
+
+// RemovePush switches the subscription back to email delivery.
+func (uc *PushUseCase) RemovePush(ctx context.Context, token string) error {
+	sub, err := subscriptionByToken(ctx, uc.logger, uc.tokenService, uc.subscriptionRepo, token)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.pushRepo.DeletePushSubscription(ctx, sub.ID); err != nil {
+		msg := fmt.Sprintf("unable to remove push subscription of subscription %d: %v", sub.ID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	uc.logger.InfoContext(ctx, "removed push subscription", "subscription_id", sub.ID)
+	return nil
+}
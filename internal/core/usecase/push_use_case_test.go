@@ -0,0 +1,47 @@
+//go:build unit
+// +build unit
+
+package usecase
+
+import (
+	"context"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPushUseCase_RegisterPush(t *testing.T) {
+	ctx := context.Background()
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+	pushRepo := &mocks.MockPushRepository{}
+	tokenService := &mocks.MockTokenService{}
+	push := domain.PushSubscription{Endpoint: "https://push.example.com/abc", P256dh: "key", Auth: "auth"}
+
+	tokenService.On("CheckTokenExists", ctx, "token").Return(nil)
+	subscriptionRepo.On("GetSubscriptionByToken", ctx, "token").Return(domain.Subscription{ID: 5}, nil)
+	pushRepo.On("SavePushSubscription", ctx, int64(5), push).Return(nil).Once()
+
+	uc := NewPushUseCase(subscriptionRepo, pushRepo, tokenService, logger.Discard())
+	err := uc.RegisterPush(ctx, "token", push)
+
+	assert.NoError(t, err)
+	pushRepo.AssertExpectations(t)
+}
+
+func TestPushUseCase_RemovePush_UnknownToken(t *testing.T) {
+	ctx := context.Background()
+	pushRepo := &mocks.MockPushRepository{}
+	tokenService := &mocks.MockTokenService{}
+
+	tokenService.On("CheckTokenExists", ctx, "missing").Return(domain.ErrTokenNotFound)
+
+	uc := NewPushUseCase(&mocks.MockSubscriptionRepository{}, pushRepo, tokenService, logger.Discard())
+	err := uc.RemovePush(ctx, "missing")
+
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+	pushRepo.AssertNotCalled(t, "DeletePushSubscription", mock.Anything, mock.Anything)
+}
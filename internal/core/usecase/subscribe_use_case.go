@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/domain"
 	"weather-api/internal/core/ports/out"
 	"weather-api/internal/core/service"
@@ -17,53 +17,81 @@ type CityService interface {
 type SubscribeUseCase struct {
 	subscriptionRepo out.SubscriptionRepository
 	subscriptionSvc  out.SubscriptionService
+	subscriberSvc    out.SubscriberService
 	cityService      CityService
 	emailService     service.EmailService
+	metrics          out.BusinessMetrics
+	logger           *slog.Logger
 }
 
 func NewSubscribeUseCase(
 	subscriptionRepo out.SubscriptionRepository,
 	subscriptionSvc out.SubscriptionService,
+	subscriberSvc out.SubscriberService,
 	cityService CityService,
 	emailService service.EmailService,
+	metrics out.BusinessMetrics,
+	logger *slog.Logger,
 ) *SubscribeUseCase {
+	if metrics == nil {
+		metrics = out.NopBusinessMetrics{}
+	}
 	return &SubscribeUseCase{
 		subscriptionRepo: subscriptionRepo,
 		subscriptionSvc:  subscriptionSvc,
+		subscriberSvc:    subscriberSvc,
 		cityService:      cityService,
 		emailService:     emailService,
+		metrics:          metrics,
+		logger:           logger,
 	}
 }
 
+// Subscribe creates an unconfirmed subscription and emails the confirmation
+// link. Subscriptions from a Telegram chat are addressed to the chat instead
+// and no email is sent; the caller confirms them directly.
 func (uc *SubscribeUseCase) Subscribe(ctx context.Context, opts out.SubscribeOptions) (string, error) {
-	log.Printf("Starting subscription process for email: %s, city: %s, frequency: %s", opts.Email, opts.City, opts.Frequency)
+	if opts.TelegramChatID != 0 {
+		opts.Email = domain.TelegramAddress(opts.TelegramChatID)
+	}
+	uc.logger.DebugContext(ctx, "subscribing", "email", opts.Email, "city", opts.City, "frequency", opts.Frequency)
 
 	city, err := uc.ensureCityExists(ctx, opts.City)
 	if err != nil {
 		msg := fmt.Sprintf("unable to check city existence for %s: %v", opts.City, err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return "", errors.New(msg)
 	}
 
 	if err := uc.checkExistingSubscription(ctx, opts, city.ID); err != nil {
 		msg := fmt.Sprintf("unable to check subscription existence for %s: %v", opts.Email, err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return "", err
 	}
 
-	token, err := uc.createSubscription(ctx, opts, city)
+	subscriber, err := uc.subscriberSvc.EnsureSubscriberExists(ctx, opts.Email)
+	if err != nil {
+		msg := fmt.Sprintf("unable to ensure subscriber exists for %s: %v", opts.Email, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return "", errors.New(msg)
+	}
+
+	token, err := uc.createSubscription(ctx, opts, city, subscriber)
 	if err != nil {
 		msg := fmt.Sprintf("unable to create subscription for %s: %v", opts.Email, err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return "", errors.New(msg)
 	}
+	uc.metrics.SubscriptionCreated(opts.Frequency)
 
-	if err := uc.sendConfirmationEmail(city, token, opts.Email); err != nil {
-		msg := fmt.Sprintf("unable to send confirmation email for %s: %v", opts.Email, err)
-		log.Print(msg)
+	if opts.TelegramChatID == 0 {
+		if err := uc.sendConfirmationEmail(ctx, city, token, opts.Email); err != nil {
+			msg := fmt.Sprintf("unable to send confirmation email for %s: %v", opts.Email, err)
+			uc.logger.ErrorContext(ctx, msg)
+		}
 	}
 
-	log.Printf("Successfully created subscription for email: %s, city: %s", opts.Email, opts.City)
+	uc.logger.InfoContext(ctx, "created subscription", "email", opts.Email, "city", opts.City)
 	return token, nil
 }
 
@@ -84,35 +112,45 @@ func (uc *SubscribeUseCase) checkExistingSubscription(ctx context.Context, opts
 	})
 	if err != nil {
 		msg := fmt.Sprintf("unable to check subscription existence: %v", err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
 	if exists {
-		log.Printf("Email %s already subscribed to city %s with frequency %s", opts.Email, opts.City, opts.Frequency)
+		uc.logger.InfoContext(ctx, "email already subscribed", "email", opts.Email, "city", opts.City, "frequency", opts.Frequency)
 		return domain.ErrEmailAlreadySubscribed
 	}
 
 	return nil
 }
 
-func (uc *SubscribeUseCase) createSubscription(ctx context.Context, opts out.SubscribeOptions, city domain.City) (string, error) {
-	token, err := uc.subscriptionSvc.CreateSubscription(ctx, opts.Email, city.ID, opts.Frequency)
+func (uc *SubscribeUseCase) createSubscription(ctx context.Context, opts out.SubscribeOptions, city domain.City, subscriber domain.Subscriber) (string, error) {
+	token, err := uc.subscriptionSvc.CreateSubscription(ctx, out.CreateSubscriptionOptions{
+		SubscriberID:   subscriber.ID,
+		Email:          opts.Email,
+		CityID:         city.ID,
+		Frequency:      opts.Frequency,
+		Schedule:       opts.Schedule,
+		Timezone:       opts.Timezone,
+		QuietHours:     opts.QuietHours,
+		SevereAlerts:   opts.SevereAlerts,
+		TelegramChatID: opts.TelegramChatID,
+	})
 	if err != nil {
 		msg := fmt.Sprintf("unable to create subscription: %v", err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return "", errors.New(msg)
 	}
 
 	return token, nil
 }
 
-func (uc *SubscribeUseCase) sendConfirmationEmail(city domain.City, token string, email string) error {
+func (uc *SubscribeUseCase) sendConfirmationEmail(ctx context.Context, city domain.City, token string, email string) error {
 	subscription := &domain.Subscription{
 		Email: email,
 		City:  &city,
 		Token: token,
 	}
 
-	return uc.emailService.SendConfirmationEmail(subscription)
+	return uc.emailService.SendConfirmationEmail(ctx, subscription)
 }
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+)
+
+// TelegramChatUseCase manages the subscriptions created from a Telegram chat.
+type TelegramChatUseCase struct {
+	subscriberRepo   out.SubscriberRepository
+	subscriptionRepo out.SubscriptionRepository
+	metrics          out.BusinessMetrics
+	logger           *slog.Logger
+}
+
+func NewTelegramChatUseCase(
+	subscriberRepo out.SubscriberRepository,
+	subscriptionRepo out.SubscriptionRepository,
+	metrics out.BusinessMetrics,
+	logger *slog.Logger,
+) *TelegramChatUseCase {
+	if metrics == nil {
+		metrics = out.NopBusinessMetrics{}
+	}
+	return &TelegramChatUseCase{
+		subscriberRepo:   subscriberRepo,
+		subscriptionRepo: subscriptionRepo,
+		metrics:          metrics,
+		logger:           logger,
+	}
+}
+
+func (uc *TelegramChatUseCase) ListSubscriptions(ctx context.Context, chatID int64) ([]domain.Subscription, error) {
+	subscriber, err := uc.subscriberRepo.GetByEmail(ctx, domain.TelegramAddress(chatID))
+	if errors.Is(err, domain.ErrSubscriberNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		msg := fmt.Sprintf("unable to get subscriber of chat %d: %v", chatID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetSubscriptionsBySubscriber(ctx, subscriber.ID)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get subscriptions of chat %d: %v", chatID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return nil, errors.New(msg)
+	}
+	return subscriptions, nil
+}
+
+// Unsubscribe removes the chat's subscriptions for city, or all of them when
+// city is empty, and returns how many were removed.
+func (uc *TelegramChatUseCase) Unsubscribe(ctx context.Context, chatID int64, city string) (int, error) {
+	subscriptions, err := uc.ListSubscriptions(ctx, chatID)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, sub := range subscriptions {
+		if city != "" && (sub.City == nil || !strings.EqualFold(sub.City.Name, city)) {
+			continue
+		}
+		if err := uc.subscriptionRepo.DeleteSubscription(ctx, sub.Token); err != nil {
+			msg := fmt.Sprintf("unable to delete subscription %d of chat %d: %v", sub.ID, chatID, err)
+			uc.logger.ErrorContext(ctx, msg)
+			uc.metrics.SubscriptionsRemoved(removed)
+			return removed, errors.New(msg)
+		}
+		removed++
+	}
+
+	uc.metrics.SubscriptionsRemoved(removed)
+	uc.logger.InfoContext(ctx, "removed chat subscriptions", "chat_id", chatID, "removed", removed)
+	return removed, nil
+}
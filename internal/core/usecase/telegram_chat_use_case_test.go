@@ -0,0 +1,51 @@
+//go:build unit
+// +build unit
+
+package usecase
+
+import (
+	"context"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramChatUseCase_UnsubscribeCity(t *testing.T) {
+	ctx := context.Background()
+	subscriberRepo := &mocks.MockSubscriberRepository{}
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+
+	subscriberRepo.On("GetByEmail", ctx, "telegram:42").Return(domain.Subscriber{ID: 3}, nil)
+	subscriptionRepo.On("GetSubscriptionsBySubscriber", ctx, int64(3)).Return([]domain.Subscription{
+		{ID: 1, Token: "kyiv-daily", City: &domain.City{Name: "Kyiv"}},
+		{ID: 2, Token: "lviv-daily", City: &domain.City{Name: "Lviv"}},
+		{ID: 3, Token: "kyiv-hourly", City: &domain.City{Name: "Kyiv"}},
+	}, nil)
+	subscriptionRepo.On("DeleteSubscription", ctx, "kyiv-daily").Return(nil).Once()
+	subscriptionRepo.On("DeleteSubscription", ctx, "kyiv-hourly").Return(nil).Once()
+
+	uc := NewTelegramChatUseCase(subscriberRepo, subscriptionRepo, nil, logger.Discard())
+	removed, err := uc.Unsubscribe(ctx, 42, "kyiv")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	subscriptionRepo.AssertExpectations(t)
+	subscriptionRepo.AssertNotCalled(t, "DeleteSubscription", ctx, "lviv-daily")
+}
+
+func TestTelegramChatUseCase_ListSubscriptions_UnknownChat(t *testing.T) {
+	ctx := context.Background()
+	subscriberRepo := &mocks.MockSubscriberRepository{}
+
+	subscriberRepo.On("GetByEmail", ctx, "telegram:7").Return(domain.Subscriber{}, domain.ErrSubscriberNotFound)
+
+	uc := NewTelegramChatUseCase(subscriberRepo, &mocks.MockSubscriptionRepository{}, nil, logger.Discard())
+	subscriptions, err := uc.ListSubscriptions(ctx, 7)
+
+	assert.NoError(t, err)
+	assert.Empty(t, subscriptions)
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"weather-api/internal/core/ports/out"
 	"weather-api/internal/core/service"
 )
@@ -12,32 +12,42 @@ import (
 type UnsubscribeUseCase struct {
 	subscriptionRepo out.SubscriptionRepository
 	tokenService     service.TokenService
+	metrics          out.BusinessMetrics
+	logger           *slog.Logger
 }
 
 func NewUnsubscribeUseCase(
 	subscriptionRepo out.SubscriptionRepository,
 	tokenService service.TokenService,
+	metrics out.BusinessMetrics,
+	logger *slog.Logger,
 ) *UnsubscribeUseCase {
+	if metrics == nil {
+		metrics = out.NopBusinessMetrics{}
+	}
 	return &UnsubscribeUseCase{
 		subscriptionRepo: subscriptionRepo,
 		tokenService:     tokenService,
+		metrics:          metrics,
+		logger:           logger,
 	}
 }
 
 func (uc *UnsubscribeUseCase) Unsubscribe(ctx context.Context, token string) error {
-	log.Printf("Starting unsubscribe process for token: %s", token)
+	uc.logger.DebugContext(ctx, "unsubscribing", "token", token)
 
 	if err := uc.tokenService.CheckTokenExists(ctx, token); err != nil {
-		log.Printf("Unable to validate token %s: %v", token, err)
+		uc.logger.InfoContext(ctx, "unable to validate token", "token", token, "error", err)
 		return err
 	}
 
 	if err := uc.subscriptionRepo.DeleteSubscription(ctx, token); err != nil {
 		msg := fmt.Sprintf("unable to delete subscription for token %s: %v", token, err)
-		log.Print(msg)
+		uc.logger.ErrorContext(ctx, msg)
 		return errors.New(msg)
 	}
 
-	log.Printf("Successfully unsubscribed for token: %s", token)
+	uc.metrics.SubscriptionsRemoved(1)
+	uc.logger.InfoContext(ctx, "unsubscribed", "token", token)
 	return nil
 }
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/core/ports/out"
+	"weather-api/internal/core/service"
+)
+
+type WebhookUseCase struct {
+	subscriptionRepo out.SubscriptionRepository
+	webhookRepo      out.WebhookRepository
+	tokenService     service.TokenService
+	logger           *slog.Logger
+}
+
+func NewWebhookUseCase(
+	subscriptionRepo out.SubscriptionRepository,
+	webhookRepo out.WebhookRepository,
+	tokenService service.TokenService,
+	logger *slog.Logger,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		subscriptionRepo: subscriptionRepo,
+		webhookRepo:      webhookRepo,
+		tokenService:     tokenService,
+		logger:           logger,
+	}
+}
+
+// RegisterWebhook delivers the subscription's updates to url instead of email.
+// Registering again replaces the webhook and re-enables it if it was disabled.
+func (uc *WebhookUseCase) RegisterWebhook(ctx context.Context, token string, url string, secret string) error {
+	sub, err := subscriptionByToken(ctx, uc.logger, uc.tokenService, uc.subscriptionRepo, token)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.webhookRepo.SaveWebhook(ctx, sub.ID, domain.Webhook{URL: url, Secret: secret}); err != nil {
+		msg := fmt.Sprintf("unable to register webhook for subscription %d: %v", sub.ID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	uc.logger.InfoContext(ctx, "registered webhook", "subscription_id", sub.ID)
+	return nil
+}
+
+// RemoveWebhook switches the subscription back to email delivery.
+func (uc *WebhookUseCase) RemoveWebhook(ctx context.Context, token string) error {
+	sub, err := subscriptionByToken(ctx, uc.logger, uc.tokenService, uc.subscriptionRepo, token)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.webhookRepo.DeleteWebhook(ctx, sub.ID); err != nil {
+		msg := fmt.Sprintf("unable to remove webhook of subscription %d: %v", sub.ID, err)
+		uc.logger.ErrorContext(ctx, msg)
+		return errors.New(msg)
+	}
+
+	uc.logger.InfoContext(ctx, "removed webhook", "subscription_id", sub.ID)
+	return nil
+}
+
+// subscriptionByToken resolves a subscription token, returning the token
+// service errors unchanged so handlers can map them.
+func subscriptionByToken(
+	ctx context.Context,
+	logger *slog.Logger,
+	tokenService service.TokenService,
+	subscriptionRepo out.SubscriptionRepository,
+	token string,
+) (domain.Subscription, error) {
+	if err := tokenService.CheckTokenExists(ctx, token); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	sub, err := subscriptionRepo.GetSubscriptionByToken(ctx, token)
+	if err != nil {
+		msg := fmt.Sprintf("unable to get subscription for token %s: %v", token, err)
+		logger.ErrorContext(ctx, msg)
+		return domain.Subscription{}, errors.New(msg)
+	}
+	return sub, nil
+}
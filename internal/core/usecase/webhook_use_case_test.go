@@ -0,0 +1,46 @@
+//go:build unit
+// +build unit
+
+package usecase
+
+import (
+	"context"
+	"testing"
+	"weather-api/internal/core/domain"
+	"weather-api/internal/mocks"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookUseCase_RegisterWebhook(t *testing.T) {
+	ctx := context.Background()
+	subscriptionRepo := &mocks.MockSubscriptionRepository{}
+	webhookRepo := &mocks.MockWebhookRepository{}
+	tokenService := &mocks.MockTokenService{}
+
+	tokenService.On("CheckTokenExists", ctx, "token").Return(nil)
+	subscriptionRepo.On("GetSubscriptionByToken", ctx, "token").Return(domain.Subscription{ID: 5}, nil)
+	webhookRepo.On("SaveWebhook", ctx, int64(5), domain.Webhook{URL: "https://example.com/hook", Secret: "0123456789abcdef"}).Return(nil).Once()
+
+	uc := NewWebhookUseCase(subscriptionRepo, webhookRepo, tokenService, logger.Discard())
+	err := uc.RegisterWebhook(ctx, "token", "https://example.com/hook", "0123456789abcdef")
+
+	assert.NoError(t, err)
+	webhookRepo.AssertExpectations(t)
+}
+
+func TestWebhookUseCase_RemoveWebhook_UnknownToken(t *testing.T) {
+	ctx := context.Background()
+	webhookRepo := &mocks.MockWebhookRepository{}
+	tokenService := &mocks.MockTokenService{}
+
+	tokenService.On("CheckTokenExists", ctx, "missing").Return(domain.ErrTokenNotFound)
+
+	uc := NewWebhookUseCase(&mocks.MockSubscriptionRepository{}, webhookRepo, tokenService, logger.Discard())
+	err := uc.RemoveWebhook(ctx, "missing")
+
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+	webhookRepo.AssertNotCalled(t, "DeleteWebhook", mock.Anything, mock.Anything)
+}
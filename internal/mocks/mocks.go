@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 	"weather-api/internal/core/ports/out"
 
 	"github.com/stretchr/testify/mock"
@@ -18,6 +19,36 @@ func (m *MockSubscriptionRepository) GetSubscriptionsByFrequency(ctx context.Con
 	return args.Get(0).([]domain.Subscription), args.Error(1)
 }
 
+func (m *MockSubscriptionRepository) GetDueSubscriptions(ctx context.Context, now time.Time) ([]domain.Subscription, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) MarkSent(ctx context.Context, opts out.MarkSentOptions) error {
+	args := m.Called(ctx, opts)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) GetSubscriptionByID(ctx context.Context, id int64) (domain.Subscription, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) SearchSubscriptions(ctx context.Context, opts out.SearchSubscriptionsOptions) ([]domain.Subscription, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) UpdateNextRun(ctx context.Context, subscriptionID int64, nextRunAt time.Time) error {
+	args := m.Called(ctx, subscriptionID, nextRunAt)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) PauseSubscriptionsByEmail(ctx context.Context, email string) (int64, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockSubscriptionRepository) CreateSubscription(ctx context.Context, sub domain.Subscription) error {
 	args := m.Called(ctx, sub)
 	return args.Error(0)
@@ -48,11 +79,43 @@ func (m *MockSubscriptionRepository) IsSubscriptionExists(ctx context.Context, o
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockSubscriptionRepository) GetAlertSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetSubscriptionsBySubscriber(ctx context.Context, subscriberID int64) ([]domain.Subscription, error) {
+	args := m.Called(ctx, subscriberID)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) DeleteSubscriptionsBySubscriber(ctx context.Context, subscriberID int64) error {
+	args := m.Called(ctx, subscriberID)
+	return args.Error(0)
+}
+
 func (m *MockSubscriptionRepository) IsTokenExists(ctx context.Context, token string) (bool, error) {
 	args := m.Called(ctx, token)
 	return args.Bool(0), args.Error(1)
 }
 
+type MockSubscriberRepository struct{ mock.Mock }
+
+func (m *MockSubscriberRepository) Create(ctx context.Context, subscriber domain.Subscriber) (domain.Subscriber, error) {
+	args := m.Called(ctx, subscriber)
+	return args.Get(0).(domain.Subscriber), args.Error(1)
+}
+
+func (m *MockSubscriberRepository) GetByEmail(ctx context.Context, email string) (domain.Subscriber, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(domain.Subscriber), args.Error(1)
+}
+
+func (m *MockSubscriberRepository) GetByToken(ctx context.Context, token string) (domain.Subscriber, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(domain.Subscriber), args.Error(1)
+}
+
 type MockWeatherProvider struct{ mock.Mock }
 
 func (m *MockWeatherProvider) GetWeather(ctx context.Context, city string) (domain.Weather, error) {
@@ -70,6 +133,23 @@ func (m *MockWeatherProvider) Name() string {
 	return args.String(0)
 }
 
+type MockSubscriptionService struct{ mock.Mock }
+
+func (m *MockSubscriptionService) CreateSubscription(ctx context.Context, opts out.CreateSubscriptionOptions) (string, error) {
+	args := m.Called(ctx, opts)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSubscriptionService) GetSubscriptionsByFrequency(ctx context.Context, frequency domain.Frequency) ([]domain.Subscription, error) {
+	args := m.Called(ctx, frequency)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionService) GetDueSubscriptions(ctx context.Context, now time.Time) ([]domain.Subscription, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
 type MockWeatherService struct{ mock.Mock }
 
 func (s *MockWeatherService) GetWeather(ctx context.Context, city string) (domain.Weather, error) {
@@ -81,8 +161,8 @@ type MockEmailService struct {
 	mock.Mock
 }
 
-func (m *MockEmailService) SendEmail(opts out.SendEmailOptions) error {
-	args := m.Called(opts)
+func (m *MockEmailService) SendEmail(ctx context.Context, opts out.SendEmailOptions) error {
+	args := m.Called(ctx, opts)
 	return args.Error(0)
 }
 
@@ -110,6 +190,10 @@ func (m *MockCityRepo) Create(ctx context.Context, city domain.City) (domain.Cit
 	args := m.Called(ctx, city)
 	return args.Get(0).(domain.City), args.Error(1)
 }
+func (m *MockCityRepo) ListWithSubscriberCounts(ctx context.Context) ([]domain.CityStats, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.CityStats), args.Error(1)
+}
 
 type MockWeatherCache struct{ mock.Mock }
 
@@ -130,3 +214,214 @@ func (m *MockWeatherCache) Close() error {
 	args := m.Called()
 	return args.Error(0)
 }
+
+type MockAlertProvider struct{ mock.Mock }
+
+func (m *MockAlertProvider) GetAlerts(ctx context.Context, city string) ([]domain.Alert, error) {
+	args := m.Called(ctx, city)
+	return args.Get(0).([]domain.Alert), args.Error(1)
+}
+
+type MockAlertRepository struct{ mock.Mock }
+
+func (m *MockAlertRepository) Save(ctx context.Context, alert domain.Alert) (int64, bool, error) {
+	args := m.Called(ctx, alert)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
+
+func (m *MockAlertRepository) GetDeliveredRecipients(ctx context.Context, alertID int64) (map[string]bool, error) {
+	args := m.Called(ctx, alertID)
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
+func (m *MockAlertRepository) MarkDelivered(ctx context.Context, alertID int64, recipient string) error {
+	args := m.Called(ctx, alertID, recipient)
+	return args.Error(0)
+}
+
+type MockSendTriggerRepository struct{ mock.Mock }
+
+func (m *MockSendTriggerRepository) Enqueue(ctx context.Context, frequency domain.Frequency) (bool, error) {
+	args := m.Called(ctx, frequency)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSendTriggerRepository) TakePending(ctx context.Context) ([]domain.Frequency, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Frequency), args.Error(1)
+}
+
+type MockWeatherObservationRepository struct{ mock.Mock }
+
+func (m *MockWeatherObservationRepository) SaveObservation(ctx context.Context, subscriptionID int64, observation domain.WeatherObservation) error {
+	args := m.Called(ctx, subscriptionID, observation)
+	return args.Error(0)
+}
+
+func (m *MockWeatherObservationRepository) TakeObservations(ctx context.Context, subscriptionID int64) ([]domain.WeatherObservation, error) {
+	args := m.Called(ctx, subscriptionID)
+	return args.Get(0).([]domain.WeatherObservation), args.Error(1)
+}
+
+type MockLocker struct{ mock.Mock }
+
+func (m *MockLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (out.Lease, error) {
+	args := m.Called(ctx, name, ttl)
+	lease, _ := args.Get(0).(out.Lease)
+	return lease, args.Error(1)
+}
+
+type MockLease struct{ mock.Mock }
+
+func (m *MockLease) Renew(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockLease) Release(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockHealthCheck struct{ mock.Mock }
+
+func (m *MockHealthCheck) Name() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockHealthCheck) Check(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockDeliveryRepository struct{ mock.Mock }
+
+func (m *MockDeliveryRepository) RecordDelivery(ctx context.Context, delivery domain.Delivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepository) GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.Delivery, error) {
+	args := m.Called(ctx, subscriptionID, limit)
+	return args.Get(0).([]domain.Delivery), args.Error(1)
+}
+
+type MockAuditLogRepository struct{ mock.Mock }
+
+func (m *MockAuditLogRepository) Record(ctx context.Context, entry domain.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+type MockSuppressionRepository struct{ mock.Mock }
+
+func (m *MockSuppressionRepository) Suppress(ctx context.Context, suppression domain.Suppression) error {
+	args := m.Called(ctx, suppression)
+	return args.Error(0)
+}
+
+func (m *MockSuppressionRepository) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockWebhookRepository struct{ mock.Mock }
+
+func (m *MockWebhookRepository) SaveWebhook(ctx context.Context, subscriptionID int64, webhook domain.Webhook) error {
+	args := m.Called(ctx, subscriptionID, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, subscriptionID int64) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RecordFailure(ctx context.Context, subscriptionID int64) (int, error) {
+	args := m.Called(ctx, subscriptionID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ResetFailures(ctx context.Context, subscriptionID int64) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) DisableWebhook(ctx context.Context, subscriptionID int64) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RecordDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+type MockWebhookSender struct{ mock.Mock }
+
+func (m *MockWebhookSender) Send(ctx context.Context, webhook domain.Webhook, message domain.WebhookMessage) (int, error) {
+	args := m.Called(ctx, webhook, message)
+	return args.Int(0), args.Error(1)
+}
+
+type MockPushRepository struct{ mock.Mock }
+
+func (m *MockPushRepository) SavePushSubscription(ctx context.Context, subscriptionID int64, push domain.PushSubscription) error {
+	args := m.Called(ctx, subscriptionID, push)
+	return args.Error(0)
+}
+
+func (m *MockPushRepository) DeletePushSubscription(ctx context.Context, subscriptionID int64) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockPushRepository) ExpirePushSubscription(ctx context.Context, subscriptionID int64) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+type MockPushSender struct{ mock.Mock }
+
+func (m *MockPushSender) Send(ctx context.Context, subscription domain.PushSubscription, notification domain.PushNotification) (int, error) {
+	args := m.Called(ctx, subscription, notification)
+	return args.Int(0), args.Error(1)
+}
+
+type MockAPIKeyRepository struct{ mock.Mock }
+
+func (m *MockAPIKeyRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByID(ctx context.Context, id int64, month time.Time) (domain.APIKey, error) {
+	args := m.Called(ctx, id, month)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string, month time.Time) (domain.APIKey, error) {
+	args := m.Called(ctx, hash, month)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListAPIKeys(ctx context.Context, month time.Time) ([]domain.APIKey, error) {
+	args := m.Called(ctx, month)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) RevokeAPIKey(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) IncrementAPIKeyUsage(ctx context.Context, id int64, month time.Time) (int64, error) {
+	args := m.Called(ctx, id, month)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyUsage(ctx context.Context, id int64) ([]domain.APIKeyUsage, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]domain.APIKeyUsage), args.Error(1)
+}
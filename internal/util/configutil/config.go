@@ -1,7 +1,11 @@
 package configutil
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"time"
+	"weather-api/internal/util/cronutil"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -12,10 +16,13 @@ type Config struct {
 	WeatherAPIBaseURL     string        `envconfig:"WEATHER_API_BASE_URL" default:"http://api.weatherapi.com/v1"`
 	OpenWeatherMapAPIKey  string        `envconfig:"OPENWEATHERMAP_API_KEY" required:"true"`
 	OpenWeatherMapBaseURL string        `envconfig:"OPENWEATHERMAP_BASE_URL" default:"https://api.openweathermap.org/data/2.5"`
-	SMTPHost              string        `envconfig:"SMTP_HOST" required:"true"`
+	WeatherAPIQuota       int64         `envconfig:"WEATHER_API_MONTHLY_QUOTA" default:"1000000"`
+	OpenWeatherMapQuota   int64         `envconfig:"OPENWEATHERMAP_MONTHLY_QUOTA" default:"0"`
+	ProviderQuotaCutoff   float64       `envconfig:"PROVIDER_QUOTA_CUTOFF" default:"0.95"`
+	SMTPHost              string        `envconfig:"SMTP_HOST"`
 	SMTPPort              int           `envconfig:"SMTP_PORT" default:"587"`
-	SMTPUser              string        `envconfig:"SMTP_USER" required:"true"`
-	SMTPPass              string        `envconfig:"SMTP_PASS" required:"true"`
+	SMTPUser              string        `envconfig:"SMTP_USER"`
+	SMTPPass              string        `envconfig:"SMTP_PASS"`
 	Port                  int           `envconfig:"PORT" default:"8080"`
 	BaseURL               string        `envconfig:"BASE_URL" default:"http://localhost:8080"`
 	HTTPReadTimeout       time.Duration `envconfig:"HTTP_READ_TIMEOUT" default:"10s"`
@@ -27,7 +34,65 @@ type Config struct {
 	RedisWriteTimeout     time.Duration `envconfig:"REDIS_WRITE_TIMEOUT" default:"3s"`
 	RedisPoolSize         int           `envconfig:"REDIS_POOL_SIZE" default:"10"`
 	RedisMinIdleConns     int           `envconfig:"REDIS_MIN_IDLE_CONNS" default:"5"`
+	CacheHotKeys          int           `envconfig:"CACHE_HOT_KEYS" default:"0"`
 	HTTPClientTimeout     time.Duration `envconfig:"HTTP_CLIENT_TIMEOUT" default:"5s"`
+	ScheduleHourly        string        `envconfig:"SCHEDULE_HOURLY" default:"0 * * * *"`
+	ScheduleDaily         string        `envconfig:"SCHEDULE_DAILY" default:"0 0 * * *"`
+	ScheduleWeekly        string        `envconfig:"SCHEDULE_WEEKLY" default:"0 0 * * *"`
+	ScheduleWeekdays      string        `envconfig:"SCHEDULE_WEEKDAYS" default:"0 0 * * 1-5"`
+	DispatchSchedule      string        `envconfig:"DISPATCH_SCHEDULE" default:"* * * * *"`
+	AlertsSchedule        string        `envconfig:"ALERTS_SCHEDULE" default:"*/15 * * * *"`
+	TriggeredSendSchedule string        `envconfig:"TRIGGERED_SEND_SCHEDULE" default:"@every 15s"`
+	LeaderElection        string        `envconfig:"LEADER_ELECTION" default:"postgres"`
+	LeaderLockTTL         time.Duration `envconfig:"LEADER_LOCK_TTL" default:"30s"`
+	InstanceID            string        `envconfig:"INSTANCE_ID"`
+	EmailWorkers          int           `envconfig:"EMAIL_WORKERS" default:"4"`
+	ShutdownTimeout       time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
+	EmailDrainTimeout     time.Duration `envconfig:"EMAIL_DRAIN_TIMEOUT" default:"10s"`
+	HealthCheckTimeout    time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"3s"`
+	HealthCacheTTL        time.Duration `envconfig:"HEALTH_CACHE_TTL" default:"10s"`
+	HealthProviderTTL     time.Duration `envconfig:"HEALTH_PROVIDER_CACHE_TTL" default:"5m"`
+	HealthProviderCity    string        `envconfig:"HEALTH_PROVIDER_CITY" default:"London"`
+	TrustedProxies        []string      `envconfig:"TRUSTED_PROXIES"`
+	RateLimitWeather      string        `envconfig:"RATE_LIMIT_WEATHER" default:"60/1m"`
+	RateLimitAlerts       string        `envconfig:"RATE_LIMIT_ALERTS" default:"60/1m"`
+	RateLimitSubscribeIP  string        `envconfig:"RATE_LIMIT_SUBSCRIBE_IP" default:"20/1h"`
+	RateLimitRecipient    string        `envconfig:"RATE_LIMIT_SUBSCRIBE_EMAIL" default:"3/1h"`
+	APIKeyRequired        bool          `envconfig:"API_KEY_REQUIRED" default:"false"`
+	AdminAPIKey           string        `envconfig:"ADMIN_API_KEY"`
+	AdminUsername         string        `envconfig:"ADMIN_USERNAME"`
+	AdminPassword         string        `envconfig:"ADMIN_PASSWORD"`
+	AutoMigrate           bool          `envconfig:"AUTO_MIGRATE" default:"true"`
+	EmailBackend          string        `envconfig:"EMAIL_BACKEND" default:"smtp"`
+	EmailFrom             string        `envconfig:"EMAIL_FROM"`
+	EmailMaildir          string        `envconfig:"EMAIL_MAILDIR" default:"maildir"`
+	EmailAPIEndpoint      string        `envconfig:"EMAIL_API_ENDPOINT"`
+	EmailAPIAuthHeader    string        `envconfig:"EMAIL_API_AUTH_HEADER" default:"Authorization"`
+	EmailAPIKey           string        `envconfig:"EMAIL_API_KEY"`
+	BounceWebhookSecret   string        `envconfig:"BOUNCE_WEBHOOK_SECRET"`
+	BounceMailboxDir      string        `envconfig:"BOUNCE_MAILBOX_DIR"`
+	BounceSchedule        string        `envconfig:"BOUNCE_SCHEDULE" default:"*/5 * * * *"`
+	WebhookMaxAttempts    int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"5"`
+	WebhookInitialBackoff time.Duration `envconfig:"WEBHOOK_INITIAL_BACKOFF" default:"1s"`
+	WebhookMaxBackoff     time.Duration `envconfig:"WEBHOOK_MAX_BACKOFF" default:"30s"`
+	WebhookRetryBudget    time.Duration `envconfig:"WEBHOOK_RETRY_BUDGET" default:"20s"`
+	WebhookDisableAfter   int           `envconfig:"WEBHOOK_DISABLE_AFTER" default:"10"`
+	WebhookTimeout        time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	TelegramBotToken      string        `envconfig:"TELEGRAM_BOT_TOKEN"`
+	TelegramAPIBaseURL    string        `envconfig:"TELEGRAM_API_BASE_URL" default:"https://api.telegram.org"`
+	TelegramMode          string        `envconfig:"TELEGRAM_MODE" default:"polling"`
+	TelegramWebhookSecret string        `envconfig:"TELEGRAM_WEBHOOK_SECRET"`
+	TelegramPollTimeout   time.Duration `envconfig:"TELEGRAM_POLL_TIMEOUT" default:"30s"`
+	VAPIDPublicKey        string        `envconfig:"VAPID_PUBLIC_KEY"`
+	VAPIDPrivateKey       string        `envconfig:"VAPID_PRIVATE_KEY"`
+	VAPIDSubject          string        `envconfig:"VAPID_SUBJECT"`
+	PushTTL               time.Duration `envconfig:"PUSH_TTL" default:"12h"`
+	LogLevel              string        `envconfig:"LOG_LEVEL" default:"info"`
+	LogFormat             string        `envconfig:"LOG_FORMAT" default:"text"`
+	LogRedact             bool          `envconfig:"LOG_REDACT" default:"true"`
+	TracingExporter       string        `envconfig:"TRACING_EXPORTER" default:"none"`
+	TracingOTLPEndpoint   string        `envconfig:"TRACING_OTLP_ENDPOINT" default:"http://localhost:4318"`
+	TracingSampleRatio    float64       `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -36,9 +101,109 @@ func LoadConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := cfg.ValidateSchedules(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateEmail(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateTelegram(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidatePush(); err != nil {
+		return nil, err
+	}
+	if cfg.EmailFrom == "" {
+		cfg.EmailFrom = cfg.SMTPUser
+	}
+	if cfg.LeaderLockTTL < 3*time.Second {
+		return nil, fmt.Errorf("invalid LEADER_LOCK_TTL: %s is shorter than 3s", cfg.LeaderLockTTL)
+	}
+	if cfg.ProviderQuotaCutoff <= 0 || cfg.ProviderQuotaCutoff > 1 {
+		return nil, fmt.Errorf("invalid PROVIDER_QUOTA_CUTOFF: %g is not in (0, 1]", cfg.ProviderQuotaCutoff)
+	}
+	if cfg.TracingSampleRatio < 0 || cfg.TracingSampleRatio > 1 {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO: %g is not between 0 and 1", cfg.TracingSampleRatio)
+	}
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("unable to determine INSTANCE_ID: %w", err)
+		}
+	}
 	return &cfg, nil
 }
 
+// EmailBackends returns the configured email backends in failover order.
+func (c *Config) EmailBackends() []string {
+	var backends []string
+	for _, backend := range strings.Split(c.EmailBackend, ",") {
+		if backend = strings.TrimSpace(backend); backend != "" {
+			backends = append(backends, backend)
+		}
+	}
+	return backends
+}
+
+// ValidateEmail checks that every email backend is known and has the settings it needs.
+func (c *Config) ValidateEmail() error {
+	backends := c.EmailBackends()
+	if len(backends) == 0 {
+		return fmt.Errorf("invalid EMAIL_BACKEND: at least one backend is required")
+	}
+	for _, backend := range backends {
+		switch backend {
+		case "smtp":
+			if c.SMTPHost == "" || c.SMTPUser == "" || c.SMTPPass == "" {
+				return fmt.Errorf("invalid EMAIL_BACKEND: smtp requires SMTP_HOST, SMTP_USER and SMTP_PASS")
+			}
+		case "http":
+			if c.EmailAPIEndpoint == "" {
+				return fmt.Errorf("invalid EMAIL_BACKEND: http requires EMAIL_API_ENDPOINT")
+			}
+		case "maildir", "log":
+		default:
+			return fmt.Errorf("invalid EMAIL_BACKEND: unsupported backend %q", backend)
+		}
+	}
+	return nil
+}
+
+// ValidateTelegram checks the bot settings when TELEGRAM_BOT_TOKEN is set.
+func (c *Config) ValidateTelegram() error {
+	if c.TelegramBotToken == "" {
+		return nil
+	}
+	switch c.TelegramMode {
+	case "polling":
+	case "webhook":
+		if c.TelegramWebhookSecret == "" {
+			return fmt.Errorf("invalid TELEGRAM_MODE: webhook requires TELEGRAM_WEBHOOK_SECRET")
+		}
+	default:
+		return fmt.Errorf("invalid TELEGRAM_MODE: expected polling or webhook, got %q", c.TelegramMode)
+	}
+	return nil
+}
+
+// PushEnabled reports whether VAPID keys are configured for Web Push.
+func (c *Config) PushEnabled() bool {
+	return c.VAPIDPublicKey != "" || c.VAPIDPrivateKey != ""
+}
+
+// ValidatePush checks that VAPID settings are complete when Web Push is enabled.
+func (c *Config) ValidatePush() error {
+	if !c.PushEnabled() {
+		return nil
+	}
+	if c.VAPIDPublicKey == "" || c.VAPIDPrivateKey == "" {
+		return fmt.Errorf("invalid VAPID keys: VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY must be set together")
+	}
+	if !strings.HasPrefix(c.VAPIDSubject, "mailto:") && !strings.HasPrefix(c.VAPIDSubject, "https://") {
+		return fmt.Errorf("invalid VAPID_SUBJECT: expected a mailto: or https: URL")
+	}
+	return nil
+}
+
 func GetBaseURL() string {
 	var cfg Config
 	if err := envconfig.Process("", &cfg); err != nil {
@@ -46,3 +211,41 @@ func GetBaseURL() string {
 	}
 	return cfg.BaseURL
 }
+
+// ValidateSchedules checks that every configured cron expression parses and
+// that frequency schedules do not fire more often than their name promises.
+func (c *Config) ValidateSchedules() error {
+	weekly, err := cronutil.WithWeekday(c.ScheduleWeekly, time.Monday)
+	if err != nil {
+		return fmt.Errorf("invalid SCHEDULE_WEEKLY: %w", err)
+	}
+
+	schedules := []struct {
+		env         string
+		spec        string
+		minInterval time.Duration
+	}{
+		{env: "SCHEDULE_HOURLY", spec: c.ScheduleHourly, minInterval: time.Hour},
+		{env: "SCHEDULE_DAILY", spec: c.ScheduleDaily, minInterval: 24 * time.Hour},
+		{env: "SCHEDULE_WEEKLY", spec: weekly, minInterval: 7 * 24 * time.Hour},
+		{env: "SCHEDULE_WEEKDAYS", spec: c.ScheduleWeekdays, minInterval: 24 * time.Hour},
+		{env: "DISPATCH_SCHEDULE", spec: c.DispatchSchedule},
+		{env: "ALERTS_SCHEDULE", spec: c.AlertsSchedule},
+		{env: "TRIGGERED_SEND_SCHEDULE", spec: c.TriggeredSendSchedule},
+		{env: "BOUNCE_SCHEDULE", spec: c.BounceSchedule},
+	}
+
+	now := time.Now().UTC()
+	for _, schedule := range schedules {
+		interval, err := cronutil.MinInterval(schedule.spec, now)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", schedule.env, err)
+		}
+		if interval < schedule.minInterval {
+			return fmt.Errorf("invalid %s: %q fires every %s, expected at most once per %s",
+				schedule.env, schedule.spec, interval, schedule.minInterval)
+		}
+	}
+
+	return nil
+}
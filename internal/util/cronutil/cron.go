@@ -0,0 +1,73 @@
+package cronutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const intervalSamples = 24
+
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+func Parse(spec string) (cron.Schedule, error) {
+	schedule, err := parser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parse cron expression %q: %w", spec, err)
+	}
+	return schedule, nil
+}
+
+func Next(spec string, after time.Time) (time.Time, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := schedule.Next(after)
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never fires", spec)
+	}
+	return next, nil
+}
+
+func MinInterval(spec string, from time.Time) (time.Duration, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return 0, err
+	}
+
+	prev := schedule.Next(from)
+	if prev.IsZero() {
+		return 0, fmt.Errorf("cron expression %q never fires", spec)
+	}
+
+	var minInterval time.Duration
+	for i := 0; i < intervalSamples; i++ {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if interval := next.Sub(prev); minInterval == 0 || interval < minInterval {
+			minInterval = interval
+		}
+		prev = next
+	}
+	return minInterval, nil
+}
+
+// WithWeekday replaces the day-of-week field of a five-field expression.
+// The field must be "*" so the configured schedule cannot contradict the weekday.
+func WithWeekday(spec string, weekday time.Weekday) (string, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("cron expression %q must have five fields", spec)
+	}
+	if fields[4] != "*" {
+		return "", fmt.Errorf("cron expression %q must use * as day of week", spec)
+	}
+	fields[4] = strconv.Itoa(int(weekday))
+	return strings.Join(fields, " "), nil
+}
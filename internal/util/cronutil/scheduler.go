@@ -0,0 +1,66 @@
+package cronutil
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Scheduler wraps cron.Cron with panic recovery, overlap protection and
+// named jobs so the next run times can be logged at startup.
+type Scheduler struct {
+	cron   *cron.Cron
+	names  map[cron.EntryID]string
+	logger *slog.Logger
+}
+
+func NewScheduler(logger *slog.Logger) *Scheduler {
+	cronLogger := slogCronLogger{logger: logger.With("component", "cron")}
+	return &Scheduler{
+		cron: cron.New(
+			cron.WithParser(parser),
+			cron.WithLogger(cronLogger),
+			cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)),
+		),
+		names:  make(map[cron.EntryID]string),
+		logger: logger,
+	}
+}
+
+func (s *Scheduler) AddJob(name, spec string, job func()) error {
+	id, err := s.cron.AddFunc(spec, job)
+	if err != nil {
+		return fmt.Errorf("add %s job: %w", name, err)
+	}
+	s.names[id] = name
+	return nil
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+	for _, entry := range s.cron.Entries() {
+		s.logger.Info("scheduled job", "job", s.names[entry.ID], "next_run", entry.Next.Format(time.RFC3339))
+	}
+}
+
+// Stop prevents new runs and returns a context that is done once running jobs finish.
+func (s *Scheduler) Stop() context.Context {
+	return s.cron.Stop()
+}
+
+// slogCronLogger adapts slog to cron.Logger. Cron's own bookkeeping (wake,
+// run, schedule) is logged at debug level.
+type slogCronLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogCronLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(msg, keysAndValues...)
+}
+
+func (l slogCronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.logger.Error(msg, append(keysAndValues, "error", err)...)
+}
@@ -1,13 +1,16 @@
 package emailutil
 
 import (
+	"html"
 	"strconv"
+	"strings"
+	"time"
 	"weather-api/internal/util/configutil"
 )
 
 func BuildConfirmationEmail(city, token string) (subject, body string) {
 	baseURL := configutil.GetBaseURL()
-	confirmURL := baseURL + "/api/confirm/" + token
+	confirmURL := baseURL + "/api/v1/confirm/" + token
 	subject = "Confirm Subscription"
 	body = "<html><body>" +
 		"<p>Thank you for subscribing to weather updates for " + city + "!</p>" +
@@ -24,19 +27,91 @@ type WeatherUpdateEmailOptions struct {
 	Humidity    int
 	Description string
 	Token       string
+	Overnight   *OvernightSummaryEmailOptions
 }
 
-func BuildWeatherUpdateEmail(opts WeatherUpdateEmailOptions) (subject, body string) {
+type OvernightSummaryEmailOptions struct {
+	From           time.Time
+	To             time.Time
+	MinTemperature float64
+	MaxTemperature float64
+	Descriptions   []string
+}
+
+type WeatherDigestEmailOptions struct {
+	Cities          []WeatherUpdateEmailOptions
+	ManagementToken string
+}
+
+func BuildWeatherDigestEmail(opts WeatherDigestEmailOptions) (subject, body string) {
 	baseURL := configutil.GetBaseURL()
-	unsubscribeURL := baseURL + "/api/unsubscribe/" + opts.Token
 	subject = "Weather Update"
-	tempStr := strconv.FormatFloat(opts.Temperature, 'f', 2, 64)
-	humidStr := strconv.Itoa(opts.Humidity)
+
+	body = "<html><body>"
+	for _, city := range opts.Cities {
+		unsubscribeURL := baseURL + "/api/v1/unsubscribe/" + city.Token
+		tempStr := strconv.FormatFloat(city.Temperature, 'f', 2, 64)
+		humidStr := strconv.Itoa(city.Humidity)
+
+		body += "<p>Weather in " + city.City + ": Temp " + tempStr + "°C, Humidity " +
+			humidStr + "%, " + city.Description + "</p>"
+		if city.Overnight != nil {
+			body += buildOvernightSummary(city.City, city.Overnight)
+		}
+		body += `<p><a href="` + unsubscribeURL +
+			`" style="color: #0066cc; text-decoration: underline;">Unsubscribe from ` + city.City + `</a></p>`
+	}
+	if opts.ManagementToken != "" {
+		manageURL := baseURL + "/api/v1/subscriber/" + opts.ManagementToken
+		body += `<p><a href="` + manageURL +
+			`" style="color: #0066cc; text-decoration: underline;">Manage all subscriptions</a></p>`
+	}
+	body += "</body></html>"
+
+	return
+}
+
+func buildOvernightSummary(city string, summary *OvernightSummaryEmailOptions) string {
+	minStr := strconv.FormatFloat(summary.MinTemperature, 'f', 1, 64)
+	maxStr := strconv.FormatFloat(summary.MaxTemperature, 'f', 1, 64)
+
+	text := "<p><em>While you were sleeping in " + city + " (" + summary.From.Format("15:04") +
+		"–" + summary.To.Format("15:04") + "): " + minStr + "°C to " + maxStr + "°C"
+	if len(summary.Descriptions) > 0 {
+		text += ", " + html.EscapeString(strings.Join(summary.Descriptions, ", "))
+	}
+	return text + ".</em></p>"
+}
+
+type SevereWeatherAlertEmailOptions struct {
+	City        string
+	Headline    string
+	Event       string
+	Severity    string
+	Areas       string
+	Description string
+	Instruction string
+	Expires     time.Time
+	Token       string
+}
+
+func BuildSevereWeatherAlertEmail(opts SevereWeatherAlertEmailOptions) (subject, body string) {
+	baseURL := configutil.GetBaseURL()
+	unsubscribeURL := baseURL + "/api/v1/unsubscribe/" + opts.Token
+	subject = "Severe Weather Alert: " + opts.Event + " in " + opts.City
 
 	body = "<html><body>" +
-		"<p>Weather in " + opts.City + ": Temp " + tempStr + "°C, Humidity " +
-		humidStr + "%, " + opts.Description + "</p>" +
-		`<p><a href="` + unsubscribeURL +
+		"<h2>" + html.EscapeString(opts.Headline) + "</h2>" +
+		"<p><strong>Severity:</strong> " + html.EscapeString(opts.Severity) + "</p>" +
+		"<p><strong>Areas:</strong> " + html.EscapeString(opts.Areas) + "</p>" +
+		"<p>" + html.EscapeString(opts.Description) + "</p>"
+	if opts.Instruction != "" {
+		body += "<p><strong>Instructions:</strong> " + html.EscapeString(opts.Instruction) + "</p>"
+	}
+	if !opts.Expires.IsZero() {
+		body += "<p>In effect until " + opts.Expires.Format(time.RFC1123) + "</p>"
+	}
+	body += `<p><a href="` + unsubscribeURL +
 		`" style="color: #0066cc; text-decoration: underline;">Unsubscribe</a></p>` +
 		"</body></html>"
 
@@ -3,21 +3,22 @@ package logger
 import (
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
 )
 
 type FileLogger struct {
-	file *os.File
-	mu   sync.Mutex
+	file   *os.File
+	mu     sync.Mutex
+	logger *slog.Logger
 }
 
-func NewFileLogger(logDir, fileName string) (*FileLogger, error) {
+func NewFileLogger(logDir, fileName string, logger *slog.Logger) (*FileLogger, error) {
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		msg := fmt.Sprintf("unable to create log directory: %v", err)
-		log.Print(msg)
+		logger.Error(msg)
 		return nil, errors.New(msg)
 	}
 
@@ -25,12 +26,13 @@ func NewFileLogger(logDir, fileName string) (*FileLogger, error) {
 	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		msg := fmt.Sprintf("unable to open log file: %v", err)
-		log.Print(msg)
+		logger.Error(msg)
 		return nil, errors.New(msg)
 	}
 
 	return &FileLogger{
-		file: file,
+		file:   file,
+		logger: logger,
 	}, nil
 }
 
@@ -38,7 +40,7 @@ func (l *FileLogger) Log(providerName string, responseBody []byte) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	log.Printf("[%s] Response: %s", providerName, string(responseBody))
+	l.logger.Debug("provider response", "provider", providerName, "body", string(responseBody))
 	logMessage := fmt.Sprintf("[%s] Response: %s\n", providerName, string(responseBody))
 	_, _ = l.file.WriteString(logMessage)
 }
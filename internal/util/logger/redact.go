@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+\-])[A-Za-z0-9._%+\-]*@([A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+	// Subscription and management tokens are 32 random bytes in base64url.
+	tokenPattern = regexp.MustCompile(`[A-Za-z0-9_\-]{40,}={0,2}`)
+)
+
+// RedactString masks email addresses, keeping their first character and
+// domain, and removes anything shaped like a subscription token.
+func RedactString(s string) string {
+	s = emailPattern.ReplaceAllString(s, "$1***@$2")
+	return tokenPattern.ReplaceAllString(s, redacted)
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	if sensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactString(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		attrs := make([]any, len(group))
+		for i, member := range group {
+			attrs[i] = redactAttr(member)
+		}
+		return slog.Group(attr.Key, attrs...)
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, RedactString(err.Error()))
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "token") || strings.Contains(key, "secret") || strings.Contains(key, "password")
+}
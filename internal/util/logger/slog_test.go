@@ -0,0 +1,81 @@
+//go:build unit
+// +build unit
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeRecord(t *testing.T, buf *bytes.Buffer) map[string]any {
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record
+}
+
+func TestNew_RedactsEmailsAndTokens(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, Options{Level: "info", Format: "json", Redact: true})
+	require.NoError(t, err)
+
+	token := "q3Xb0Lk1_9f8GvPzN2mW4eR7tY6uI5oA1sD3fG5hJ7k="
+	log.Info("Sent confirmation to john.doe@example.com",
+		"email", "jane@example.org",
+		"token", token,
+		"error", errors.New("unknown token "+token),
+	)
+
+	record := decodeRecord(t, &buf)
+	assert.Equal(t, "Sent confirmation to j***@example.com", record["msg"])
+	assert.Equal(t, "j***@example.org", record["email"])
+	assert.Equal(t, "[REDACTED]", record["token"])
+	assert.Equal(t, "unknown token [REDACTED]", record["error"])
+}
+
+func TestNew_RedactionDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, Options{Format: "json"})
+	require.NoError(t, err)
+
+	log.Info("subscribed", "email", "jane@example.org")
+
+	assert.Equal(t, "jane@example.org", decodeRecord(t, &buf)["email"])
+}
+
+func TestNew_AddsRequestIDAndFiltersLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, Options{Level: "warn", Format: "json", Redact: true})
+	require.NoError(t, err)
+
+	ctx := WithRequestID(context.Background(), "abc-123")
+	log.InfoContext(ctx, "dropped")
+	assert.Zero(t, buf.Len())
+
+	log.With("component", "scheduler").WarnContext(ctx, "slow run")
+	record := decodeRecord(t, &buf)
+	assert.Equal(t, "abc-123", record["request_id"])
+	assert.Equal(t, "scheduler", record["component"])
+	assert.Equal(t, "WARN", record["level"])
+}
+
+func TestNew_RejectsUnknownOptions(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, Options{Level: "verbose"})
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, Options{Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("DEBUG")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, level)
+}
@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Options struct {
+	// Exporter is none, stdout or otlp.
+	Exporter string
+	// Endpoint is the OTLP/HTTP collector URL, e.g. http://localhost:4318.
+	Endpoint    string
+	ServiceName string
+	InstanceID  string
+	// SampleRatio is the share of new traces that are recorded (0..1).
+	// Incoming sampled traces are always continued.
+	SampleRatio float64
+	// Redact replaces raw URL paths of server spans with the route template.
+	Redact bool
+	// Output receives spans of the stdout exporter; defaults to stdout.
+	Output io.Writer
+}
+
+// Setup installs the global tracer provider and W3C trace context
+// propagation. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.ServiceInstanceID(opts.InstanceID),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithSpanProcessor(sanitizingProcessor{redactPaths: opts.Redact}),
+		sdktrace.WithBatcher(exporter),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(opts.Exporter) {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		stdoutOpts := []stdouttrace.Option{stdouttrace.WithPrettyPrint()}
+		if opts.Output != nil {
+			stdoutOpts = append(stdoutOpts, stdouttrace.WithWriter(opts.Output))
+		}
+		exporter, err := stdouttrace.New(stdoutOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("create stdout trace exporter: %w", err)
+		}
+		return exporter, nil
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.Endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("create OTLP trace exporter for %s: %w", opts.Endpoint, err)
+		}
+		return exporter, nil
+	}
+	return nil, fmt.Errorf("unknown trace exporter %q", opts.Exporter)
+}
+
+// sanitizingProcessor keeps secrets out of exported spans. Query strings are
+// always dropped because provider requests carry the API key there; with
+// redaction on, url.path of server spans is replaced with http.route so
+// tokens in paths like /api/v1/confirm/:token are not exported either.
+type sanitizingProcessor struct {
+	redactPaths bool
+}
+
+func (p sanitizingProcessor) OnStart(_ context.Context, span sdktrace.ReadWriteSpan) {
+	var route string
+	var sanitized []attribute.KeyValue
+	hasPath := false
+	for _, attr := range span.Attributes() {
+		switch attr.Key {
+		case semconv.HTTPRouteKey:
+			route = attr.Value.AsString()
+		case semconv.URLPathKey:
+			hasPath = true
+		case semconv.URLQueryKey:
+			sanitized = append(sanitized, attr.Key.String(redacted))
+		case semconv.URLFullKey:
+			sanitized = append(sanitized, attr.Key.String(withoutQuery(attr.Value.AsString())))
+		}
+	}
+	if hasPath && p.redactPaths {
+		if route == "" {
+			route = "unmatched"
+		}
+		sanitized = append(sanitized, semconv.URLPath(route))
+	}
+	if len(sanitized) > 0 {
+		span.SetAttributes(sanitized...)
+	}
+}
+
+func (sanitizingProcessor) OnEnd(sdktrace.ReadOnlySpan)      {}
+func (sanitizingProcessor) Shutdown(context.Context) error   { return nil }
+func (sanitizingProcessor) ForceFlush(context.Context) error { return nil }
+
+const redacted = "[REDACTED]"
+
+func withoutQuery(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redacted
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = redacted
+	}
+	return u.String()
+}
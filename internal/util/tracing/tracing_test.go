@@ -0,0 +1,69 @@
+//go:build unit
+// +build unit
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func recordSpan(t *testing.T, redactPaths bool, attrs ...attribute.KeyValue) attribute.Set {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sanitizingProcessor{redactPaths: redactPaths}),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	_, span := provider.Tracer("test").Start(context.Background(), "span", trace.WithAttributes(attrs...))
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	return attribute.NewSet(spans[0].Attributes()...)
+}
+
+func TestSanitizingProcessor_DropsQueryFromClientURL(t *testing.T) {
+	attrs := recordSpan(t, false,
+		semconv.URLFull("https://api.weatherapi.com/v1/current.json?key=secret&q=Kyiv"),
+	)
+
+	full, _ := attrs.Value(semconv.URLFullKey)
+	assert.Equal(t, "https://api.weatherapi.com/v1/current.json?[REDACTED]", full.AsString())
+}
+
+func TestSanitizingProcessor_ReplacesPathWithRoute(t *testing.T) {
+	attrs := recordSpan(t, true,
+		semconv.URLPath("/api/v1/confirm/q3Xb0Lk1"),
+		semconv.URLQuery("token=q3Xb0Lk1"),
+		semconv.HTTPRoute("/api/v1/confirm/:token"),
+	)
+
+	path, _ := attrs.Value(semconv.URLPathKey)
+	query, _ := attrs.Value(semconv.URLQueryKey)
+	assert.Equal(t, "/api/v1/confirm/:token", path.AsString())
+	assert.Equal(t, "[REDACTED]", query.AsString())
+}
+
+func TestSanitizingProcessor_KeepsPathWithoutRedaction(t *testing.T) {
+	attrs := recordSpan(t, false, semconv.URLPath("/api/v1/weather"))
+
+	path, _ := attrs.Value(semconv.URLPathKey)
+	assert.Equal(t, "/api/v1/weather", path.AsString())
+}
+
+func TestSetup_NoneExporter(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{Exporter: ExporterNone})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), Options{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
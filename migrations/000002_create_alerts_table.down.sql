@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS alert_deliveries;
+DROP TABLE IF EXISTS alerts;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS severe_alerts;
\ No newline at end of file
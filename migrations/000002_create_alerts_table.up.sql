@@ -0,0 +1,26 @@
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS severe_alerts BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    city_id BIGINT NOT NULL REFERENCES cities(id) ON DELETE CASCADE,
+    fingerprint TEXT NOT NULL,
+    headline TEXT NOT NULL,
+    event TEXT NOT NULL,
+    severity TEXT NOT NULL,
+    urgency TEXT NOT NULL DEFAULT '',
+    areas TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    instruction TEXT NOT NULL DEFAULT '',
+    effective TIMESTAMPTZ,
+    expires TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE UNIQUE INDEX uniq_alert_city_fingerprint ON alerts(city_id, fingerprint);
+
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    alert_id BIGINT NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    recipient TEXT NOT NULL,
+    delivered_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (alert_id, recipient)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS subscriber_id;
+DROP TABLE IF EXISTS subscribers;
\ No newline at end of file
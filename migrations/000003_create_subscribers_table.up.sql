@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS subscribers (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    token TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- Same format as TokenService.GenerateToken: 32 random bytes, padded base64url.
+INSERT INTO subscribers (email, token)
+SELECT e.email, translate(encode(gen_random_bytes(32), 'base64'), '+/', '-_')
+FROM (SELECT DISTINCT email FROM subscriptions) e
+ON CONFLICT (email) DO NOTHING;
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS subscriber_id BIGINT REFERENCES subscribers(id) ON DELETE CASCADE;
+
+UPDATE subscriptions s
+SET subscriber_id = sb.id
+FROM subscribers sb
+WHERE sb.email = s.email AND s.subscriber_id IS NULL;
+
+ALTER TABLE subscriptions ALTER COLUMN subscriber_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_subscriber_id ON subscriptions(subscriber_id);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_subscriptions_next_run_at;
+
+DELETE FROM subscriptions WHERE frequency::text NOT IN ('daily', 'hourly');
+
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS last_sent_at,
+    DROP COLUMN IF EXISTS next_run_at,
+    DROP COLUMN IF EXISTS cron_expression,
+    DROP COLUMN IF EXISTS interval_hours,
+    DROP COLUMN IF EXISTS weekday;
@@ -0,0 +1,20 @@
+ALTER TYPE frequency_type ADD VALUE IF NOT EXISTS 'weekly';
+ALTER TYPE frequency_type ADD VALUE IF NOT EXISTS 'interval';
+ALTER TYPE frequency_type ADD VALUE IF NOT EXISTS 'weekdays';
+ALTER TYPE frequency_type ADD VALUE IF NOT EXISTS 'custom';
+
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS weekday SMALLINT CHECK (weekday BETWEEN 0 AND 6),
+    ADD COLUMN IF NOT EXISTS interval_hours SMALLINT CHECK (interval_hours BETWEEN 1 AND 23),
+    ADD COLUMN IF NOT EXISTS cron_expression TEXT,
+    ADD COLUMN IF NOT EXISTS next_run_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS last_sent_at TIMESTAMPTZ;
+
+UPDATE subscriptions
+SET next_run_at = CASE frequency::text
+    WHEN 'hourly' THEN date_trunc('hour', now()) + interval '1 hour'
+    ELSE date_trunc('day', now()) + interval '1 day'
+END
+WHERE is_confirmed = true AND next_run_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_next_run_at ON subscriptions(next_run_at) WHERE is_confirmed = true;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS quiet_hours_observations;
+
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS quiet_summary,
+    DROP COLUMN IF EXISTS quiet_end,
+    DROP COLUMN IF EXISTS quiet_start,
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,16 @@
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC',
+    ADD COLUMN IF NOT EXISTS quiet_start SMALLINT CHECK (quiet_start BETWEEN 0 AND 1439),
+    ADD COLUMN IF NOT EXISTS quiet_end SMALLINT CHECK (quiet_end BETWEEN 0 AND 1439),
+    ADD COLUMN IF NOT EXISTS quiet_summary BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS quiet_hours_observations (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    subscription_id BIGINT NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    temperature DOUBLE PRECISION NOT NULL,
+    humidity INTEGER NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    observed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiet_hours_observations_subscription_id ON quiet_hours_observations(subscription_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_subscriptions_lower_email;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS is_paused;
+
+DROP TABLE IF EXISTS email_suppressions;
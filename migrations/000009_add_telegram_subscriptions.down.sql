@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_subscriptions_telegram_chat_id;
+
+DELETE FROM subscriptions WHERE telegram_chat_id IS NOT NULL;
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS valid_email;
+ALTER TABLE subscriptions ADD CONSTRAINT valid_email CHECK (
+    email ~* '^[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}$'
+);
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS telegram_chat_id;
@@ -0,0 +1,9 @@
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS telegram_chat_id BIGINT;
+
+-- Telegram subscriptions are addressed to telegram:<chat id> instead of an email.
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS valid_email;
+ALTER TABLE subscriptions ADD CONSTRAINT valid_email CHECK (
+    telegram_chat_id IS NOT NULL OR email ~* '^[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}$'
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_telegram_chat_id ON subscriptions(telegram_chat_id) WHERE telegram_chat_id IS NOT NULL;
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS send_triggers (
+    frequency TEXT PRIMARY KEY,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
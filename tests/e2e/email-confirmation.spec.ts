@@ -45,7 +45,7 @@ test.describe('Email Confirmation Flow', () => {
   test('should handle invalid confirmation token', async ({ page }) => {
     await page.goto('/api/confirm/invalid-token-123');
     
-    await expect(page.locator('body')).toContainText('error');
+    await expect(page.locator('body')).toContainText('token_not_found');
   });
 
   test('should verify email template content', async ({ page }) => {
@@ -93,8 +93,8 @@ test.describe('Weather Subscription Page', () => {
     const secondResponse = await secondResponsePromise;
     
     const secondData = await secondResponse.json();
-    expect(secondData).toHaveProperty('error');
-    expect(secondData.error).toContain('email already subscribed');
+    expect(secondData.code).toBe('already_subscribed');
+    expect(secondData.detail).toContain('email already subscribed');
   });
 
   test('should verify email content and headers', async ({ page }) => {
@@ -159,7 +159,7 @@ func TestEmailIntegration_WithMailHog(t *testing.T) {
 				require.NoError(t, err)
 				require.Len(t, updates, 1)
 
-				err = ts.EmailService.SendUpdates(updates)
+				err = ts.EmailService.SendUpdates(context.Background(), updates)
 				require.NoError(t, err)
 
 				return []string{email}, city
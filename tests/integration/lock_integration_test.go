@@ -0,0 +1,47 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+	"weather-api/internal/adapter/lock"
+	"weather-api/internal/core/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	redisv9 "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLocker_SingleHolder(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+	client := redisv9.NewClient(&redisv9.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	first := lock.NewRedisLocker(client, "instance-a")
+	// A restart of the same instance must not be able to touch the old lease.
+	second := lock.NewRedisLocker(client, "instance-a")
+
+	lease, err := first.TryAcquire(ctx, "scheduler", time.Second)
+	require.NoError(t, err)
+
+	_, err = second.TryAcquire(ctx, "scheduler", time.Second)
+	assert.ErrorIs(t, err, domain.ErrLockNotAcquired)
+	require.NoError(t, lease.Renew(ctx))
+
+	s.FastForward(2 * time.Second)
+	assert.ErrorIs(t, lease.Renew(ctx), domain.ErrLockLost)
+
+	takeover, err := second.TryAcquire(ctx, "scheduler", time.Second)
+	require.NoError(t, err)
+
+	require.NoError(t, lease.Release(ctx))
+	require.NoError(t, takeover.Renew(ctx))
+	require.NoError(t, takeover.Release(ctx))
+}
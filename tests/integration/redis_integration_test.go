@@ -14,6 +14,7 @@ import (
 	corecache "weather-api/internal/adapter/cache/core"
 	"weather-api/internal/adapter/cache/core/redis"
 	weathercache "weather-api/internal/adapter/cache/weather"
+	"weather-api/internal/core/domain"
 )
 
 func defaultRedisOptions() redis.CacheOptions {
@@ -153,3 +154,16 @@ func TestWeatherCache_RedisError(t *testing.T) {
 	require.True(t, errors.As(err, &cacheErr))
 	require.Equal(t, corecache.RedisError, cacheErr.Code)
 }
+
+func TestWeatherCache_AlertsKeyIsNormalized(t *testing.T) {
+	cache, cleanup := newWeatherCache(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	require.NoError(t, cache.SetAlerts(ctx, " Kyiv", []domain.Alert{{Headline: "Storm warning"}}))
+
+	alerts, found, err := cache.GetAlerts(ctx, "kyiv ")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, alerts, 1)
+}
@@ -13,6 +13,8 @@ import (
 	"os"
 	"testing"
 	httphandler "weather-api/internal/adapter/handler/http"
+	"weather-api/internal/adapter/handler/http/middleware"
+	"weather-api/internal/util/logger"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -41,11 +43,12 @@ func setupSubscriptionTestServer(t *testing.T) *subscriptionTestServer {
 		services.SubscribeUseCase,
 		services.ConfirmUseCase,
 		services.UnsubscribeUseCase,
+		logger.Discard(),
 	)
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	router.Use(gin.Recovery())
+	router.Use(gin.Recovery(), middleware.Problems(logger.Discard()))
 
 	api := router.Group("/api")
 	{
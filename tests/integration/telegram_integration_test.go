@@ -0,0 +1,70 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"weather-api/internal/adapter/repository/postgres"
+	"weather-api/internal/adapter/telegram"
+	"weather-api/internal/core/usecase"
+	"weather-api/internal/util/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type botReplies struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (b *botReplies) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Text string `json:"text"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+	b.mu.Lock()
+	b.texts = append(b.texts, payload.Text)
+	b.mu.Unlock()
+	_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{}})
+}
+
+func TestTelegramBot_SubscribesChat(t *testing.T) {
+	services := SetupTestServices(t)
+	defer services.Cleanup()
+
+	replies := &botReplies{}
+	server := httptest.NewServer(replies)
+	defer server.Close()
+
+	client := telegram.NewClient(telegram.ClientOptions{BaseURL: server.URL, Token: "test-token", HTTPClient: server.Client()})
+	chats := usecase.NewTelegramChatUseCase(
+		postgres.NewSubscriberRepository(services.DB, logger.Discard()),
+		postgres.NewSubscriptionRepo(services.DB, logger.Discard()),
+		nil,
+		logger.Discard(),
+	)
+	bot := telegram.NewBot(client, services.SubscribeUseCase, services.ConfirmUseCase, nil, chats, logger.Discard())
+
+	ctx := context.Background()
+	bot.HandleUpdate(ctx, telegram.Update{UpdateID: 1, Message: &telegram.Message{Chat: telegram.Chat{ID: 4242}, Text: "/subscribe Kyiv daily"}})
+
+	var email string
+	var chatID int64
+	var confirmed bool
+	err := services.DB.QueryRow(`SELECT email, telegram_chat_id, is_confirmed FROM subscriptions`).Scan(&email, &chatID, &confirmed)
+	require.NoError(t, err)
+	assert.Equal(t, "telegram:4242", email)
+	assert.Equal(t, int64(4242), chatID)
+	assert.True(t, confirmed)
+
+	replies.mu.Lock()
+	defer replies.mu.Unlock()
+	assert.Equal(t, []string{"Subscribed to daily updates for Kyiv"}, replies.texts)
+}
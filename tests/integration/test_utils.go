@@ -18,6 +18,7 @@ import (
 	"weather-api/internal/core/ports/out"
 	"weather-api/internal/core/service"
 	"weather-api/internal/core/usecase"
+	"weather-api/internal/util/logger"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/stretchr/testify/require"
@@ -126,7 +127,7 @@ func SetupTestDatabase(t *testing.T) *sql.DB {
 }
 
 func CleanupTestDatabase(t *testing.T, db *sql.DB) {
-	tables := []string{"subscriptions", "cities"}
+	tables := []string{"subscriptions", "subscribers", "cities"}
 
 	for _, table := range tables {
 		_, err := db.Exec(fmt.Sprintf("DELETE FROM %s", table))
@@ -186,26 +187,31 @@ func SetupTestServices(t *testing.T) *TestServices {
 	mockLogger := &MockLogger{}
 
 	weatherAdapter := weatherapi.NewClient(weatherapi.ClientOptions{
-		APIKey:     testConfig.WeatherAPIKey,
-		BaseURL:    "http://api.weatherapi.com/v1",
-		HTTPClient: &MockHTTPClient{},
-		Logger:     mockLogger,
+		APIKey:         testConfig.WeatherAPIKey,
+		BaseURL:        "http://api.weatherapi.com/v1",
+		HTTPClient:     &MockHTTPClient{},
+		ResponseLogger: mockLogger,
+		Logger:         logger.Discard(),
 	})
 
-	subscriptionRepo := postgres.NewSubscriptionRepo(db)
-	cityRepo := postgres.NewCityRepository(db)
+	subscriptionRepo := postgres.NewSubscriptionRepo(db, logger.Discard())
+	cityRepo := postgres.NewCityRepository(db, logger.Discard())
+	subscriberRepo := postgres.NewSubscriberRepository(db, logger.Discard())
+	observationRepo := postgres.NewWeatherObservationRepository(db, logger.Discard())
+	suppressionRepo := postgres.NewSuppressionRepository(db, logger.Discard())
 
 	weatherService := service.NewWeatherService(weatherAdapter)
-	tokenService := service.NewTokenService(subscriptionRepo)
-	emailService := service.NewEmailService(emailAdapter)
+	tokenService := service.NewTokenService(subscriptionRepo, logger.Discard())
+	emailService := service.NewEmailService(emailAdapter, suppressionRepo, subscriptionRepo, logger.Discard())
 
-	cityService := service.NewCityService(cityRepo, weatherAdapter)
-	subscriptionService := service.NewSubscriptionService(subscriptionRepo, cityRepo, weatherAdapter, tokenService, emailService)
-	subscribeUseCase := usecase.NewSubscribeUseCase(subscriptionRepo, subscriptionService, cityService, emailService)
-	confirmUseCase := usecase.NewConfirmSubscriptionUseCase(subscriptionRepo, tokenService, emailService)
-	unsubscribeUseCase := usecase.NewUnsubscribeUseCase(subscriptionRepo, tokenService)
+	cityService := service.NewCityService(cityRepo, weatherAdapter, logger.Discard())
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, cityRepo, weatherAdapter, tokenService, emailService, logger.Discard())
+	subscriberService := service.NewSubscriberService(subscriberRepo, tokenService, logger.Discard())
+	subscribeUseCase := usecase.NewSubscribeUseCase(subscriptionRepo, subscriptionService, subscriberService, cityService, emailService, nil, logger.Discard())
+	confirmUseCase := usecase.NewConfirmSubscriptionUseCase(subscriptionRepo, tokenService, emailService, service.DefaultSchedules(), nil, logger.Discard())
+	unsubscribeUseCase := usecase.NewUnsubscribeUseCase(subscriptionRepo, tokenService, nil, logger.Discard())
 
-	weatherUpdateService := service.NewWeatherUpdateService(subscriptionService, weatherService)
+	weatherUpdateService := service.NewWeatherUpdateService(subscriptionService, weatherService, observationRepo, logger.Discard())
 
 	return &TestServices{
 		DB:                   db,
@@ -14,7 +14,9 @@ import (
 	"os"
 	"testing"
 	httphandler "weather-api/internal/adapter/handler/http"
+	"weather-api/internal/adapter/handler/http/middleware"
 	"weather-api/internal/core/domain"
+	"weather-api/internal/util/logger"
 )
 
 type weatherTestServer struct {
@@ -37,7 +39,7 @@ func setupWeatherTestServer(t *testing.T) *weatherTestServer {
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	router.Use(gin.Recovery())
+	router.Use(gin.Recovery(), middleware.Problems(logger.Discard()))
 
 	weatherHandler := httphandler.NewWeatherHandler(services.WeatherService)
 	api := router.Group("/api")
@@ -104,7 +106,8 @@ func TestWeatherEndpoint_Integration(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "city parameter is required", response["error"])
+		assert.Equal(t, "validation_failed", response["code"])
+		assert.Equal(t, "city parameter is required", response["detail"])
 	})
 
 	t.Run("GetWeather - Different Cities", func(t *testing.T) {
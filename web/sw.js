@@ -0,0 +1,15 @@
+// Service worker for Web Push weather updates. Served from /sw.js so its
+// scope covers every page of the site.
+
+self.addEventListener('push', (event) => {
+    const data = event.data ? event.data.json() : {};
+    event.waitUntil(self.registration.showNotification(data.title || 'Weather update', {
+        body: data.body,
+        data: { url: data.url || '/' },
+    }));
+});
+
+self.addEventListener('notificationclick', (event) => {
+    event.notification.close();
+    event.waitUntil(clients.openWindow(event.notification.data.url));
+});